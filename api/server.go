@@ -0,0 +1,244 @@
+package api
+
+import (
+	_ "embed"
+	"errors"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+//go:embed dashboard.html
+var dashboard []byte
+
+type BakerStatus struct {
+	Name               string        `json:"name"`
+	BakerPKH           tezos.Address `json:"baker"`
+	PayoutPKH          tezos.Address `json:"payout_wallet"`
+	LastProcessedCycle int64         `json:"last_processed_cycle"`
+	LastError          string        `json:"last_error,omitempty"`
+}
+
+type ContinualStatus struct {
+	BakerPKH              tezos.Address `json:"baker"`
+	PayoutPKH             tezos.Address `json:"payout_wallet"`
+	Bakers                []BakerStatus `json:"bakers,omitempty"`
+	CurrentCycle          int64         `json:"current_cycle"`
+	LastCompletedCycle    int64         `json:"last_completed_cycle"`
+	LastProcessedCycle    int64         `json:"last_processed_cycle"`
+	PendingCycle          int64         `json:"pending_cycle"`
+	PayoutIntervalCycles  int64         `json:"payout_interval"`
+	IntervalTriggerOffset int64         `json:"interval_trigger_offset"`
+	IsDryRun              bool          `json:"dry_run"`
+	Version               string        `json:"version"`
+}
+
+type StatusProvider func() ContinualStatus
+
+// BakerReporter is read-only reporter of a baker profile
+type BakerReporter struct {
+	Name     string
+	Reporter common.ReporterEngine
+}
+
+type Server struct {
+	app       *fiber.App
+	reporters []BakerReporter
+	status    StatusProvider
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func respondWithError(c *fiber.Ctx, status int, err error) error {
+	return c.Status(status).JSON(errorResponse{Error: err.Error()})
+}
+
+func parseCycleParam(c *fiber.Ctx) (int64, error) {
+	cycle, err := strconv.ParseInt(c.Params("cycle"), 10, 64)
+	if err != nil || cycle < 0 {
+		return 0, errors.New("invalid cycle")
+	}
+	return cycle, nil
+}
+
+// NewServer creates api server over reports of the bakers, reports of all bakers are merged unless the baker is selected by name
+func NewServer(reporters []BakerReporter, status StatusProvider) *Server {
+	server := &Server{
+		app: fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		}),
+		reporters: reporters,
+		status:    status,
+	}
+
+	server.app.Get("/", server.getDashboard)
+	server.app.Get("/api/status", server.getStatus)
+	for _, prefix := range []string{"/api", "/api/bakers/:name"} {
+		server.app.Get(prefix+"/cycles/:cycle/payouts", server.getCyclePayouts)
+		server.app.Get(prefix+"/cycles/:cycle/summary", server.getCycleSummary)
+		server.app.Get(prefix+"/delegators/:address/payouts", server.getDelegatorPayouts)
+	}
+	return server
+}
+
+// App exposes underlying fiber app so other read-only handlers can be mounted
+func (server *Server) App() *fiber.App {
+	return server.app
+}
+
+func (server *Server) Listen(address string) {
+	go func() {
+		slog.Info("starting api server", "address", address)
+		if err := server.app.Listen(address); err != nil {
+			slog.Error("api server failed", "error", err.Error())
+		}
+	}()
+}
+
+func (server *Server) Shutdown() error {
+	return server.app.Shutdown()
+}
+
+func (server *Server) getDashboard(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.Send(dashboard)
+}
+
+func (server *Server) getStatus(c *fiber.Ctx) error {
+	return c.JSON(server.status())
+}
+
+// getReporters returns reporter of the baker selected by name or reporters of all bakers
+func (server *Server) getReporters(c *fiber.Ctx) ([]BakerReporter, bool) {
+	name := c.Params("name")
+	if name == "" {
+		return server.reporters, true
+	}
+	reporter, ok := lo.Find(server.reporters, func(reporter BakerReporter) bool { return reporter.Name == name })
+	return []BakerReporter{reporter}, ok
+}
+
+func (server *Server) getCyclePayouts(c *fiber.Ctx) error {
+	cycle, err := parseCycleParam(c)
+	if err != nil {
+		return respondWithError(c, fiber.StatusBadRequest, err)
+	}
+	reporters, ok := server.getReporters(c)
+	if !ok {
+		return respondWithError(c, fiber.StatusNotFound, errors.New("unknown baker"))
+	}
+
+	var result []common.PayoutReport
+	for _, reporter := range reporters {
+		reports, err := reporter.Reporter.GetExistingReports(cycle)
+		switch {
+		case os.IsNotExist(err):
+			continue
+		case err != nil:
+			slog.Warn("api - failed to load reports", "baker", reporter.Name, "cycle", cycle, "error", err.Error())
+			return respondWithError(c, fiber.StatusInternalServerError, errors.New("failed to load reports"))
+		}
+		result = append(result, reports...)
+	}
+	if result == nil {
+		return respondWithError(c, fiber.StatusNotFound, errors.New("no reports for cycle"))
+	}
+	return c.JSON(result)
+}
+
+func (server *Server) getCycleSummary(c *fiber.Ctx) error {
+	cycle, err := parseCycleParam(c)
+	if err != nil {
+		return respondWithError(c, fiber.StatusBadRequest, err)
+	}
+	reporters, ok := server.getReporters(c)
+	if !ok {
+		return respondWithError(c, fiber.StatusNotFound, errors.New("unknown baker"))
+	}
+
+	var result *common.CyclePayoutSummary
+	for _, reporter := range reporters {
+		summary, err := reporter.Reporter.GetExistingCycleSummary(cycle)
+		switch {
+		case os.IsNotExist(err):
+			continue
+		case err != nil:
+			slog.Warn("api - failed to load cycle summary", "baker", reporter.Name, "cycle", cycle, "error", err.Error())
+			return respondWithError(c, fiber.StatusInternalServerError, errors.New("failed to load summary"))
+		}
+		if result == nil {
+			copied := *summary
+			result = &copied
+			continue
+		}
+		// summaries of multiple bakers are summed up
+		result.AddAmounts(summary)
+		result.Delegators += summary.Delegators
+		result.PaidDelegators += summary.PaidDelegators
+		if summary.Timestamp.After(result.Timestamp) {
+			result.Timestamp = summary.Timestamp
+		}
+	}
+	if result == nil {
+		return respondWithError(c, fiber.StatusNotFound, errors.New("no summary for cycle"))
+	}
+	return c.JSON(result)
+}
+
+type delegatorPayoutsResponse struct {
+	Delegator  tezos.Address         `json:"delegator"`
+	FirstCycle int64                 `json:"first_cycle"`
+	LastCycle  int64                 `json:"last_cycle"`
+	Payouts    []common.PayoutReport `json:"payouts"`
+}
+
+func (server *Server) getDelegatorPayouts(c *fiber.Ctx) error {
+	address, err := tezos.ParseAddress(c.Params("address"))
+	if err != nil {
+		return respondWithError(c, fiber.StatusBadRequest, errors.New("invalid address"))
+	}
+	reporters, ok := server.getReporters(c)
+	if !ok {
+		return respondWithError(c, fiber.StatusNotFound, errors.New("unknown baker"))
+	}
+
+	cycles := c.QueryInt("cycles", constants.DEFAULT_API_HISTORY_CYCLES)
+	cycles = lo.Clamp(cycles, 1, constants.MAXIMUM_API_HISTORY_CYCLES)
+
+	lastCycle := int64(c.QueryInt("last_cycle", 0))
+	if lastCycle <= 0 {
+		lastCycle = server.status().LastProcessedCycle
+	}
+	firstCycle := max(lastCycle-int64(cycles-1), 0)
+
+	payouts := make([]common.PayoutReport, 0)
+	for cycle := lastCycle; cycle >= firstCycle; cycle-- {
+		for _, reporter := range reporters {
+			reports, err := reporter.Reporter.GetExistingReports(cycle)
+			if err != nil {
+				if !os.IsNotExist(err) {
+					slog.Warn("api - failed to load reports", "baker", reporter.Name, "cycle", cycle, "error", err.Error())
+				}
+				continue
+			}
+			payouts = append(payouts, lo.Filter(reports, func(report common.PayoutReport, _ int) bool {
+				return report.Delegator.Equal(address) || report.Recipient.Equal(address)
+			})...)
+		}
+	}
+
+	return c.JSON(delegatorPayoutsResponse{
+		Delegator:  address,
+		FirstCycle: firstCycle,
+		LastCycle:  lastCycle,
+		Payouts:    payouts,
+	})
+}
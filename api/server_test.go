@@ -0,0 +1,145 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type memoryReporter struct {
+	mock.EmptyReporter
+	reports   map[int64][]common.PayoutReport
+	summaries map[int64]*common.CyclePayoutSummary
+}
+
+func (engine *memoryReporter) GetExistingReports(cycle int64) ([]common.PayoutReport, error) {
+	reports, ok := engine.reports[cycle]
+	if !ok {
+		return []common.PayoutReport{}, os.ErrNotExist
+	}
+	return reports, nil
+}
+
+func (engine *memoryReporter) GetExistingCycleSummary(cycle int64) (*common.CyclePayoutSummary, error) {
+	summary, ok := engine.summaries[cycle]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return summary, nil
+}
+
+func request(t *testing.T, server *Server, url string, target any) int {
+	response, err := server.App().Test(httptest.NewRequest("GET", url, nil))
+	assert.Nil(t, err)
+	body, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+	if target != nil {
+		assert.Nil(t, json.Unmarshal(body, target))
+	}
+	return response.StatusCode
+}
+
+func TestServer(t *testing.T) {
+	assert := assert.New(t)
+
+	delegator := mock.GetRandomAddress()
+	other := mock.GetRandomAddress()
+	reporter := &memoryReporter{
+		reports: map[int64][]common.PayoutReport{
+			10: {{Cycle: 10, Delegator: delegator, Recipient: delegator, Amount: tezos.NewZ(100), IsSuccess: true}, {Cycle: 10, Delegator: other, Recipient: other, Amount: tezos.NewZ(200)}},
+			12: {{Cycle: 12, Delegator: delegator, Recipient: delegator, Amount: tezos.NewZ(300), IsSuccess: true}},
+		},
+		summaries: map[int64]*common.CyclePayoutSummary{
+			10: {Delegators: 2, PaidDelegators: 1},
+		},
+	}
+	server := NewServer([]BakerReporter{{Name: "baker", Reporter: reporter}}, func() ContinualStatus {
+		return ContinualStatus{LastProcessedCycle: 12, PendingCycle: 13}
+	})
+
+	var status ContinualStatus
+	assert.Equal(200, request(t, server, "/api/status", &status))
+	assert.Equal(int64(13), status.PendingCycle)
+
+	var payouts []common.PayoutReport
+	assert.Equal(200, request(t, server, "/api/cycles/10/payouts", &payouts))
+	assert.Len(payouts, 2)
+	assert.Equal(404, request(t, server, "/api/cycles/11/payouts", nil))
+	assert.Equal(400, request(t, server, "/api/cycles/abc/payouts", nil))
+
+	var summary common.CyclePayoutSummary
+	assert.Equal(200, request(t, server, "/api/cycles/10/summary", &summary))
+	assert.Equal(2, summary.Delegators)
+	assert.Equal(404, request(t, server, "/api/cycles/12/summary", nil))
+
+	var history delegatorPayoutsResponse
+	assert.Equal(200, request(t, server, "/api/delegators/"+delegator.String()+"/payouts", &history))
+	assert.Equal(int64(12), history.LastCycle)
+	assert.Equal(int64(3), history.FirstCycle)
+	assert.Len(history.Payouts, 2)
+	assert.Equal(int64(12), history.Payouts[0].Cycle)
+
+	assert.Equal(200, request(t, server, "/api/delegators/"+delegator.String()+"/payouts?cycles=2", &history))
+	assert.Len(history.Payouts, 1)
+	assert.Equal(400, request(t, server, "/api/delegators/invalid/payouts", nil))
+
+	assert.Equal(200, request(t, server, "/", nil))
+}
+
+func TestServerMultipleBakers(t *testing.T) {
+	assert := assert.New(t)
+
+	delegator := mock.GetRandomAddress()
+	first := &memoryReporter{
+		reports: map[int64][]common.PayoutReport{
+			10: {{Cycle: 10, Delegator: delegator, Recipient: delegator, Amount: tezos.NewZ(100), IsSuccess: true}},
+		},
+		summaries: map[int64]*common.CyclePayoutSummary{
+			10: {Delegators: 1, PaidDelegators: 1, DistributedRewards: tezos.NewZ(100)},
+		},
+	}
+	second := &memoryReporter{
+		reports: map[int64][]common.PayoutReport{
+			10: {{Cycle: 10, Delegator: delegator, Recipient: delegator, Amount: tezos.NewZ(200), IsSuccess: true}},
+			11: {{Cycle: 11, Delegator: delegator, Recipient: delegator, Amount: tezos.NewZ(300), IsSuccess: true}},
+		},
+		summaries: map[int64]*common.CyclePayoutSummary{
+			10: {Delegators: 2, PaidDelegators: 1, DistributedRewards: tezos.NewZ(200)},
+		},
+	}
+	server := NewServer([]BakerReporter{{Name: "first", Reporter: first}, {Name: "second", Reporter: second}}, func() ContinualStatus {
+		return ContinualStatus{LastProcessedCycle: 11, PendingCycle: 12}
+	})
+
+	var payouts []common.PayoutReport
+	assert.Equal(200, request(t, server, "/api/cycles/10/payouts", &payouts))
+	assert.Len(payouts, 2)
+	assert.Equal(200, request(t, server, "/api/bakers/second/cycles/10/payouts", &payouts))
+	assert.Len(payouts, 1)
+	assert.Equal(int64(200), payouts[0].Amount.Int64())
+	assert.Equal(200, request(t, server, "/api/cycles/11/payouts", &payouts))
+	assert.Len(payouts, 1)
+	assert.Equal(404, request(t, server, "/api/bakers/first/cycles/11/payouts", nil))
+	assert.Equal(404, request(t, server, "/api/bakers/unknown/cycles/10/payouts", nil))
+
+	var summary common.CyclePayoutSummary
+	assert.Equal(200, request(t, server, "/api/cycles/10/summary", &summary))
+	assert.Equal(3, summary.Delegators)
+	assert.Equal(int64(300), summary.DistributedRewards.Int64())
+	assert.Equal(200, request(t, server, "/api/bakers/first/cycles/10/summary", &summary))
+	assert.Equal(1, summary.Delegators)
+	assert.Equal(int64(100), summary.DistributedRewards.Int64())
+
+	var history delegatorPayoutsResponse
+	assert.Equal(200, request(t, server, "/api/delegators/"+delegator.String()+"/payouts", &history))
+	assert.Len(history.Payouts, 3)
+	assert.Equal(200, request(t, server, "/api/bakers/first/delegators/"+delegator.String()+"/payouts", &history))
+	assert.Len(history.Payouts, 1)
+}
@@ -4,6 +4,8 @@ import (
 	"bufio"
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"sync"
@@ -14,9 +16,11 @@ import (
 	"github.com/tez-capital/tezpay/constants"
 	"github.com/tez-capital/tezpay/core"
 	collector_engines "github.com/tez-capital/tezpay/engines/collector"
+	reporter_engines "github.com/tez-capital/tezpay/engines/reporter"
 	signer_engines "github.com/tez-capital/tezpay/engines/signer"
 	transactor_engines "github.com/tez-capital/tezpay/engines/transactor"
 	"github.com/tez-capital/tezpay/extension"
+	"github.com/tez-capital/tezpay/metrics"
 	"github.com/tez-capital/tezpay/state"
 	"github.com/tez-capital/tezpay/utils"
 	"github.com/trilitech/tzgo/tezos"
@@ -27,6 +31,8 @@ type configurationAndEngines struct {
 	Collector     common.CollectorEngine
 	Signer        common.SignerEngine
 	Transactor    common.TransactorEngine
+	// unwrapped transactor, baker profiles wrap it by their own multisig
+	rpcTransactor *transactor_engines.DefaultRpcTransactor
 }
 
 func (cae *configurationAndEngines) Unwrap() (*configuration.RuntimeConfiguration, common.CollectorEngine, common.SignerEngine, common.TransactorEngine) {
@@ -48,12 +54,16 @@ func loadConfigurationEnginesExtensions() (*configurationAndEngines, error) {
 	}
 	// for testing point transactor to testnet
 	// transactorEngine, err := clients.InitDefaultTransactor("https://rpc.tzkt.io/ghostnet/", "https://api.ghostnet.tzkt.io/") // (config.Network.RpcUrl, config.Network.TzktUrl)
-	transactorEngine, err := transactor_engines.InitDefaultTransactor(config)
+	rpcTransactor, err := transactor_engines.InitDefaultTransactor(config)
 	if err != nil {
 		return nil, errors.Join(constants.ErrTransactorLoadFailed, err)
 	}
+	transactorEngine, err := loadTransactor(rpcTransactor, &config.PayoutConfiguration)
+	if err != nil {
+		return nil, err
+	}
 
-	collector, err := collector_engines.InitDefaultRpcAndTzktColletor(config)
+	collector, err := collector_engines.Load(config, notifyAdminFactory(config))
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +76,7 @@ func loadConfigurationEnginesExtensions() (*configurationAndEngines, error) {
 		BakerPKH:  config.BakerPKH.String(),
 		PayoutPKH: signerEngine.GetPKH().String(),
 		RpcPool:   config.Network.RpcPool,
+		AuditFile: state.Global.GetExtensionAuditFilePath(),
 	}
 	if err = extension.InitializeExtensionStore(context.Background(), config.Extensions, extEnv); err != nil {
 		return nil, errors.Join(constants.ErrExtensionStoreInitializationFailed, err)
@@ -76,9 +87,76 @@ func loadConfigurationEnginesExtensions() (*configurationAndEngines, error) {
 		Collector:     collector,
 		Signer:        signerEngine,
 		Transactor:    transactorEngine,
+		rpcTransactor: rpcTransactor,
 	}, nil
 }
 
+// loadTransactor wraps the transactor by multisig if configured
+func loadTransactor(transactor *transactor_engines.DefaultRpcTransactor, payouts *configuration.RuntimePayoutConfiguration) (common.TransactorEngine, error) {
+	if payouts.Multisig == nil {
+		return transactor, nil
+	}
+	signers := make([]common.SignerEngine, 0, len(payouts.Multisig.Signers))
+	for i, spec := range payouts.Multisig.Signers {
+		signer, err := signer_engines.Load(spec)
+		if err != nil {
+			return nil, errors.Join(constants.ErrTransactorLoadFailed, fmt.Errorf("multisig signer %d", i), err)
+		}
+		signers = append(signers, signer)
+	}
+	return transactor_engines.InitMultisigTransactor(transactor, payouts.Multisig, signers), nil
+}
+
+type bakerProfileContext struct {
+	*configurationAndEngines
+	Name string
+
+	// continual mode progress of the profile, failed profiles are retried without holding back the others
+	lastProcessedCycle int64
+	lastError          string
+	retryAt            time.Time
+}
+
+// loadBakerProfileContexts returns context for each configured baker profile,
+// without profiles the main configuration is the only baker
+func loadBakerProfileContexts(context *configurationAndEngines) ([]*bakerProfileContext, error) {
+	config, collector, signer, transactor := context.Unwrap()
+	if !config.IsMultiBaker() {
+		return []*bakerProfileContext{{configurationAndEngines: context, Name: config.BakerPKH.String()}}, nil
+	}
+
+	result := make([]*bakerProfileContext, 0, len(config.Bakers))
+	for _, profile := range config.Bakers {
+		profileSigner := signer
+		if profile.PayoutWallet != "" {
+			var err error
+			profileSigner, err = signer_engines.Load(profile.PayoutWallet)
+			if err != nil {
+				return nil, errors.Join(constants.ErrSignerLoadFailed, fmt.Errorf("baker profile '%s'", profile.Name), err)
+			}
+		}
+		profileTransactor := transactor
+		if profile.PayoutConfiguration.Multisig != nil || config.PayoutConfiguration.Multisig != nil {
+			var err error
+			profileTransactor, err = loadTransactor(context.rpcTransactor, &profile.PayoutConfiguration)
+			if err != nil {
+				return nil, errors.Join(fmt.Errorf("baker profile '%s'", profile.Name), err)
+			}
+		}
+		result = append(result, &bakerProfileContext{
+			configurationAndEngines: &configurationAndEngines{
+				Configuration: config.ForBaker(&profile),
+				Collector:     collector,
+				Signer:        profileSigner,
+				Transactor:    profileTransactor,
+				rpcTransactor: context.rpcTransactor,
+			},
+			Name: profile.Name,
+		})
+	}
+	return result, nil
+}
+
 func loadGeneratedPayoutsFromBytes(data []byte) (common.CyclePayoutBlueprints, error) {
 	payouts, err := utils.PayoutBlueprintFromJson(data)
 	if err != nil {
@@ -129,13 +207,14 @@ func GetProtocolWithRetry(collector common.CollectorEngine) tezos.ProtocolHash {
 	return protocol
 }
 
-func PrintPayoutWalletRemainingBalance(collector common.CollectorEngine, signer common.SignerEngine) {
-	addr := signer.GetPKH()
+func PrintPayoutWalletRemainingBalance(collector common.CollectorEngine, signer common.SignerEngine, config *configuration.RuntimeConfiguration) {
+	addr := config.PayoutConfiguration.GetPayoutAddress(signer.GetPKH())
 	balance, err := collector.GetBalance(addr)
 	if err != nil {
 		slog.Error("failed to get balance", "error", err.Error())
 		return
 	}
+	metrics.PayoutWalletBalance.WithLabelValues(addr.String()).Set(float64(balance.Int64()))
 
 	slog.Info("the payout wallet remaining balance", "wallet", addr.String(), "balance", common.FormatTezAmount(balance.Int64()), "phase", "payout_wallet_remaining_balance")
 }
@@ -209,6 +288,20 @@ func getCyclesInCompletedPeriod(cycle, interval, offset, includePrevious int64)
 
 func generatePayoutsForCycles(cycles []int64, config *configuration.RuntimeConfiguration, collector common.CollectorEngine, signer common.SignerEngine, options *common.GeneratePayoutsOptions) (common.CyclePayoutBlueprints, error) {
 	slog.Info("generating payouts for cycles", "cycles", cycles)
+
+	// payout history is needed only to evaluate loyalty of delegators
+	var historyReporter common.ReporterEngine
+	if len(config.Delegators.Loyalty) > 0 {
+		var err error
+		historyReporter, err = reporter_engines.Load(config, &common.ReporterEngineOptions{IsReadOnly: true})
+		if err != nil {
+			return nil, err
+		}
+		if closer, ok := historyReporter.(io.Closer); ok {
+			defer closer.Close()
+		}
+	}
+
 	generationResults := make(common.CyclePayoutBlueprints, 0, len(cycles))
 	bluePrintChannel := make(chan *common.CyclePayoutBlueprint, len(cycles))
 	errChannel := make(chan error, len(cycles))
@@ -221,7 +314,7 @@ func generatePayoutsForCycles(cycles []int64, config *configuration.RuntimeConfi
 				cycleOptions = *options
 			}
 			cycleOptions.Cycle = cycle // set cycle for this go routine
-			generationResult, err := core.GeneratePayouts(config, common.NewGeneratePayoutsEngines(collector, signer, notifyAdminFactory(config)), &cycleOptions)
+			generationResult, err := core.GeneratePayouts(config, common.NewGeneratePayoutsEngines(collector, signer, notifyAdminFactory(config)).WithReporter(historyReporter), &cycleOptions)
 			switch {
 			case errors.Is(err, constants.ErrNoCycleDataAvailable):
 				slog.Info("no data available for cycle, skipping", "cycle", cycle)
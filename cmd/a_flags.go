@@ -15,6 +15,7 @@ const (
 	SKIP_VERSION_CHECK_FLAG      = "skip-version-check"
 	SKIP_BALANCE_CHECK_FLAG      = "skip-balance-check"
 	DRY_RUN_FLAG                 = "dry-run"
+	EXPORT_UNSIGNED_FLAG         = "export-unsigned"
 
 	REPORT_TO_STDOUT                 = "report-to-stdout"
 	DISABLE_SEPARATE_SC_PAYOUTS_FLAG = "no-separate-sc"
@@ -28,4 +29,14 @@ const (
 	START_DATE_FLAG                  = "start-date"
 	END_DATE_FLAG                    = "end-date"
 	MONTH_FLAG                       = "month"
+	API_SERVER_FLAG                  = "api-server"
+	METRICS_SERVER_FLAG              = "metrics-server"
+	DELEGATOR_FLAG                   = "delegator"
+	RECIPIENT_FLAG                   = "recipient"
+	BAKER_FLAG                       = "baker"
+	TIMESTAMP_FLAG                   = "timestamp"
+	SIGNATURE_FLAG                   = "signature"
+	STATUS_FLAG                      = "status"
+	NOTE_FLAG                        = "note"
+	OUTPUT_FLAG                      = "output"
 )
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/core"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+const (
+	OFFLINE_PAYOUTS_MANIFEST_FILE = "payouts.json"
+	OFFLINE_BATCH_FILE_PATTERN    = "batch-%03d.json"
+)
+
+func writeJsonFile(file string, value any) error {
+	data, err := json.MarshalIndent(value, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0644)
+}
+
+func readJsonFile(file string, value any) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, value)
+}
+
+func writeOfflineBatches(dir string, batches []*common.OfflineBatch) error {
+	for i, batch := range batches {
+		if err := writeJsonFile(path.Join(dir, fmt.Sprintf(OFFLINE_BATCH_FILE_PATTERN, i+1)), batch); err != nil {
+			return errors.Join(constants.ErrOfflinePayoutsSaveFailed, err)
+		}
+	}
+	return nil
+}
+
+func writeOfflinePayouts(dir string, payouts *common.OfflinePayouts) error {
+	slog.Info("writing unsigned batches", "path", dir, "batches_count", len(payouts.Batches))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Join(constants.ErrOfflinePayoutsSaveFailed, err)
+	}
+	if existing, _ := filepath.Glob(path.Join(dir, "batch-*.json")); len(existing) > 0 {
+		return errors.Join(constants.ErrOfflinePayoutsSaveFailed, fmt.Errorf("directory '%s' already contains batches", dir))
+	}
+	if err := writeJsonFile(path.Join(dir, OFFLINE_PAYOUTS_MANIFEST_FILE), payouts); err != nil {
+		return errors.Join(constants.ErrOfflinePayoutsSaveFailed, err)
+	}
+	return writeOfflineBatches(dir, payouts.Batches)
+}
+
+func loadOfflinePayouts(dir string) (*common.OfflinePayouts, error) {
+	slog.Info("reading offline batches", "path", dir)
+	payouts := &common.OfflinePayouts{}
+	if err := readJsonFile(path.Join(dir, OFFLINE_PAYOUTS_MANIFEST_FILE), payouts); err != nil {
+		return nil, errors.Join(constants.ErrOfflinePayoutsLoadFailed, err)
+	}
+	files, err := filepath.Glob(path.Join(dir, "batch-*.json"))
+	if err != nil {
+		return nil, errors.Join(constants.ErrOfflinePayoutsLoadFailed, err)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		batch := &common.OfflineBatch{}
+		if err := readJsonFile(file, batch); err != nil {
+			return nil, errors.Join(constants.ErrOfflinePayoutsLoadFailed, fmt.Errorf("file: %s", file), err)
+		}
+		payouts.Batches = append(payouts.Batches, batch)
+	}
+	if len(payouts.Batches) == 0 {
+		return nil, errors.Join(constants.ErrOfflinePayoutsLoadFailed, fmt.Errorf("no batches found in '%s'", dir))
+	}
+	return payouts, nil
+}
+
+func exportUnsignedPayouts(dir string, preparationResult *common.PreparePayoutsResult, config *configuration.RuntimeConfiguration, collector common.CollectorEngine, signer common.SignerEngine, transactor common.TransactorEngine, reporter common.ReporterEngine, options *common.ExecutePayoutsOptions) {
+	if config.PayoutConfiguration.Multisig != nil {
+		slog.Error("exporting unsigned batches is not supported with multisig payouts, multisig signatures are collected during execution")
+		os.Exit(EXIT_OPERTION_FAILED)
+	}
+	// reveal would be added to every batch, reveal the payout wallet with 'tezpay reveal' first
+	revealed := assertRunWithResultAndErrorMessage(func() (bool, error) {
+		return collector.IsRevealed(signer.GetPKH())
+	}, EXIT_OPERTION_FAILED, "failed to check if payout wallet is revealed")
+	if !revealed {
+		slog.Error("payout wallet has to be revealed before exporting unsigned batches", "wallet", signer.GetPKH().String())
+		os.Exit(EXIT_OPERTION_FAILED)
+	}
+
+	branch := assertRunWithResultAndErrorMessage(func() (tezos.BlockHash, error) {
+		return collector.GetBranch(0)
+	}, EXIT_OPERTION_FAILED, "failed to get branch")
+	offlinePayouts := assertRunWithResult(func() (*common.OfflinePayouts, error) {
+		return core.ExportUnsignedPayouts(preparationResult, config, common.NewExecutePayoutsEngineContext(collector, signer, transactor, reporter, notifyAdminFactory(config)), branch, options)
+	}, EXIT_OPERTION_FAILED)
+	assertRunWithErrorMessage(func() error {
+		return writeOfflinePayouts(dir, offlinePayouts)
+	}, EXIT_PAYOUT_WRITE_FAILURE, "failed to write unsigned batches")
+	slog.Info("unsigned batches exported, sign them with 'tezpay sign-batches' and broadcast with 'tezpay broadcast-signed' before the branch expires", "path", dir, "branch", branch.String(), "phase", "result")
+}
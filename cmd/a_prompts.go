@@ -11,6 +11,7 @@ import (
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/hashicorp/go-version"
 	"github.com/tez-capital/tezpay/constants"
+	signer_engines "github.com/tez-capital/tezpay/engines/signer"
 	"github.com/tez-capital/tezpay/utils"
 )
 
@@ -32,6 +33,21 @@ func assertRequireConfirmation(msg string) {
 	assertRunWithParamAndErrorMessage(requireConfirmation, msg, EXIT_OPERTION_CANCELED, "not confirmed")
 }
 
+func promptPassphrase(msg string) (string, error) {
+	passphrase := ""
+	prompt := &survey.Password{
+		Message: msg,
+	}
+	if err := survey.AskOne(prompt, &passphrase); err != nil {
+		return "", err
+	}
+	return passphrase, nil
+}
+
+func init() {
+	signer_engines.SetPassphrasePrompt(promptPassphrase)
+}
+
 func checkForNewVersionAvailable() (bool, string) {
 	slog.Debug("checking for new version")
 	// https://api.github.com/repos/tez-capital/tezpay/releases/latest
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/core"
+	reporter_engines "github.com/tez-capital/tezpay/engines/reporter"
+	"github.com/tez-capital/tezpay/extension"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/tez-capital/tezpay/utils"
+)
+
+var broadcastSignedCmd = &cobra.Command{
+	Use:   "broadcast-signed <dir>",
+	Short: "broadcasts batches signed with 'sign-batches'",
+	Long:  "broadcasts batches signed with 'sign-batches' and writes payout reports",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		config, collector, signer, transactor := assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE).Unwrap()
+		defer extension.CloseExtensions()
+		confirmed, _ := cmd.Flags().GetBool(CONFIRM_FLAG)
+
+		offlinePayouts := assertRunWithResult(func() (*common.OfflinePayouts, error) {
+			return loadOfflinePayouts(args[0])
+		}, EXIT_PAYOUTS_READ_FAILURE)
+		if !offlinePayouts.Baker.Equal(config.BakerPKH) {
+			slog.Error("batches were exported for different baker", "baker", offlinePayouts.Baker.String(), "configured_baker", config.BakerPKH.String())
+			os.Exit(EXIT_PAYOUTS_READ_FAILURE)
+		}
+		cycles := offlinePayouts.Cycles
+
+		payoutReporter := assertRunWithResult(func() (common.ReporterEngine, error) {
+			return reporter_engines.Load(config, &common.ReporterEngineOptions{})
+		}, EXIT_CONFIGURATION_LOAD_FAILURE)
+
+		slog.Info("acquiring lock", "cycles", cycles, "phase", "acquiring_lock")
+		unlock, err := lockCyclesWithTimeout(time.Minute*10, cycles...)
+		if err != nil {
+			slog.Error("failed to acquire lock", "error", err.Error())
+			os.Exit(EXIT_OPERTION_FAILED)
+		}
+		defer unlock()
+
+		if !confirmed {
+			assertRequireConfirmation(fmt.Sprintf("Do you want to broadcast %d signed batches of %s?", len(offlinePayouts.Batches), utils.FormatCycleNumbers(cycles...)))
+		}
+
+		slog.Info("broadcasting signed batches")
+		executionResult := assertRunWithResult(func() (*common.ExecutePayoutsResult, error) {
+			return core.BroadcastSignedPayouts(offlinePayouts, config, common.NewExecutePayoutsEngineContext(collector, signer, transactor, payoutReporter, notifyAdminFactory(config)), &common.ExecutePayoutsOptions{})
+		}, EXIT_OPERTION_FAILED)
+
+		failedCount := lo.CountBy(executionResult.BatchResults, func(br *common.BatchResult) bool { return !br.IsSuccess })
+		if len(executionResult.BatchResults) > 0 && failedCount > 0 {
+			slog.Error("failed operations detected", "failed", failedCount, "total", len(executionResult.BatchResults))
+			os.Exit(EXIT_OPERTION_FAILED)
+		}
+		if silent, _ := cmd.Flags().GetBool(SILENT_FLAG); !silent {
+			notifyPayoutsProcessedThroughAllNotificators(config, &executionResult.Summary)
+		}
+		switch {
+		case state.Global.GetWantsOutputJson():
+			slog.Info(constants.LOG_MESSAGE_PAYOUTS_EXECUTED, constants.LOG_FIELD_CYCLES, cycles, "phase", "result")
+		default:
+			utils.PrintBatchResults(executionResult.BatchResults, fmt.Sprintf("Results of %s", utils.FormatCycleNumbers(cycles...)), config.Network.Explorer)
+		}
+		PrintPayoutWalletRemainingBalance(collector, signer, config)
+	},
+}
+
+func init() {
+	broadcastSignedCmd.Flags().Bool(CONFIRM_FLAG, false, "automatically confirms broadcasting")
+	broadcastSignedCmd.Flags().BoolP(SILENT_FLAG, "s", false, "suppresses notifications")
+	RootCmd.AddCommand(broadcastSignedCmd)
+}
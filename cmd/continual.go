@@ -3,30 +3,95 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"math/rand"
 	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
+	"github.com/tez-capital/tezpay/api"
 	"github.com/tez-capital/tezpay/common"
 	"github.com/tez-capital/tezpay/constants"
 	"github.com/tez-capital/tezpay/core"
 	reporter_engines "github.com/tez-capital/tezpay/engines/reporter"
 	"github.com/tez-capital/tezpay/extension"
+	"github.com/tez-capital/tezpay/metrics"
 	"github.com/tez-capital/tezpay/state"
 	"github.com/tez-capital/tezpay/utils"
 	"github.com/trilitech/tzgo/tezos"
 )
 
 var (
-	onchainCompletedCycle int64
-	lastProcessedCycle    int64
-	cycleToProcess        int64
+	// read by the api server, hence atomic
+	onchainCompletedCycle atomic.Int64
+	lastProcessedCycle    atomic.Int64
 	endCycle              int64
+	bakerStatuses         = newContinualBakerStatuses()
 )
 
+// continualBakerStatuses keeps state of each baker profile for the api server
+type continualBakerStatuses struct {
+	mutex    sync.RWMutex
+	statuses map[string]api.BakerStatus
+	active   *bakerProfileContext
+}
+
+func newContinualBakerStatuses() *continualBakerStatuses {
+	return &continualBakerStatuses{statuses: make(map[string]api.BakerStatus)}
+}
+
+func (s *continualBakerStatuses) update(profile *bakerProfileContext, cycle int64, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	status := s.statuses[profile.Name]
+	status.Name = profile.Name
+	status.BakerPKH = profile.Configuration.BakerPKH
+	status.PayoutPKH = profile.Signer.GetPKH()
+	status.LastError = ""
+	if err != nil {
+		status.LastError = err.Error()
+	} else {
+		status.LastProcessedCycle = cycle
+	}
+	s.statuses[profile.Name] = status
+}
+
+// setActive marks profile which is being processed
+func (s *continualBakerStatuses) setActive(profile *bakerProfileContext) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.active = profile
+}
+
+// getActive returns baker and payout wallet of the profile being processed, fallback is used before first processing
+func (s *continualBakerStatuses) getActive(fallback *bakerProfileContext) (tezos.Address, tezos.Address) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	profile := lo.Ternary(s.active != nil, s.active, fallback)
+	return profile.Configuration.BakerPKH, profile.Signer.GetPKH()
+}
+
+// get returns statuses in order of the profiles, profiles not processed yet are reported from their configuration
+func (s *continualBakerStatuses) get(profiles []*bakerProfileContext) []api.BakerStatus {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return lo.Map(profiles, func(profile *bakerProfileContext, _ int) api.BakerStatus {
+		if status, ok := s.statuses[profile.Name]; ok {
+			return status
+		}
+		return api.BakerStatus{
+			Name:      profile.Name,
+			BakerPKH:  profile.Configuration.BakerPKH,
+			PayoutPKH: profile.Signer.GetPKH(),
+		}
+	})
+}
+
 type protocolChangeDecision struct {
 	HasProtocolChanged              bool
 	ShouldNotify                    bool
@@ -63,119 +128,303 @@ func evaluateProtocolChange(expectedProtocol, currentProtocol tezos.ProtocolHash
 	return decision
 }
 
-func processCycleInContinualMode(context *configurationAndEngines, forceConfirmationPrompt bool, mixInContractCalls bool, mixInFATransfers bool, isDryRun bool, silent bool, payoutInterval, intervalTriggerOffset, includePrevious int64) (processed bool) {
-	processed = true
-	retry := func() bool {
-		processed = false
-		return false
-	}
+type continualPayoutOptions struct {
+	ForceConfirmationPrompt bool
+	MixInContractCalls      bool
+	MixInFATransfers        bool
+	IsDryRun                bool
+	Silent                  bool
+	// failures of isolated bakers are returned instead of terminating the process
+	IsIsolated bool
+}
 
-	cycleToProcess = lastProcessedCycle + 1
-	cycles, isEndOfThePeriod := getCyclesInCompletedPeriod(cycleToProcess, payoutInterval, intervalTriggerOffset, includePrevious)
-	if !isEndOfThePeriod {
-		slog.Info("cycle is not at the end of the specified payout interval, skipping", "cycle", cycleToProcess, "payout_interval", payoutInterval, "interval_trigger_offset", intervalTriggerOffset, "include_previous", includePrevious)
-		lastProcessedCycle = cycleToProcess
-		return
+type bakerCycleResult struct {
+	Name    string
+	Cycle   int64
+	Summary *common.PayoutSummary
+	Err     error
+	// failure differs from the previous one of the baker or the baker recovered from failure
+	IsFailureChange bool
+}
+
+func runBakerPhase[T any](options *continualPayoutOptions, toExecute func() (T, error)) (T, error) {
+	if !options.IsIsolated {
+		return assertRunWithResult(toExecute, EXIT_OPERTION_FAILED), nil
 	}
+	return toExecute()
+}
 
-	defer func() { // complete cycle
-		switch {
-		case processed:
-			lastProcessedCycle = cycleToProcess
-			slog.Info("cycle processed successfully", "cycle", cycleToProcess)
-			slog.Info("===================== PROCESSING -END- =====================")
-			extension.CloseScopedExtensions()
-			if endCycle != 0 && lastProcessedCycle >= endCycle {
-				slog.Info("end cycle reached, exiting")
-				os.Exit(0)
-			}
-		default:
-			slog.Info("cycle processing failed, retrying in 5 minutes")
-			time.Sleep(time.Minute * 5) // wait for a while before retry
-		}
-	}()
+func processBakerCycleInContinualMode(profile *bakerProfileContext, cycle int64, cycles []int64, options *continualPayoutOptions) (*common.PayoutSummary, error) {
+	config, collector, signer, transactor := profile.Unwrap()
+	logger := slog.With("baker", profile.Name)
+	extension.SetEnvironmentBaker(config.BakerPKH.String(), signer.GetPKH().String())
+	bakerStatuses.setActive(profile)
 
-	config, collector, signer, transactor := context.Unwrap()
-	fsReporter := reporter_engines.NewFileSystemReporter(config, &common.ReporterEngineOptions{
-		DryRun: isDryRun,
+	payoutReporter, err := reporter_engines.Load(config, &common.ReporterEngineOptions{
+		DryRun: options.IsDryRun,
 	})
-
-	// refresh engine params - for protocol upgrades
-	if err := errors.Join(transactor.RefreshParams(), collector.RefreshParams()); err != nil {
-		slog.Error("failed to check for protocol changes", "error", err.Error())
-		return retry()
-	}
-
-	slog.Info("acquiring lock", "cycles", cycles, "phase", "acquiring_lock")
-	unlock, err := lockCyclesWithTimeout(time.Minute*10, cycles...)
 	if err != nil {
-		slog.Error("failed to acquire lock", "error", err.Error())
-		return retry()
+		return nil, err
+	}
+	if closer, ok := payoutReporter.(io.Closer); ok {
+		defer closer.Close()
 	}
-	defer unlock()
-
-	slog.Info("===================== PROCESSING START =====================")
-	slog.Info("processing cycles", "cycles", cycles)
 
 	generationResult, err := generatePayoutsForCycles(cycles, config, collector, signer, &common.GeneratePayoutsOptions{})
 	if err != nil {
 		if errors.Is(err, constants.ErrNoCycleDataAvailable) {
-			slog.Info("no data available for cycle, skipping", "cycle", cycleToProcess)
-			return
+			logger.Info("no data available for cycle, skipping", "cycle", cycle)
+			return nil, nil
 		}
-		slog.Error("failed to generate payouts", "error", err.Error())
-		return retry()
+		return nil, err
 	}
 
-	slog.Info("checking reports of past payouts")
-	preparationResult := assertRunWithResult(func() (*common.PreparePayoutsResult, error) {
-		return core.PreparePayouts(generationResult, config, common.NewPreparePayoutsEngineContext(collector, signer, fsReporter, notifyAdminFactory(config)), &common.PreparePayoutsOptions{
+	logger.Info("checking reports of past payouts")
+	preparationResult, err := runBakerPhase(options, func() (*common.PreparePayoutsResult, error) {
+		return core.PreparePayouts(generationResult, config, common.NewPreparePayoutsEngineContext(collector, signer, payoutReporter, notifyAdminFactory(config)), &common.PreparePayoutsOptions{
 			WaitForSufficientBalance: true,
 			Accumulate:               true,
+			UpdateOwedLedger:         !options.IsDryRun,
 		})
-	}, EXIT_OPERTION_FAILED)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(preparationResult.HeldPayouts) > 0 {
+		heldCycles := lo.Uniq(lo.Map(preparationResult.HeldPayouts, func(report common.PayoutReport, _ int) int64 { return report.Cycle }))
+		logger.Warn("payouts with unknown outcome held until reconciled", "count", len(preparationResult.HeldPayouts), "cycles", heldCycles)
+		notifyAdmin(config, fmt.Sprintf("%d payouts of cycles %s were recorded before execution but never settled, they are held until reconciled with 'tezpay reconcile'", len(preparationResult.HeldPayouts), utils.FormatCycleNumbers(heldCycles...)))
+	}
 
 	if len(preparationResult.ValidPayouts) == 0 {
-		slog.Info("nothing to pay out, skipping")
-		return
+		logger.Info("nothing to pay out, skipping")
+		return nil, nil
 	}
 
-	slog.Info("processing payouts", "valid", len(preparationResult.ValidPayouts), "invalid", len(preparationResult.InvalidPayouts), "accumulated", len(preparationResult.ValidPayouts), "already_successful", len(preparationResult.ReportsOfPastSuccessfulPayouts))
+	logger.Info("processing payouts", "valid", len(preparationResult.ValidPayouts), "invalid", len(preparationResult.InvalidPayouts), "accumulated", len(preparationResult.ValidPayouts), "already_successful", len(preparationResult.ReportsOfPastSuccessfulPayouts))
 
-	if forceConfirmationPrompt && utils.IsTty() {
+	if options.ForceConfirmationPrompt && utils.IsTty() {
 		utils.PrintPreparePayoutsResult(preparationResult, &utils.PrintPreparePayoutsResultOptions{AutoMergeRecords: true})
 		msg := "Do you want to pay out above VALID payouts?"
-		if isDryRun {
+		if options.IsIsolated {
+			msg = fmt.Sprintf("Do you want to pay out above VALID payouts of %s?", profile.Name)
+		}
+		if options.IsDryRun {
 			msg = msg + " " + constants.DRY_RUN_NOTE
 		}
 		assertRequireConfirmation(msg)
 	}
 
-	slog.Info("executing payouts", "valid", len(preparationResult.ValidPayouts), "invalid", len(preparationResult.InvalidPayouts), "accumulated", len(preparationResult.ValidPayouts), "already_successful", len(preparationResult.ReportsOfPastSuccessfulPayouts))
-	executionResult := assertRunWithResult(func() (*common.ExecutePayoutsResult, error) {
-		return core.ExecutePayouts(preparationResult, config, common.NewExecutePayoutsEngineContext(signer, transactor, fsReporter, notifyAdminFactory(config)), &common.ExecutePayoutsOptions{
-			MixInContractCalls: mixInContractCalls,
-			MixInFATransfers:   mixInFATransfers,
-			DryRun:             isDryRun,
+	logger.Info("executing payouts", "valid", len(preparationResult.ValidPayouts), "invalid", len(preparationResult.InvalidPayouts), "accumulated", len(preparationResult.ValidPayouts), "already_successful", len(preparationResult.ReportsOfPastSuccessfulPayouts))
+	executionResult, err := runBakerPhase(options, func() (*common.ExecutePayoutsResult, error) {
+		return core.ExecutePayouts(preparationResult, config, common.NewExecutePayoutsEngineContext(collector, signer, transactor, payoutReporter, notifyAdminFactory(config)), &common.ExecutePayoutsOptions{
+			MixInContractCalls: options.MixInContractCalls,
+			MixInFATransfers:   options.MixInFATransfers,
+			DryRun:             options.IsDryRun,
 		})
-	}, EXIT_OPERTION_FAILED)
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	// notify
 	failedCount := lo.CountBy(executionResult.BatchResults, func(br *common.BatchResult) bool { return !br.IsSuccess })
 	if len(executionResult.BatchResults) > 0 {
 		if failedCount > 0 {
-			slog.Error("failed operations detected", "failed", failedCount, "total", len(executionResult.BatchResults), "cycle", cycleToProcess, "phase", "cycle_processing_failed")
-			notifyAdmin(config, fmt.Sprintf("Failed operations detected: %d/%d in cycle %d", failedCount, len(executionResult.BatchResults), cycleToProcess))
-			return
+			logger.Error("failed operations detected", "failed", failedCount, "total", len(executionResult.BatchResults), "cycle", cycle, "phase", "cycle_processing_failed")
+			notifyAdmin(config, fmt.Sprintf("Failed operations detected: %d/%d in cycle %d", failedCount, len(executionResult.BatchResults), cycle))
+			return &executionResult.Summary, nil
 		} else {
-			slog.Info("all operations succeeded", "total", len(executionResult.BatchResults), "cycle", cycleToProcess, "phase", "cycle_processing_success")
+			logger.Info("all operations succeeded", "total", len(executionResult.BatchResults), "cycle", cycle, "phase", "cycle_processing_success")
 		}
 	}
-	if !silent && !isDryRun {
+	if !options.Silent && !options.IsDryRun {
 		notifyPayoutsProcessedThroughAllNotificators(config, &executionResult.Summary)
 	}
-	PrintPayoutWalletRemainingBalance(collector, signer)
-	return
+	PrintPayoutWalletRemainingBalance(collector, signer, config)
+	return &executionResult.Summary, nil
+}
+
+func summarizeBakerCycleResults(cycle int64, results []bakerCycleResult) string {
+	lines := make([]string, 0, len(results)+1)
+	lines = append(lines, fmt.Sprintf("Cycle %d processed for %d bakers:", cycle, len(results)))
+	for _, result := range results {
+		name := result.Name
+		if result.Cycle != cycle {
+			name = fmt.Sprintf("%s (cycle %d)", result.Name, result.Cycle)
+		}
+		switch {
+		case result.Err != nil:
+			lines = append(lines, fmt.Sprintf("%s - failed: %s", name, result.Err.Error()))
+		case result.Summary == nil:
+			lines = append(lines, fmt.Sprintf("%s - nothing to pay out", name))
+		default:
+			lines = append(lines, fmt.Sprintf("%s - paid %d delegators, distributed %s", name, result.Summary.PaidDelegators, common.FormatTezAmount(result.Summary.DistributedRewards.Int64())))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func describeBakerFailureChange(result bakerCycleResult) string {
+	if result.Err != nil {
+		return fmt.Sprintf("Failed to process cycle %d of %s, retrying every 5 minutes: %s", result.Cycle, result.Name, result.Err.Error())
+	}
+	return fmt.Sprintf("Cycle %d of %s processed after previous failure", result.Cycle, result.Name)
+}
+
+type processBakerCycleFunc func(profile *bakerProfileContext, cycle int64, cycles []int64) (*common.PayoutSummary, error)
+
+// processBakersInContinualMode processes cycles of each profile up to the cycle, profiles which failed are skipped until their retry time
+func processBakersInContinualMode(profiles []*bakerProfileContext, toCycle, payoutInterval, intervalTriggerOffset, includePrevious int64, process processBakerCycleFunc) []bakerCycleResult {
+	results := make([]bakerCycleResult, 0, len(profiles))
+	for _, profile := range profiles {
+		if profile.lastProcessedCycle >= toCycle || time.Now().Before(profile.retryAt) {
+			continue
+		}
+		for cycle := profile.lastProcessedCycle + 1; cycle <= toCycle; cycle++ {
+			cycles, isEndOfThePeriod := getCyclesInCompletedPeriod(cycle, payoutInterval, intervalTriggerOffset, includePrevious)
+			if !isEndOfThePeriod {
+				profile.lastProcessedCycle = cycle
+				continue
+			}
+
+			summary, err := process(profile, cycle, cycles)
+			bakerStatuses.update(profile, cycle, err)
+			result := bakerCycleResult{Name: profile.Name, Cycle: cycle, Summary: summary, Err: err}
+			if err != nil {
+				slog.Error("failed to process payouts, retrying in 5 minutes", "baker", profile.Name, "cycle", cycle, "error", err.Error())
+				result.IsFailureChange = err.Error() != profile.lastError
+				profile.lastError = err.Error()
+				profile.retryAt = time.Now().Add(time.Minute * 5)
+				results = append(results, result)
+				break
+			}
+			result.IsFailureChange = profile.lastError != ""
+			profile.lastError = ""
+			profile.lastProcessedCycle = cycle
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+func hasFailedBakers(profiles []*bakerProfileContext) bool {
+	return lo.SomeBy(profiles, func(profile *bakerProfileContext) bool { return profile.lastError != "" })
+}
+
+func isEndCycleReached() bool {
+	return endCycle != 0 && lastProcessedCycle.Load() >= endCycle
+}
+
+// processCycleInContinualMode processes next completed cycle of all bakers, if there is none only failed bakers are retried
+func processCycleInContinualMode(context *configurationAndEngines, profiles []*bakerProfileContext, options *continualPayoutOptions, payoutInterval, intervalTriggerOffset, includePrevious int64) {
+	isRetry := lastProcessedCycle.Load() >= onchainCompletedCycle.Load() || isEndCycleReached()
+	cycleToProcess := lastProcessedCycle.Load()
+	if !isRetry {
+		cycleToProcess++
+		if _, isEndOfThePeriod := getCyclesInCompletedPeriod(cycleToProcess, payoutInterval, intervalTriggerOffset, includePrevious); isEndOfThePeriod {
+			slog.Info("===================== PROCESSING START =====================")
+			slog.Info("processing cycle", "cycle", cycleToProcess)
+		} else {
+			slog.Info("cycle is not at the end of the specified payout interval, skipping", "cycle", cycleToProcess, "payout_interval", payoutInterval, "interval_trigger_offset", intervalTriggerOffset, "include_previous", includePrevious)
+		}
+	}
+
+	config, collector, _, transactor := context.Unwrap()
+	refreshed := false
+	results := processBakersInContinualMode(profiles, cycleToProcess, payoutInterval, intervalTriggerOffset, includePrevious, func(profile *bakerProfileContext, cycle int64, cycles []int64) (*common.PayoutSummary, error) {
+		// refresh engine params - for protocol upgrades
+		if !refreshed {
+			if err := errors.Join(transactor.RefreshParams(), collector.RefreshParams()); err != nil {
+				return nil, errors.Join(errors.New("failed to check for protocol changes"), err)
+			}
+			refreshed = true
+		}
+
+		slog.Info("acquiring lock", "baker", profile.Name, "cycles", cycles, "phase", "acquiring_lock")
+		unlock, err := lockCyclesWithTimeout(time.Minute*10, cycles...)
+		if err != nil {
+			return nil, errors.Join(errors.New("failed to acquire lock"), err)
+		}
+		defer unlock()
+
+		if config.IsMultiBaker() {
+			slog.Info("processing baker", "baker", profile.Name, "cycles", cycles)
+		}
+		return processBakerCycleInContinualMode(profile, cycle, cycles, options)
+	})
+
+	// failures are notified once, summary of bakers is sent once per cycle
+	if config.IsMultiBaker() && !isRetry && len(results) > 0 {
+		summary := summarizeBakerCycleResults(cycleToProcess, results)
+		slog.Info(summary)
+		notifyAdmin(config, summary)
+	} else {
+		for _, result := range results {
+			if result.IsFailureChange {
+				notifyAdmin(config, describeBakerFailureChange(result))
+			}
+		}
+	}
+	if len(results) > 0 {
+		extension.CloseScopedExtensions()
+	}
+
+	if !isRetry {
+		lastProcessedCycle.Store(cycleToProcess)
+		metrics.LastProcessedCycle.Set(float64(cycleToProcess))
+		if hasFailedBakers(profiles) {
+			slog.Warn("cycle processed, failed bakers will be retried", "cycle", cycleToProcess)
+		} else {
+			slog.Info("cycle processed successfully", "cycle", cycleToProcess)
+		}
+		slog.Info("===================== PROCESSING -END- =====================")
+	}
+	if isEndCycleReached() && !hasFailedBakers(profiles) {
+		slog.Info("end cycle reached, exiting")
+		os.Exit(0)
+	}
+}
+
+type completedCycleResult struct {
+	cycle int64
+	err   error
+}
+
+// waitForNextCompletedCycle waits in background so failed bakers can be retried meanwhile
+func waitForNextCompletedCycle(monitor common.CycleMonitor, lastProcessedCycle int64) chan completedCycleResult {
+	result := make(chan completedCycleResult, 1)
+	go func() {
+		cycle, err := monitor.WaitForNextCompletedCycle(lastProcessedCycle)
+		result <- completedCycleResult{cycle: cycle, err: err}
+	}()
+	return result
+}
+
+// loadApiReporters loads read-only reporter of each profile, profiles write reports into their own directories
+func loadApiReporters(profiles []*bakerProfileContext, isDryRun bool) ([]api.BakerReporter, error) {
+	result := make([]api.BakerReporter, 0, len(profiles))
+	for _, profile := range profiles {
+		reporter, err := reporter_engines.Load(profile.Configuration, &common.ReporterEngineOptions{
+			DryRun:     isDryRun,
+			IsReadOnly: true,
+		})
+		if err != nil {
+			closeApiReporters(result)
+			return nil, errors.Join(fmt.Errorf("baker profile '%s'", profile.Name), err)
+		}
+		result = append(result, api.BakerReporter{Name: profile.Name, Reporter: reporter})
+	}
+	return result, nil
+}
+
+func closeApiReporters(reporters []api.BakerReporter) {
+	for _, reporter := range reporters {
+		if closer, ok := reporter.Reporter.(io.Closer); ok {
+			closer.Close()
+		}
+	}
 }
 
 var continualCmd = &cobra.Command{
@@ -184,7 +433,7 @@ var continualCmd = &cobra.Command{
 	Long:  "runs payout until stopped manually",
 	Run: func(cmd *cobra.Command, args []string) {
 		configurationContext := assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE)
-		config, collector, _, _ := configurationContext.Unwrap()
+		config, collector, signer, _ := configurationContext.Unwrap()
 		defer extension.CloseExtensions()
 		initialCycle, _ := cmd.Flags().GetInt64(CYCLE_FLAG)
 		endCycle, _ = cmd.Flags().GetInt64(END_CYCLE_FLAG)
@@ -193,6 +442,8 @@ var continualCmd = &cobra.Command{
 		forceConfirmationPrompt, _ := cmd.Flags().GetBool(FORCE_CONFIRMATION_PROMPT_FLAG)
 		isDryRun, _ := cmd.Flags().GetBool(DRY_RUN_FLAG)
 		silent, _ := cmd.Flags().GetBool(SILENT_FLAG)
+		apiServerAddress, _ := cmd.Flags().GetString(API_SERVER_FLAG)
+		metricsServerAddress, _ := cmd.Flags().GetString(METRICS_SERVER_FLAG)
 
 		payoutInterval, _ := cmd.Flags().GetInt64(PAYMENT_INTERVAL_CYCLES_FLAG)
 		payoutInterval = getBoundedPayoutInterval(payoutInterval)
@@ -203,6 +454,7 @@ var continualCmd = &cobra.Command{
 
 		if isDryRun {
 			slog.Info("Dry run mode enabled")
+			extension.EnableHookCapture(state.Global.GetExtensionCaptureDirectory())
 		}
 
 		if forceConfirmationPrompt {
@@ -215,7 +467,20 @@ var continualCmd = &cobra.Command{
 			}
 		}
 
-		if !state.Global.IsDonationPromptDisabled() && !config.IsDonatingToTezCapital() {
+		profiles := assertRunWithResultAndErrorMessage(func() ([]*bakerProfileContext, error) {
+			return loadBakerProfileContexts(configurationContext)
+		}, EXIT_CONFIGURATION_LOAD_FAILURE, "failed to load baker profiles")
+		options := &continualPayoutOptions{
+			ForceConfirmationPrompt: forceConfirmationPrompt,
+			MixInContractCalls:      mixInContractCalls,
+			MixInFATransfers:        mixInFATransfers,
+			IsDryRun:                isDryRun,
+			Silent:                  silent,
+			IsIsolated:              config.IsMultiBaker(),
+		}
+
+		isDonating := !lo.SomeBy(profiles, func(profile *bakerProfileContext) bool { return !profile.Configuration.IsDonatingToTezCapital() })
+		if !state.Global.IsDonationPromptDisabled() && !isDonating {
 			assertRequireConfirmation("⚠️  With your current configuration you are not going to donate to tez.capital.😔 Do you want to proceed?")
 		}
 
@@ -227,18 +492,56 @@ var continualCmd = &cobra.Command{
 		}, EXIT_OPERTION_FAILED, "failed to init cycle monitor")
 
 		// last completed cycle at the time we started continual mode on
-		onchainCompletedCycle = assertRunWithResultAndErrorMessage(func() (int64, error) {
+		onchainCompletedCycle.Store(assertRunWithResultAndErrorMessage(func() (int64, error) {
 			return collector.GetLastCompletedCycle()
-		}, EXIT_OPERTION_FAILED, "failed to get last completed cycle")
+		}, EXIT_OPERTION_FAILED, "failed to get last completed cycle"))
 
-		lastProcessedCycle = onchainCompletedCycle
+		lastProcessedCycle.Store(onchainCompletedCycle.Load())
 		if initialCycle != 0 {
 			if initialCycle > 0 {
-				lastProcessedCycle = initialCycle - 1
+				lastProcessedCycle.Store(initialCycle - 1)
 			} else {
-				lastProcessedCycle = onchainCompletedCycle + initialCycle
+				lastProcessedCycle.Store(onchainCompletedCycle.Load() + initialCycle)
 			}
 		}
+		for _, profile := range profiles {
+			profile.lastProcessedCycle = lastProcessedCycle.Load()
+		}
+
+		if apiServerAddress != "" {
+			apiReporters := assertRunWithResultAndErrorMessage(func() ([]api.BakerReporter, error) {
+				return loadApiReporters(profiles, isDryRun)
+			}, EXIT_CONFIGURATION_LOAD_FAILURE, "failed to load reporter for api server")
+			defer closeApiReporters(apiReporters)
+
+			apiServer := api.NewServer(apiReporters, func() api.ContinualStatus {
+				processed := lastProcessedCycle.Load()
+				bakerPKH, payoutPKH := bakerStatuses.getActive(profiles[0])
+				return api.ContinualStatus{
+					BakerPKH:              bakerPKH,
+					PayoutPKH:             payoutPKH,
+					Bakers:                lo.Ternary(config.IsMultiBaker(), bakerStatuses.get(profiles), nil),
+					CurrentCycle:          monitor.GetCurrentCycle(),
+					LastCompletedCycle:    onchainCompletedCycle.Load(),
+					LastProcessedCycle:    processed,
+					PendingCycle:          processed + 1,
+					PayoutIntervalCycles:  payoutInterval,
+					IntervalTriggerOffset: intervalTriggerOffset,
+					IsDryRun:              isDryRun,
+					Version:               constants.VERSION,
+				}
+			})
+			apiServer.Listen(apiServerAddress)
+			defer apiServer.Shutdown()
+		}
+
+		if metricsServerAddress != "" {
+			metrics.LastCompletedCycle.Set(float64(onchainCompletedCycle.Load()))
+			metrics.LastProcessedCycle.Set(float64(lastProcessedCycle.Load()))
+			PrintPayoutWalletRemainingBalance(collector, signer, config)
+			metricsServer := metrics.Listen(metricsServerAddress)
+			defer metricsServer.Shutdown()
+		}
 
 		notifiedNewVersionAvailable := false
 		lastNotifiedProtocolPair := ""
@@ -250,24 +553,42 @@ var continualCmd = &cobra.Command{
 		}
 
 		defer func() {
-			notifyAdmin(config, fmt.Sprintf("Continual payouts stopped on cycle #%d", lastProcessedCycle+1))
+			notifyAdmin(config, fmt.Sprintf("Continual payouts stopped on cycle #%d", lastProcessedCycle.Load()+1))
 		}()
-		notifyAdmin(config, fmt.Sprintf("Continual payouts started on cycle #%d (tezpay %s, protocol %s)", lastProcessedCycle+1, constants.VERSION, expectedProtocol))
+		notifyAdmin(config, fmt.Sprintf("Continual payouts started on cycle #%d (tezpay %s, protocol %s)", lastProcessedCycle.Load()+1, constants.VERSION, expectedProtocol))
+		var nextCompletedCycle chan completedCycleResult
 		for {
-			if lastProcessedCycle >= onchainCompletedCycle {
-				slog.Info("waiting for next cycle to complete", "phase", "waiting_for_next_cycle")
-				var err error
-				onchainCompletedCycle, err = monitor.WaitForNextCompletedCycle(lastProcessedCycle)
-				if err != nil {
-					if errors.Is(err, constants.ErrMonitoringCanceled) {
-						slog.Info("cycle monitoring canceled", "phase", "cycle_monitoring_canceled")
-						notifyAdmin(config, "Cycle monitoring canceled.")
-					} else {
-						slog.Error("failed to wait for next completed cycle", "error", err.Error(), "phase", "failed_to_wait_for_next_completed_cycle")
-						notifyAdmin(config, "Failed to wait for next completed cycle.")
-					}
+			if lastProcessedCycle.Load() >= onchainCompletedCycle.Load() || isEndCycleReached() {
+				if nextCompletedCycle == nil && !isEndCycleReached() {
+					slog.Info("waiting for next cycle to complete", "phase", "waiting_for_next_cycle")
+					nextCompletedCycle = waitForNextCompletedCycle(monitor, lastProcessedCycle.Load())
+				}
+				var retryFailedBakers <-chan time.Time
+				if hasFailedBakers(profiles) {
+					retryFailedBakers = time.After(time.Minute * 5)
+				}
+				if nextCompletedCycle == nil && retryFailedBakers == nil {
 					return
 				}
+
+				select {
+				case result := <-nextCompletedCycle:
+					nextCompletedCycle = nil
+					if result.err != nil {
+						if errors.Is(result.err, constants.ErrMonitoringCanceled) {
+							slog.Info("cycle monitoring canceled", "phase", "cycle_monitoring_canceled")
+							notifyAdmin(config, "Cycle monitoring canceled.")
+						} else {
+							slog.Error("failed to wait for next completed cycle", "error", result.err.Error(), "phase", "failed_to_wait_for_next_completed_cycle")
+							notifyAdmin(config, "Failed to wait for next completed cycle.")
+						}
+						return
+					}
+					onchainCompletedCycle.Store(result.cycle)
+					metrics.LastCompletedCycle.Set(float64(result.cycle))
+				case <-retryFailedBakers:
+					slog.Info("retrying failed bakers", "phase", "retrying_failed_bakers")
+				}
 			}
 
 			slog.Debug("checking for protocol changes")
@@ -293,7 +614,7 @@ var continualCmd = &cobra.Command{
 				}
 			}
 
-			processCycleInContinualMode(configurationContext, forceConfirmationPrompt, mixInContractCalls, mixInFATransfers, isDryRun, silent, payoutInterval, intervalTriggerOffset, includePrevious)
+			processCycleInContinualMode(configurationContext, profiles, options, payoutInterval, intervalTriggerOffset, includePrevious)
 		}
 	},
 }
@@ -308,6 +629,8 @@ func init() {
 	continualCmd.Flags().Bool(DISABLE_SEPARATE_FA_PAYOUTS_FLAG, false, "disables fa transfers separation (mixes txs and fa transfers within batches)")
 	continualCmd.Flags().BoolP(FORCE_CONFIRMATION_PROMPT_FLAG, "a", false, "ask for confirmation on each payout")
 	continualCmd.Flags().Bool(DRY_RUN_FLAG, false, "Performs all actions except sending transactions. Reports are stored in 'reports/dry' folder")
+	continualCmd.Flags().String(API_SERVER_FLAG, "", "launches read-only api server and payout dashboard at specified address (e.g. 127.0.0.1:8080)")
+	continualCmd.Flags().String(METRICS_SERVER_FLAG, "", "exposes prometheus metrics at specified address under /metrics (e.g. 127.0.0.1:9090)")
 
 	RootCmd.AddCommand(continualCmd)
 }
@@ -1,10 +1,22 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/api"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants/enums"
+	reporter_engines "github.com/tez-capital/tezpay/engines/reporter"
+	signer_engines "github.com/tez-capital/tezpay/engines/signer"
+	"github.com/tez-capital/tezpay/state"
 	"github.com/trilitech/tzgo/tezos"
 )
 
@@ -89,3 +101,176 @@ func TestEvaluateProtocolChange_IgnoreModeSecondTransition_NewPairNotifies(t *te
 	assert.Equal(protoC, decision.UpdatedExpectedProtocol)
 	assert.Equal(fmt.Sprintf("%s->%s", protoB, protoC), decision.UpdatedLastNotifiedProtocolPair)
 }
+
+func TestContinualBakerStatuses(t *testing.T) {
+	assert := assert.New(t)
+
+	profiles := make([]*bakerProfileContext, 0, 2)
+	for _, name := range []string{"first", "second"} {
+		key, _ := tezos.GenerateKey(tezos.KeyTypeEd25519)
+		signer, err := signer_engines.InitInMemorySigner(key.String())
+		assert.Nil(err)
+		profiles = append(profiles, &bakerProfileContext{
+			configurationAndEngines: &configurationAndEngines{
+				Configuration: &configuration.RuntimeConfiguration{BakerPKH: key.Address()},
+				Signer:        signer,
+			},
+			Name: name,
+		})
+	}
+
+	statuses := newContinualBakerStatuses()
+	statuses.update(profiles[0], 100, nil)
+	statuses.update(profiles[1], 100, errors.New("failed"))
+
+	result := statuses.get(profiles)
+	assert.Len(result, 2)
+	assert.Equal("first", result[0].Name)
+	assert.Equal(profiles[0].Configuration.BakerPKH, result[0].BakerPKH)
+	assert.Equal(profiles[0].Signer.GetPKH(), result[0].PayoutPKH)
+	assert.Equal(int64(100), result[0].LastProcessedCycle)
+	assert.Empty(result[0].LastError)
+	assert.Equal(int64(0), result[1].LastProcessedCycle)
+	assert.Equal("failed", result[1].LastError)
+
+	// retry succeeds
+	statuses.update(profiles[1], 100, nil)
+	result = statuses.get(profiles)
+	assert.Equal(int64(100), result[1].LastProcessedCycle)
+	assert.Empty(result[1].LastError)
+}
+
+func TestApiServerReportsOfBakerProfiles(t *testing.T) {
+	assert := assert.New(t)
+	assert.Nil(state.Init(t.TempDir(), state.StateInitOptions{}))
+
+	delegator := tezos.MustParseAddress("tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM")
+	config := &configuration.RuntimeConfiguration{
+		Reporting: configuration.RuntimeReportingConfiguration{Kind: enums.REPORTER_KIND_FILESYSTEM},
+	}
+	profiles := make([]*bakerProfileContext, 0, 2)
+	for i, name := range []string{"first", "second"} {
+		key, _ := tezos.GenerateKey(tezos.KeyTypeEd25519)
+		profileConfig := config.ForBaker(&configuration.RuntimeBakerProfile{Name: name, BakerPKH: key.Address(), ReportsDirectory: name})
+		profiles = append(profiles, &bakerProfileContext{
+			configurationAndEngines: &configurationAndEngines{Configuration: profileConfig},
+			Name:                    name,
+		})
+
+		reporter := reporter_engines.NewFileSystemReporter(profileConfig, &common.ReporterEngineOptions{})
+		assert.Nil(reporter.ReportPayouts([]common.PayoutReport{{
+			Baker:     key.Address(),
+			Cycle:     10,
+			Kind:      enums.PAYOUT_KIND_DELEGATOR_REWARD,
+			Delegator: delegator,
+			Recipient: delegator,
+			Amount:    tezos.NewZ(int64(100 * (i + 1))),
+			IsSuccess: true,
+		}}))
+		assert.Nil(reporter.ReportCycleSummary(10, common.CyclePayoutSummary{Delegators: i + 1}))
+	}
+
+	reporters, err := loadApiReporters(profiles, false)
+	assert.Nil(err)
+	defer closeApiReporters(reporters)
+	server := api.NewServer(reporters, func() api.ContinualStatus {
+		return api.ContinualStatus{LastProcessedCycle: 10}
+	})
+	request := func(url string, target any) int {
+		response, err := server.App().Test(httptest.NewRequest("GET", url, nil))
+		assert.Nil(err)
+		body, err := io.ReadAll(response.Body)
+		assert.Nil(err)
+		if target != nil {
+			assert.Nil(json.Unmarshal(body, target))
+		}
+		return response.StatusCode
+	}
+
+	for i, profile := range profiles {
+		var payouts []common.PayoutReport
+		assert.Equal(200, request("/api/bakers/"+profile.Name+"/cycles/10/payouts", &payouts))
+		assert.Len(payouts, 1)
+		assert.Equal(profile.Configuration.BakerPKH, payouts[0].Baker)
+
+		var summary common.CyclePayoutSummary
+		assert.Equal(200, request("/api/bakers/"+profile.Name+"/cycles/10/summary", &summary))
+		assert.Equal(i+1, summary.Delegators)
+	}
+
+	var payouts []common.PayoutReport
+	assert.Equal(200, request("/api/cycles/10/payouts", &payouts))
+	assert.Len(payouts, 2)
+	var history struct {
+		Payouts []common.PayoutReport `json:"payouts"`
+	}
+	assert.Equal(200, request("/api/delegators/"+delegator.String()+"/payouts", &history))
+	assert.Len(history.Payouts, 2)
+}
+
+func TestProcessBakersInContinualMode(t *testing.T) {
+	assert := assert.New(t)
+
+	profiles := make([]*bakerProfileContext, 0, 2)
+	for _, name := range []string{"healthy", "failing"} {
+		key, _ := tezos.GenerateKey(tezos.KeyTypeEd25519)
+		signer, err := signer_engines.InitInMemorySigner(key.String())
+		assert.Nil(err)
+		profiles = append(profiles, &bakerProfileContext{
+			configurationAndEngines: &configurationAndEngines{
+				Configuration: &configuration.RuntimeConfiguration{BakerPKH: key.Address()},
+				Signer:        signer,
+			},
+			Name:               name,
+			lastProcessedCycle: 9,
+		})
+	}
+	healthy, failing := profiles[0], profiles[1]
+
+	processed := make(map[string][]int64)
+	failure := errors.New("empty wallet")
+	process := func(profile *bakerProfileContext, cycle int64, cycles []int64) (*common.PayoutSummary, error) {
+		processed[profile.Name] = append(processed[profile.Name], cycle)
+		if profile == failing && failure != nil {
+			return nil, failure
+		}
+		return &common.PayoutSummary{}, nil
+	}
+
+	results := processBakersInContinualMode(profiles, 10, 1, 0, 0, process)
+	assert.Len(results, 2)
+	assert.Nil(results[0].Err)
+	assert.True(results[1].IsFailureChange)
+	assert.Equal(int64(10), healthy.lastProcessedCycle)
+	assert.Equal(int64(9), failing.lastProcessedCycle)
+	assert.True(hasFailedBakers(profiles))
+
+	// failed baker waits for its retry while healthy one continues
+	results = processBakersInContinualMode(profiles, 11, 1, 0, 0, process)
+	assert.Len(results, 1)
+	assert.Equal("healthy", results[0].Name)
+	assert.Equal(int64(11), healthy.lastProcessedCycle)
+
+	// only failed baker is retried and the same failure is not reported again
+	failing.retryAt = time.Time{}
+	results = processBakersInContinualMode(profiles, 11, 1, 0, 0, process)
+	assert.Len(results, 1)
+	assert.Equal("failing", results[0].Name)
+	assert.Equal(int64(10), results[0].Cycle)
+	assert.False(results[0].IsFailureChange)
+
+	// recovered baker catches up, cycles out of the payout interval are skipped
+	failure = nil
+	failing.retryAt = time.Time{}
+	results = processBakersInContinualMode(profiles, 13, 2, 0, 0, process)
+	assert.Len(results, 3)
+	assert.Equal("failing", results[1].Name)
+	assert.Equal(int64(10), results[1].Cycle)
+	assert.True(results[1].IsFailureChange)
+	assert.False(results[2].IsFailureChange)
+	assert.Equal(int64(13), failing.lastProcessedCycle)
+	assert.Equal(int64(13), healthy.lastProcessedCycle)
+	assert.False(hasFailedBakers(profiles))
+	assert.Equal([]int64{10, 11, 12}, processed["healthy"])
+	assert.Equal([]int64{10, 10, 10, 12}, processed["failing"])
+}
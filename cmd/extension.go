@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+
+	"github.com/hjson/hjson-go/v4"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/extension"
+	"github.com/tez-capital/tezpay/extension/examples"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/tez-capital/tezpay/utils"
+)
+
+var extensionCmd = &cobra.Command{
+	Use:   "extension",
+	Short: "extension development and diagnostics",
+	Long: `Tools for developing and diagnosing extensions.
+
+	Data passed to hooks is captured during dry runs (extension_captures folder) and can be replayed against configured extensions.
+
+	Example:
+		tezpay pay --cycle 580 --dry-run
+		tezpay extension replay after_candidates_generated --cycle 580
+`,
+}
+
+var extensionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "lists configured extensions",
+	Run: func(cmd *cobra.Command, args []string) {
+		config := assertRunWithResultAndErrorMessage(configuration.Load, EXIT_CONFIGURATION_LOAD_FAILURE, "failed to load configuration")
+
+		if state.Global.GetWantsOutputJson() {
+			slog.Info("extensions", "extensions", config.Extensions, "phase", "result")
+			return
+		}
+		if len(config.Extensions) == 0 {
+			slog.Info("no extensions configured", "phase", "result")
+			return
+		}
+		utils.PrintExtensions(config.Extensions, "Extensions")
+	},
+}
+
+var extensionHealthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "checks health of configured extensions",
+	Run: func(cmd *cobra.Command, args []string) {
+		assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE)
+		defer extension.CloseExtensions()
+
+		results := extension.CheckExtensionsHealth()
+		if state.Global.GetWantsOutputJson() {
+			slog.Info("extensions health", "results", lo.Map(results, func(result extension.ExtensionHealth, _ int) map[string]any {
+				return map[string]any{"name": result.Definition.Name, "healthy": result.Err == nil, "error": fmt.Sprint(result.Err)}
+			}), "phase", "result")
+		} else {
+			utils.PrintExtensionsHealth(results, "Extensions Health")
+		}
+
+		if unhealthy := lo.CountBy(results, func(result extension.ExtensionHealth) bool { return result.Err != nil }); unhealthy > 0 {
+			slog.Error("some extensions are not healthy", "unhealthy", unhealthy, "total", len(results))
+			os.Exit(EXIT_OPERTION_FAILED)
+		}
+	},
+}
+
+var extensionReplayCmd = &cobra.Command{
+	Use:   "replay <hook>",
+	Short: "replays hook data captured during dry run against configured extensions",
+	Long:  "executes the hook with data captured during dry run and prints changes made by extensions",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cycle, _ := cmd.Flags().GetInt64(CYCLE_FLAG)
+		hook := enums.EExtensionHook(args[0])
+		capture := assertRunWithResultAndErrorMessage(func() (*extension.HookCapture, error) {
+			return extension.LoadHookCapture(state.Global.GetExtensionCaptureDirectory(), hook, cycle)
+		}, EXIT_INVALID_ARGS, "no captured data found, run dry run of the cycle first", "hook", hook, "cycle", cycle)
+
+		assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE)
+		defer extension.CloseExtensions()
+
+		diff := assertRunWithResultAndErrorMessage(func() (*extension.HookDataDiff, error) {
+			return extension.ReplayHook(capture)
+		}, EXIT_OPERTION_FAILED, "failed to replay hook", "hook", hook)
+
+		if state.Global.GetWantsOutputJson() {
+			slog.Info("hook replayed", "hook", hook, "cycle", cycle, "diff", diff, "phase", "result")
+			return
+		}
+		if diff.IsEmpty() {
+			slog.Info("hook replayed, no changes were made by extensions", "hook", hook, "cycle", cycle, "phase", "result")
+			return
+		}
+		utils.PrintHookDataChanges(diff, fmt.Sprintf("Changes of %s (cycle %d)", hook, cycle))
+	},
+}
+
+var extensionNewCmd = &cobra.Command{
+	Use:   "new <lang>",
+	Short: "scaffolds new extension from template",
+	Long:  fmt.Sprintf("creates new extension based on the log example, supported languages: %v", examples.GetTemplateLanguages()),
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString(OUTPUT_FLAG)
+		template, content, err := examples.GetTemplate(args[0])
+		if err != nil {
+			slog.Error("failed to get extension template", "error", err.Error())
+			os.Exit(EXIT_INVALID_ARGS)
+		}
+
+		file := path.Join(output, template.FileName)
+		assertRunWithErrorMessage(func() error {
+			if _, err := os.Stat(file); err == nil {
+				return errors.Join(constants.ErrExtensionScaffoldFailed, fmt.Errorf("%s already exists", file))
+			}
+			if err := os.MkdirAll(output, 0755); err != nil {
+				return errors.Join(constants.ErrExtensionScaffoldFailed, err)
+			}
+			return os.WriteFile(file, content, 0644)
+		}, EXIT_OPERTION_FAILED, "failed to scaffold extension")
+
+		// the template logs all hooks into file configured by LOG_FILE
+		extensionConfiguration := json.RawMessage(fmt.Sprintf(`{"LOG_FILE": %q}`, path.Join(output, "log.txt")))
+		definition := common.ExtensionDefinition{
+			Name:    path.Base(output),
+			Command: template.Command,
+			Args: lo.Map(template.Args, func(arg string, _ int) string {
+				return lo.Ternary(arg == template.FileName, file, arg)
+			}),
+			Kind: enums.EXTENSION_STDIO_RPC,
+			Hooks: []common.ExtensionHook{{
+				Id:   enums.EXTENSION_HOOK_ALL,
+				Mode: enums.EXTENSION_HOOK_MODE_READ_ONLY,
+			}},
+			Configuration: &extensionConfiguration,
+		}
+		definitionSnippet, _ := hjson.Marshal(definition)
+		slog.Info("extension created, add it to extensions in your configuration and check it with 'tezpay extension health'", "file", file, "phase", "result")
+		fmt.Println(string(definitionSnippet))
+	},
+}
+
+func init() {
+	extensionReplayCmd.Flags().Int64(CYCLE_FLAG, 0, "cycle of the captured data to replay")
+	extensionReplayCmd.MarkFlagRequired(CYCLE_FLAG)
+	extensionNewCmd.Flags().String(OUTPUT_FLAG, "my-extension", "directory to create the extension in")
+
+	extensionCmd.AddCommand(extensionListCmd)
+	extensionCmd.AddCommand(extensionHealthCmd)
+	extensionCmd.AddCommand(extensionReplayCmd)
+	extensionCmd.AddCommand(extensionNewCmd)
+	RootCmd.AddCommand(extensionCmd)
+}
@@ -65,12 +65,14 @@ var generatePayoutsCmd = &cobra.Command{
 		case state.Global.GetWantsOutputJson():
 			slog.Info(constants.LOG_MESSAGE_PAYOUTS_GENERATED, constants.LOG_FIELD_CYCLES, cycles, constants.LOG_FIELD_CYCLE_PAYOUT_BLUEPRINT, generationResults, "phase", "result")
 		default:
-			fsReporter := reporter_engines.NewFileSystemReporter(config, &common.ReporterEngineOptions{
-				IsReadOnly: true,
-				DryRun:     isDryRun,
-			})
+			payoutReporter := assertRunWithResult(func() (common.ReporterEngine, error) {
+				return reporter_engines.Load(config, &common.ReporterEngineOptions{
+					IsReadOnly: true,
+					DryRun:     isDryRun,
+				})
+			}, EXIT_CONFIGURATION_LOAD_FAILURE)
 			preparationResult := assertRunWithResult(func() (*common.PreparePayoutsResult, error) {
-				return core.PreparePayouts(generationResults, config, common.NewPreparePayoutsEngineContext(collector, signer, fsReporter, notifyAdminFactory(config)), &common.PreparePayoutsOptions{
+				return core.PreparePayouts(generationResults, config, common.NewPreparePayoutsEngineContext(collector, signer, payoutReporter, notifyAdminFactory(config)), &common.PreparePayoutsOptions{
 					Accumulate:       true,
 					SkipBalanceCheck: skipBalanceCheck,
 				})
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path"
+
+	"github.com/spf13/cobra"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	reporter_engines "github.com/tez-capital/tezpay/engines/reporter"
+	"github.com/tez-capital/tezpay/state"
+)
+
+var importReportsCmd = &cobra.Command{
+	Use:   "import-reports [source-directory]",
+	Short: "imports csv/json reports into sqlite database",
+	Long: `Imports reports created by filesystem reporter (reports/<cycle>/payouts.csv, invalid.csv and summary.json) into sqlite reports database.
+
+	If source directory is not specified, reports directory is used. Cycles already present in the database are replaced by imported ones.
+
+	Example:
+		tezpay import-reports
+		tezpay import-reports ./old-reports
+`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		isDryRun, _ := cmd.Flags().GetBool(DRY_RUN_FLAG)
+		confirmed, _ := cmd.Flags().GetBool(CONFIRM_FLAG)
+
+		config := assertRunWithResult(func() (*configuration.RuntimeConfiguration, error) {
+			config, err := configuration.Load()
+			if err != nil {
+				return nil, errors.Join(constants.ErrConfigurationLoadFailed, err)
+			}
+			return config, nil
+		}, EXIT_CONFIGURATION_LOAD_FAILURE)
+
+		sourceDirectory := path.Join(state.Global.GetReportsDirectory(), config.Reporting.Subdirectory)
+		if isDryRun {
+			sourceDirectory = path.Join(sourceDirectory, "dry")
+		}
+		if len(args) > 0 {
+			sourceDirectory = args[0]
+		}
+		if _, err := os.Stat(sourceDirectory); err != nil {
+			slog.Error("invalid source directory", "path", sourceDirectory, "error", err.Error())
+			os.Exit(EXIT_INVALID_ARGS)
+		}
+
+		reporter := assertRunWithResultAndErrorMessage(func() (*reporter_engines.SqliteReporter, error) {
+			return reporter_engines.NewSqliteReporter(config, &common.ReporterEngineOptions{
+				DryRun: isDryRun,
+			})
+		}, EXIT_CONFIGURATION_LOAD_FAILURE, "failed to open reports database")
+		defer reporter.Close()
+
+		if !confirmed {
+			assertRequireConfirmation("do you want to import reports from '" + sourceDirectory + "'?")
+		}
+
+		result := assertRunWithResultAndErrorMessage(func() (*reporter_engines.ImportReportsResult, error) {
+			return reporter.ImportFileSystemReports(sourceDirectory)
+		}, EXIT_OPERTION_FAILED, "failed to import reports")
+
+		slog.Info("reports imported", "cycles", result.Cycles, "payouts", result.Payouts, "invalid_payouts", result.InvalidPayouts, "summaries", result.Summaries, "phase", "result")
+		if config.Reporting.Kind != enums.REPORTER_KIND_SQLITE {
+			slog.Warn("configuration.reporting.kind is not set to 'sqlite', imported reports won't be used until you switch the reporter")
+		}
+	},
+}
+
+func init() {
+	importReportsCmd.Flags().Bool(CONFIRM_FLAG, false, "automatically confirms import")
+	importReportsCmd.Flags().Bool(DRY_RUN_FLAG, false, "imports dry run reports ('reports/dry' folder) into dry run database")
+	RootCmd.AddCommand(importReportsCmd)
+}
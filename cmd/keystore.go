@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	signer_engines "github.com/tez-capital/tezpay/engines/signer"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func readPrivateKeyFile() ([]byte, string) {
+	privateKeyFile := state.Global.GetPrivateKeyFilePath()
+	data, err := os.ReadFile(privateKeyFile)
+	if err != nil {
+		slog.Error("failed to read private key file", "path", privateKeyFile, "error", err.Error())
+		os.Exit(EXIT_OPERTION_FAILED)
+	}
+	return data, privateKeyFile
+}
+
+// writes through temporary file so the key is never left half written
+func writePrivateKeyFile(privateKeyFile string, data []byte) error {
+	tmpFile := privateKeyFile + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, privateKeyFile)
+}
+
+func encryptAndWritePrivateKey(privateKeyFile string, key tezos.PrivateKey) {
+	passphrase := assertRunWithResultAndErrorMessage(signer_engines.GetNewKeystorePassphrase, EXIT_OPERTION_FAILED, "failed to get new passphrase")
+	keystore := assertRunWithResultAndErrorMessage(func() (*signer_engines.Keystore, error) {
+		return signer_engines.EncryptPrivateKey(key, passphrase)
+	}, EXIT_OPERTION_FAILED, "failed to encrypt private key")
+	data := assertRunWithResultAndErrorMessage(keystore.ToJSON, EXIT_OPERTION_FAILED, "failed to serialize keystore")
+	assertRunWithErrorMessage(func() error {
+		return writePrivateKeyFile(privateKeyFile, data)
+	}, EXIT_OPERTION_FAILED, "failed to write keystore")
+}
+
+var keystoreCmd = &cobra.Command{
+	Use:   "keystore",
+	Short: "manages encrypted payout wallet keystore",
+	Long: `Encrypts, decrypts and rotates passphrase of payout wallet private key file (payout_wallet_private.key).
+
+	Passphrase is read from TEZPAY_KEYSTORE_PASSPHRASE, from file descriptor specified in TEZPAY_KEYSTORE_PASSPHRASE_FD or prompted interactively.
+	New passphrase (encrypt/rotate) is read from TEZPAY_KEYSTORE_NEW_PASSPHRASE or prompted interactively.
+`,
+}
+
+var keystoreEncryptCmd = &cobra.Command{
+	Use:   "encrypt",
+	Short: "encrypts plaintext private key file",
+	Run: func(cmd *cobra.Command, args []string) {
+		confirmed, _ := cmd.Flags().GetBool(CONFIRM_FLAG)
+		data, privateKeyFile := readPrivateKeyFile()
+		if signer_engines.IsKeystore(data) {
+			slog.Error("private key file is already encrypted, use 'keystore rotate' to change passphrase", "path", privateKeyFile)
+			os.Exit(EXIT_INVALID_ARGS)
+		}
+		key := assertRunWithResultAndErrorMessage(func() (tezos.PrivateKey, error) {
+			return tezos.ParsePrivateKey(strings.TrimSpace(string(data)))
+		}, EXIT_OPERTION_FAILED, "failed to parse private key")
+
+		if !confirmed {
+			assertRequireConfirmation("Do you want to encrypt private key of '" + key.Address().String() + "' stored in '" + privateKeyFile + "'?")
+		}
+		encryptAndWritePrivateKey(privateKeyFile, key)
+		slog.Info("private key encrypted", "path", privateKeyFile, "pkh", key.Address().String(), "phase", "result")
+	},
+}
+
+var keystoreDecryptCmd = &cobra.Command{
+	Use:   "decrypt",
+	Short: "decrypts keystore back to plaintext private key file",
+	Run: func(cmd *cobra.Command, args []string) {
+		confirmed, _ := cmd.Flags().GetBool(CONFIRM_FLAG)
+		data, privateKeyFile := readPrivateKeyFile()
+		if !signer_engines.IsKeystore(data) {
+			slog.Error("private key file is not encrypted", "path", privateKeyFile)
+			os.Exit(EXIT_INVALID_ARGS)
+		}
+		key := assertRunWithResultAndErrorMessage(func() (tezos.PrivateKey, error) {
+			return signer_engines.DecryptKeystoreFile(data)
+		}, EXIT_OPERTION_FAILED, "failed to decrypt keystore")
+
+		if !confirmed {
+			assertRequireConfirmation("⚠️  Private key of '" + key.Address().String() + "' will be stored UNENCRYPTED in '" + privateKeyFile + "'. Do you want to proceed?")
+		}
+		assertRunWithErrorMessage(func() error {
+			return writePrivateKeyFile(privateKeyFile, []byte(key.String()))
+		}, EXIT_OPERTION_FAILED, "failed to write private key")
+		slog.Info("private key decrypted", "path", privateKeyFile, "pkh", key.Address().String(), "phase", "result")
+	},
+}
+
+var keystoreRotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "re-encrypts keystore with new passphrase",
+	Run: func(cmd *cobra.Command, args []string) {
+		data, privateKeyFile := readPrivateKeyFile()
+		if !signer_engines.IsKeystore(data) {
+			slog.Error("private key file is not encrypted, use 'keystore encrypt' first", "path", privateKeyFile)
+			os.Exit(EXIT_INVALID_ARGS)
+		}
+		key := assertRunWithResultAndErrorMessage(func() (tezos.PrivateKey, error) {
+			return signer_engines.DecryptKeystoreFile(data)
+		}, EXIT_OPERTION_FAILED, "failed to decrypt keystore")
+
+		encryptAndWritePrivateKey(privateKeyFile, key)
+		slog.Info("keystore passphrase rotated", "path", privateKeyFile, "pkh", key.Address().String(), "phase", "result")
+	},
+}
+
+func init() {
+	keystoreEncryptCmd.Flags().Bool(CONFIRM_FLAG, false, "automatically confirms encryption")
+	keystoreDecryptCmd.Flags().Bool(CONFIRM_FLAG, false, "automatically confirms decryption")
+	keystoreCmd.AddCommand(keystoreEncryptCmd)
+	keystoreCmd.AddCommand(keystoreDecryptCmd)
+	keystoreCmd.AddCommand(keystoreRotateCmd)
+	RootCmd.AddCommand(keystoreCmd)
+}
@@ -30,10 +30,15 @@ var payDateRangeCmd = &cobra.Command{
 		mixInContractCalls, _ := cmd.Flags().GetBool(DISABLE_SEPARATE_SC_PAYOUTS_FLAG)
 		mixInFATransfers, _ := cmd.Flags().GetBool(DISABLE_SEPARATE_FA_PAYOUTS_FLAG)
 		isDryRun, _ := cmd.Flags().GetBool(DRY_RUN_FLAG)
+		if isDryRun {
+			extension.EnableHookCapture(state.Global.GetExtensionCaptureDirectory())
+		}
 
-		fsReporter := reporter_engines.NewFileSystemReporter(config, &common.ReporterEngineOptions{
-			DryRun: isDryRun,
-		})
+		payoutReporter := assertRunWithResult(func() (common.ReporterEngine, error) {
+			return reporter_engines.Load(config, &common.ReporterEngineOptions{
+				DryRun: isDryRun,
+			})
+		}, EXIT_CONFIGURATION_LOAD_FAILURE)
 		stdioReporter := reporter_engines.NewStdioReporter(config)
 
 		if !state.Global.IsDonationPromptDisabled() && !config.IsDonatingToTezCapital() {
@@ -74,9 +79,10 @@ var payDateRangeCmd = &cobra.Command{
 
 		slog.Info("checking reports of past payouts")
 		preparationResult := assertRunWithResult(func() (*common.PreparePayoutsResult, error) {
-			return core.PreparePayouts(generationResults, config, common.NewPreparePayoutsEngineContext(collector, signer, fsReporter, notifyAdminFactory(config)), &common.PreparePayoutsOptions{
+			return core.PreparePayouts(generationResults, config, common.NewPreparePayoutsEngineContext(collector, signer, payoutReporter, notifyAdminFactory(config)), &common.PreparePayoutsOptions{
 				Accumulate:       true,
 				SkipBalanceCheck: skipBalanceCheck,
+				UpdateOwedLedger: !isDryRun,
 			})
 		}, EXIT_OPERTION_FAILED)
 
@@ -85,6 +91,7 @@ var payDateRangeCmd = &cobra.Command{
 			slog.Info(constants.LOG_MESSAGE_PREPAYOUT_SUMMARY,
 				constants.LOG_FIELD_CYCLES, cycles,
 				constants.LOG_FIELD_REPORTS_OF_PAST_PAYOUTS, preparationResult.ReportsOfPastSuccessfulPayouts,
+				constants.LOG_FIELD_HELD_PAYOUTS, preparationResult.HeldPayouts,
 				constants.LOG_FIELD_ACCUMULATED_PAYOUTS, preparationResult.ValidPayouts,
 				constants.LOG_FIELD_VALID_PAYOUTS, preparationResult.ValidPayouts,
 				constants.LOG_FIELD_INVALID_PAYOUTS, preparationResult.InvalidPayouts,
@@ -113,11 +120,11 @@ var payDateRangeCmd = &cobra.Command{
 		slog.Info("executing payout")
 		executionResult := assertRunWithResult(func() (*common.ExecutePayoutsResult, error) {
 			var reporter common.ReporterEngine
-			reporter = fsReporter
+			reporter = payoutReporter
 			if reportToStdout, _ := cmd.Flags().GetBool(REPORT_TO_STDOUT); reportToStdout {
 				reporter = stdioReporter
 			}
-			return core.ExecutePayouts(preparationResult, config, common.NewExecutePayoutsEngineContext(signer, transactor, reporter, notifyAdminFactory(config)), &common.ExecutePayoutsOptions{
+			return core.ExecutePayouts(preparationResult, config, common.NewExecutePayoutsEngineContext(collector, signer, transactor, reporter, notifyAdminFactory(config)), &common.ExecutePayoutsOptions{
 				MixInContractCalls: mixInContractCalls,
 				MixInFATransfers:   mixInFATransfers,
 				DryRun:             isDryRun,
@@ -139,7 +146,7 @@ var payDateRangeCmd = &cobra.Command{
 		default:
 			utils.PrintBatchResults(executionResult.BatchResults, fmt.Sprintf("Results of %s", utils.FormatCycleNumbers(cycles...)), config.Network.Explorer)
 		}
-		PrintPayoutWalletRemainingBalance(collector, signer)
+		PrintPayoutWalletRemainingBalance(collector, signer, config)
 	},
 }
 
@@ -31,6 +31,9 @@ var payCmd = &cobra.Command{
 		mixInContractCalls, _ := cmd.Flags().GetBool(DISABLE_SEPARATE_SC_PAYOUTS_FLAG)
 		mixInFATransfers, _ := cmd.Flags().GetBool(DISABLE_SEPARATE_FA_PAYOUTS_FLAG)
 		isDryRun, _ := cmd.Flags().GetBool(DRY_RUN_FLAG)
+		if isDryRun {
+			extension.EnableHookCapture(state.Global.GetExtensionCaptureDirectory())
+		}
 
 		payoutInterval, _ := cmd.Flags().GetInt64(PAYMENT_INTERVAL_CYCLES_FLAG)
 		payoutInterval = getBoundedPayoutInterval(payoutInterval)
@@ -39,9 +42,11 @@ var payCmd = &cobra.Command{
 		includePrevious, _ := cmd.Flags().GetInt64(INCLUDE_PREVIOUS_CYCLES_FLAG)
 		includePrevious = boundToInterval(includePrevious, payoutInterval*2, "include-previous-cycles")
 
-		fsReporter := reporter_engines.NewFileSystemReporter(config, &common.ReporterEngineOptions{
-			DryRun: isDryRun,
-		})
+		payoutReporter := assertRunWithResult(func() (common.ReporterEngine, error) {
+			return reporter_engines.Load(config, &common.ReporterEngineOptions{
+				DryRun: isDryRun,
+			})
+		}, EXIT_CONFIGURATION_LOAD_FAILURE)
 		stdioReporter := reporter_engines.NewStdioReporter(config)
 
 		if !state.Global.IsDonationPromptDisabled() && !config.IsDonatingToTezCapital() {
@@ -94,9 +99,10 @@ var payCmd = &cobra.Command{
 
 		slog.Info("checking past reports")
 		preparationResult := assertRunWithResult(func() (*common.PreparePayoutsResult, error) {
-			return core.PreparePayouts(generationResults, config, common.NewPreparePayoutsEngineContext(collector, signer, fsReporter, notifyAdminFactory(config)), &common.PreparePayoutsOptions{
+			return core.PreparePayouts(generationResults, config, common.NewPreparePayoutsEngineContext(collector, signer, payoutReporter, notifyAdminFactory(config)), &common.PreparePayoutsOptions{
 				Accumulate:       true,
 				SkipBalanceCheck: skipBalanceCheck,
+				UpdateOwedLedger: !isDryRun,
 			})
 		}, EXIT_OPERTION_FAILED)
 
@@ -105,6 +111,7 @@ var payCmd = &cobra.Command{
 			slog.Info(constants.LOG_MESSAGE_PREPAYOUT_SUMMARY,
 				constants.LOG_FIELD_CYCLES, cycles,
 				constants.LOG_FIELD_REPORTS_OF_PAST_PAYOUTS, preparationResult.ReportsOfPastSuccessfulPayouts,
+				constants.LOG_FIELD_HELD_PAYOUTS, preparationResult.HeldPayouts,
 				constants.LOG_FIELD_ACCUMULATED_PAYOUTS, preparationResult.ValidPayouts,
 				constants.LOG_FIELD_VALID_PAYOUTS, preparationResult.ValidPayouts,
 				constants.LOG_FIELD_INVALID_PAYOUTS, preparationResult.InvalidPayouts,
@@ -122,6 +129,15 @@ var payCmd = &cobra.Command{
 			os.Exit(0)
 		}
 
+		exportUnsigned, _ := cmd.Flags().GetString(EXPORT_UNSIGNED_FLAG)
+		if exportUnsigned != "" {
+			exportUnsignedPayouts(exportUnsigned, preparationResult, config, collector, signer, transactor, payoutReporter, &common.ExecutePayoutsOptions{
+				MixInContractCalls: mixInContractCalls,
+				MixInFATransfers:   mixInFATransfers,
+			})
+			os.Exit(0)
+		}
+
 		if !confirmed {
 			msg := "Do you want to pay out above VALID payouts?"
 			if isDryRun {
@@ -133,11 +149,11 @@ var payCmd = &cobra.Command{
 		slog.Info("executing payouts")
 		executionResult := assertRunWithResult(func() (*common.ExecutePayoutsResult, error) {
 			var reporter common.ReporterEngine
-			reporter = fsReporter
+			reporter = payoutReporter
 			if reportToStdout, _ := cmd.Flags().GetBool(REPORT_TO_STDOUT); reportToStdout {
 				reporter = stdioReporter
 			}
-			return core.ExecutePayouts(preparationResult, config, common.NewExecutePayoutsEngineContext(signer, transactor, reporter, notifyAdminFactory(config)), &common.ExecutePayoutsOptions{
+			return core.ExecutePayouts(preparationResult, config, common.NewExecutePayoutsEngineContext(collector, signer, transactor, reporter, notifyAdminFactory(config)), &common.ExecutePayoutsOptions{
 				MixInContractCalls: mixInContractCalls,
 				MixInFATransfers:   mixInFATransfers,
 				DryRun:             isDryRun,
@@ -159,7 +175,7 @@ var payCmd = &cobra.Command{
 		default:
 			utils.PrintBatchResults(executionResult.BatchResults, fmt.Sprintf("Results of %s", utils.FormatCycleNumbers(cycles...)), config.Network.Explorer)
 		}
-		PrintPayoutWalletRemainingBalance(collector, signer)
+		PrintPayoutWalletRemainingBalance(collector, signer, config)
 	},
 }
 
@@ -178,6 +194,7 @@ func init() {
 	payCmd.Flags().String(NOTIFICATOR_FLAG, "", "Notify through specific notificator")
 	payCmd.Flags().Bool(SKIP_BALANCE_CHECK_FLAG, false, "skips payout wallet balance check")
 	payCmd.Flags().Bool(DRY_RUN_FLAG, false, "Performs all actions except sending transactions. Reports are stored in 'reports/dry' folder")
+	payCmd.Flags().String(EXPORT_UNSIGNED_FLAG, "", "writes unsigned batches to specified directory to be signed offline with 'sign-batches' instead of paying out")
 
 	RootCmd.AddCommand(payCmd)
 }
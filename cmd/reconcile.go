@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/core"
+	reporter_engines "github.com/tez-capital/tezpay/engines/reporter"
+	"github.com/tez-capital/tezpay/extension"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/tez-capital/tezpay/utils"
+)
+
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "settles payouts recorded before execution",
+	Long: `Settles payouts left in 'recorded before execution' state (e.g. after crash during payout execution).
+
+	Each such payout is looked up on chain (by op hash or by incoming transactions of the recipient) and marked as success or failure.
+	Payouts which never landed are marked as failed so they are paid out again during next payout run.
+
+	Example:
+		tezpay reconcile --cycle 750
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cycle, _ := cmd.Flags().GetInt64(CYCLE_FLAG)
+		confirmed, _ := cmd.Flags().GetBool(CONFIRM_FLAG)
+		isDryRun, _ := cmd.Flags().GetBool(DRY_RUN_FLAG)
+
+		config, collector, signer, _ := assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE).Unwrap()
+		defer extension.CloseExtensions()
+
+		if cycle <= 0 {
+			lastCompletedCycle := assertRunWithResultAndErrorMessage(collector.GetLastCompletedCycle, EXIT_OPERTION_FAILED, "failed to get last completed cycle")
+			cycle = lastCompletedCycle + cycle
+		}
+
+		payoutReporter := assertRunWithResult(func() (common.ReporterEngine, error) {
+			return reporter_engines.Load(config, &common.ReporterEngineOptions{
+				DryRun: isDryRun,
+			})
+		}, EXIT_CONFIGURATION_LOAD_FAILURE)
+		if closer, ok := payoutReporter.(io.Closer); ok {
+			defer closer.Close()
+		}
+
+		reports, err := payoutReporter.GetExistingReports(cycle)
+		switch {
+		case os.IsNotExist(err):
+			slog.Info("no reports found for cycle", "cycle", cycle, "phase", "result")
+			return
+		case err != nil:
+			slog.Error("failed to load reports", "cycle", cycle, "error", err.Error())
+			os.Exit(EXIT_OPERTION_FAILED)
+		}
+
+		// reports of other bakers are kept untouched
+		bakerReports := utils.FilterReportsByBaker(reports, config.BakerPKH)
+		otherReports := utils.RejectReportsByBaker(reports, config.BakerPKH)
+
+		result := assertRunWithResultAndErrorMessage(func() (*common.ReconcilePayoutsResult, error) {
+			return core.ReconcilePayouts(bakerReports, config.PayoutConfiguration.GetPayoutAddress(signer.GetPKH()), collector, &common.ReconcilePayoutsOptions{})
+		}, EXIT_OPERTION_FAILED, "failed to reconcile payouts")
+
+		if len(result.Reconciled) == 0 {
+			slog.Info("no payouts recorded before execution found", "cycle", cycle, "phase", "result")
+			return
+		}
+
+		if state.Global.GetWantsOutputJson() {
+			slog.Info("reconciled payouts", "cycle", cycle, "reconciled", result.Reconciled, "phase", "reconcile_diff")
+		} else {
+			utils.PrintReconciledPayouts(result.Reconciled, fmt.Sprintf("Reconciled Payouts #%d", cycle))
+		}
+
+		changed := 0
+		for _, reconciled := range result.Reconciled {
+			if reconciled.IsChanged() {
+				changed++
+			}
+		}
+		if changed == 0 {
+			slog.Info("nothing to update, all payouts recorded before execution are still unresolved", "cycle", cycle, "phase", "result")
+			return
+		}
+
+		if !confirmed {
+			assertRequireConfirmation(fmt.Sprintf("Do you want to write %d reconciled payouts to cycle #%d report?", changed, cycle))
+		}
+
+		assertRunWithErrorMessage(func() error {
+			return payoutReporter.ReportPayouts(append(result.Reports, otherReports...))
+		}, EXIT_OPERTION_FAILED, "failed to write reconciled reports")
+		slog.Info("reports updated", "cycle", cycle, "reconciled", changed, "unresolved", len(result.Reconciled)-changed, "phase", "result")
+	},
+}
+
+func init() {
+	reconcileCmd.Flags().Int64P(CYCLE_FLAG, "c", 0, "cycle to reconcile (0 or negative values are relative to last completed cycle)")
+	reconcileCmd.Flags().Bool(CONFIRM_FLAG, false, "automatically confirms writing of reconciled reports")
+	reconcileCmd.Flags().Bool(DRY_RUN_FLAG, false, "reconciles reports from 'reports/dry' folder")
+	RootCmd.AddCommand(reconcileCmd)
+}
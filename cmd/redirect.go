@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	collector_engines "github.com/tez-capital/tezpay/engines/collector"
+	"github.com/tez-capital/tezpay/redirects"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/tez-capital/tezpay/utils"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func loadRedirectRegistry() *redirects.Registry {
+	return assertRunWithResultAndErrorMessage(func() (*redirects.Registry, error) {
+		return redirects.Load(state.Global.GetRedirectRegistryFilePath())
+	}, EXIT_OPERTION_FAILED, "failed to load redirect registry")
+}
+
+func saveRedirectRegistry(registry *redirects.Registry) {
+	assertRunWithErrorMessage(func() error {
+		return registry.Save(state.Global.GetRedirectRegistryFilePath())
+	}, EXIT_OPERTION_FAILED, "failed to save redirect registry")
+}
+
+func getAddressFlag(cmd *cobra.Command, flag string) tezos.Address {
+	value, _ := cmd.Flags().GetString(flag)
+	address, err := tezos.ParseAddress(value)
+	if err != nil {
+		slog.Error("invalid address", "flag", flag, "value", value, "error", err.Error())
+		os.Exit(EXIT_INVALID_ARGS)
+	}
+	return address
+}
+
+// getRedirectRequest builds request from flags, baker defaults to the configured one
+func getRedirectRequest(cmd *cobra.Command, config *configuration.RuntimeConfiguration) redirects.RedirectRequest {
+	baker := config.BakerPKH
+	if value, _ := cmd.Flags().GetString(BAKER_FLAG); value != "" {
+		baker = getAddressFlag(cmd, BAKER_FLAG)
+	}
+	timestamp := time.Now().UTC().Truncate(time.Second)
+	if value, _ := cmd.Flags().GetString(TIMESTAMP_FLAG); value != "" {
+		var err error
+		if timestamp, err = time.Parse(time.RFC3339, value); err != nil {
+			slog.Error("invalid timestamp, RFC3339 expected", "value", value, "error", err.Error())
+			os.Exit(EXIT_INVALID_ARGS)
+		}
+	}
+	return redirects.RedirectRequest{
+		Baker:     baker,
+		Delegator: getAddressFlag(cmd, DELEGATOR_FLAG),
+		Recipient: getAddressFlag(cmd, RECIPIENT_FLAG),
+		Timestamp: timestamp,
+	}
+}
+
+func getDelegatorKey(config *configuration.RuntimeConfiguration, delegator tezos.Address) (tezos.Key, error) {
+	collector, err := collector_engines.Load(config, notifyAdminFactory(config))
+	if err != nil {
+		return tezos.InvalidKey, err
+	}
+	key, err := collector.GetManagerKey(delegator)
+	if err != nil {
+		return tezos.InvalidKey, err
+	}
+	if !key.IsValid() {
+		return tezos.InvalidKey, errors.Join(constants.ErrRedirectDelegatorNotRevealed, errors.New(delegator.String()))
+	}
+	return key, nil
+}
+
+var redirectCmd = &cobra.Command{
+	Use:   "redirect",
+	Short: "manages payout redirects requested by delegators",
+	Long: `Manages registry of payout redirects requested by delegators through messages signed by their own key.
+
+	Delegator signs the message printed by 'redirect message', the signature is verified against revealed key of the delegator by 'redirect submit'.
+	Submitted redirects are applied to payouts once approved. All changes are recorded in audit trail of the registry (redirects.json).
+
+	Example:
+		tezpay redirect message --delegator tz1... --recipient tz1...
+		tezpay redirect submit --delegator tz1... --recipient tz1... --timestamp 2024-01-01T00:00:00Z --signature edsig...
+		tezpay redirect approve <id>
+`,
+}
+
+var redirectMessageCmd = &cobra.Command{
+	Use:   "message",
+	Short: "prints message to be signed by the delegator",
+	Run: func(cmd *cobra.Command, args []string) {
+		config := assertRunWithResultAndErrorMessage(configuration.Load, EXIT_CONFIGURATION_LOAD_FAILURE, "failed to load configuration")
+		request := getRedirectRequest(cmd, config)
+		assertRunWithErrorMessage(request.Validate, EXIT_INVALID_ARGS, "invalid redirect request")
+
+		slog.Info("sign the message with the delegator key and submit the signature with the same timestamp",
+			"message", request.GetMessage(), "timestamp", request.Timestamp.Format(time.RFC3339), "phase", "result")
+	},
+}
+
+var redirectSubmitCmd = &cobra.Command{
+	Use:   "submit",
+	Short: "verifies signed redirect request and records it as pending",
+	Run: func(cmd *cobra.Command, args []string) {
+		config := assertRunWithResultAndErrorMessage(configuration.Load, EXIT_CONFIGURATION_LOAD_FAILURE, "failed to load configuration")
+		request := getRedirectRequest(cmd, config)
+		value, _ := cmd.Flags().GetString(SIGNATURE_FLAG)
+		signature, err := tezos.ParseSignature(value)
+		if err != nil {
+			slog.Error("invalid signature", "value", value, "error", err.Error())
+			os.Exit(EXIT_INVALID_ARGS)
+		}
+		key := assertRunWithResultAndErrorMessage(func() (tezos.Key, error) {
+			return getDelegatorKey(config, request.Delegator)
+		}, EXIT_OPERTION_FAILED, "failed to get public key of the delegator")
+
+		registry := loadRedirectRegistry()
+		registration := assertRunWithResultAndErrorMessage(func() (*redirects.Registration, error) {
+			return registry.Submit(request, key, signature)
+		}, EXIT_OPERTION_FAILED, "failed to submit redirect")
+		saveRedirectRegistry(registry)
+		slog.Info("redirect submitted, approve it to apply it to payouts", "id", registration.Id, "delegator", registration.Delegator.String(), "recipient", registration.Recipient.String(), "phase", "result")
+	},
+}
+
+var redirectListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "lists redirect registrations",
+	Run: func(cmd *cobra.Command, args []string) {
+		status, _ := cmd.Flags().GetString(STATUS_FLAG)
+		registry := loadRedirectRegistry()
+		registrations := lo.Filter(registry.Registrations, func(registration *redirects.Registration, _ int) bool {
+			return status == "" || string(registration.Status) == status
+		})
+
+		if state.Global.GetWantsOutputJson() {
+			slog.Info("redirect registrations", "registrations", registrations, "phase", "result")
+			return
+		}
+		if len(registrations) == 0 {
+			slog.Info("no redirect registrations found", "phase", "result")
+			return
+		}
+		utils.PrintRedirectRegistrations(registrations, "Redirects")
+	},
+}
+
+var redirectApproveCmd = &cobra.Command{
+	Use:   "approve <id>",
+	Short: "approves pending redirect, it is applied to next payouts",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		confirmed, _ := cmd.Flags().GetBool(CONFIRM_FLAG)
+		note, _ := cmd.Flags().GetString(NOTE_FLAG)
+		registry := loadRedirectRegistry()
+		registration := assertRunWithResultAndErrorMessage(func() (*redirects.Registration, error) {
+			return registry.Get(args[0])
+		}, EXIT_INVALID_ARGS, "failed to find redirect")
+
+		if !confirmed {
+			assertRequireConfirmation("Do you want to redirect payouts of '" + registration.Delegator.String() + "' to '" + registration.Recipient.String() + "'?")
+		}
+		assertRunWithResultAndErrorMessage(func() (*redirects.Registration, error) {
+			return registry.Approve(registration.Id, note)
+		}, EXIT_OPERTION_FAILED, "failed to approve redirect")
+		saveRedirectRegistry(registry)
+		slog.Info("redirect approved", "id", registration.Id, "delegator", registration.Delegator.String(), "recipient", registration.Recipient.String(), "phase", "result")
+	},
+}
+
+var redirectRevokeCmd = &cobra.Command{
+	Use:   "revoke <id>",
+	Short: "revokes pending or approved redirect",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		note, _ := cmd.Flags().GetString(NOTE_FLAG)
+		registry := loadRedirectRegistry()
+		registration := assertRunWithResultAndErrorMessage(func() (*redirects.Registration, error) {
+			return registry.Revoke(args[0], note)
+		}, EXIT_OPERTION_FAILED, "failed to revoke redirect")
+		saveRedirectRegistry(registry)
+		slog.Info("redirect revoked", "id", registration.Id, "delegator", registration.Delegator.String(), "phase", "result")
+	},
+}
+
+func init() {
+	for _, command := range []*cobra.Command{redirectMessageCmd, redirectSubmitCmd} {
+		command.Flags().String(DELEGATOR_FLAG, "", "address of the delegator requesting the redirect")
+		command.Flags().String(RECIPIENT_FLAG, "", "address payouts are redirected to")
+		command.Flags().String(BAKER_FLAG, "", "address of the baker (defaults to the configured baker)")
+		command.Flags().String(TIMESTAMP_FLAG, "", "timestamp of the request in RFC3339 (defaults to now)")
+		command.MarkFlagRequired(DELEGATOR_FLAG)
+		command.MarkFlagRequired(RECIPIENT_FLAG)
+	}
+	redirectSubmitCmd.Flags().String(SIGNATURE_FLAG, "", "signature of the message made by the delegator key")
+	redirectSubmitCmd.MarkFlagRequired(SIGNATURE_FLAG)
+	redirectSubmitCmd.MarkFlagRequired(TIMESTAMP_FLAG)
+	redirectListCmd.Flags().String(STATUS_FLAG, "", "lists only registrations in the status ("+string(enums.REDIRECT_STATUS_PENDING)+", "+string(enums.REDIRECT_STATUS_APPROVED)+", "+string(enums.REDIRECT_STATUS_REVOKED)+", "+string(enums.REDIRECT_STATUS_SUPERSEDED)+")")
+	redirectApproveCmd.Flags().Bool(CONFIRM_FLAG, false, "automatically confirms approval")
+	redirectApproveCmd.Flags().String(NOTE_FLAG, "", "note recorded in audit trail")
+	redirectRevokeCmd.Flags().String(NOTE_FLAG, "", "note recorded in audit trail")
+	redirectCmd.AddCommand(redirectMessageCmd)
+	redirectCmd.AddCommand(redirectSubmitCmd)
+	redirectCmd.AddCommand(redirectListCmd)
+	redirectCmd.AddCommand(redirectApproveCmd)
+	redirectCmd.AddCommand(redirectRevokeCmd)
+	RootCmd.AddCommand(redirectCmd)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants/enums"
+	signer_engines "github.com/tez-capital/tezpay/engines/signer"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/tez-capital/tezpay/utils"
+)
+
+var signBatchesCmd = &cobra.Command{
+	Use:   "sign-batches <dir>",
+	Short: "signs batches exported with 'pay --export-unsigned'",
+	Long:  "signs batches exported with 'pay --export-unsigned' with the payout wallet key, does not require network access",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dir := args[0]
+		confirmed, _ := cmd.Flags().GetBool(CONFIRM_FLAG)
+
+		signer := state.Global.SignerOverride
+		if signer == nil {
+			signer = assertRunWithResultAndErrorMessage(func() (common.SignerEngine, error) {
+				return signer_engines.Load(string(enums.WALLET_MODE_LOCAL_PRIVATE_KEY))
+			}, EXIT_CONFIGURATION_LOAD_FAILURE, "failed to load payout wallet key")
+		}
+
+		offlinePayouts := assertRunWithResult(func() (*common.OfflinePayouts, error) {
+			return loadOfflinePayouts(dir)
+		}, EXIT_PAYOUTS_READ_FAILURE)
+
+		for i, batch := range offlinePayouts.Batches {
+			assertRunWithErrorMessage(batch.Verify, EXIT_PAYOUTS_READ_FAILURE, "batch does not match its payouts", "batch", i+1)
+		}
+
+		utils.PrintPreparePayoutsResult(&common.PreparePayoutsResult{
+			ValidPayouts: lo.FlatMap(offlinePayouts.Batches, func(batch *common.OfflineBatch, _ int) []*common.AccumulatedPayoutRecipe {
+				return batch.GetRecipeBatch()
+			}),
+		}, &utils.PrintPreparePayoutsResultOptions{AutoMergeRecords: true})
+
+		if !confirmed {
+			assertRequireConfirmation(fmt.Sprintf("Do you want to sign %d batches above with %s?", len(offlinePayouts.Batches), signer.GetPKH().String()))
+		}
+
+		for i, batch := range offlinePayouts.Batches {
+			assertRunWithErrorMessage(func() error {
+				return batch.Sign(signer)
+			}, EXIT_OPERTION_FAILED, "failed to sign batch", "batch", i+1)
+		}
+		assertRunWithErrorMessage(func() error {
+			return writeOfflineBatches(dir, offlinePayouts.Batches)
+		}, EXIT_PAYOUT_WRITE_FAILURE, "failed to write signed batches")
+		slog.Info("batches signed, broadcast them with 'tezpay broadcast-signed'", "path", dir, "batches_count", len(offlinePayouts.Batches), "phase", "result")
+	},
+}
+
+func init() {
+	signBatchesCmd.Flags().Bool(CONFIRM_FLAG, false, "automatically confirms signing")
+	RootCmd.AddCommand(signBatchesCmd)
+}
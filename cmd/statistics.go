@@ -24,15 +24,17 @@ var statisticsCmd = &cobra.Command{
 		if lastCycle == 0 {
 			lastCycle = assertRunWithResult(collector.GetLastCompletedCycle, EXIT_OPERTION_FAILED)
 		}
-		fsReporter := reporter_engines.NewFileSystemReporter(config, &common.ReporterEngineOptions{
-			DryRun: isDryRun,
-		})
+		payoutReporter := assertRunWithResult(func() (common.ReporterEngine, error) {
+			return reporter_engines.Load(config, &common.ReporterEngineOptions{
+				DryRun: isDryRun,
+			})
+		}, EXIT_CONFIGURATION_LOAD_FAILURE)
 
 		var total common.PayoutSummary
 		ok := 0
 		for i := 0; i < n; i++ {
 			cycle := lastCycle - int64(i)
-			summary, err := fsReporter.GetExistingCycleSummary(cycle)
+			summary, err := payoutReporter.GetExistingCycleSummary(cycle)
 			if err != nil {
 				slog.Warn("failed to read report", "cycle", cycle, "error", err.Error())
 				continue
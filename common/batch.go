@@ -10,25 +10,34 @@ import (
 	"github.com/trilitech/tzgo/tezos"
 )
 
+// BatchOverhead is fixed cost of a batch added on top of its payouts, e.g. multisig wrapper
+type BatchOverhead struct {
+	GasLimit     int64
+	StorageLimit int64
+	Bytes        int
+}
+
 type batchBlueprint struct {
 	Payouts     []*AccumulatedPayoutRecipe
 	UsedStorage int64
 	UsedGas     int64
 	Op          *codec.Op
 	limits      OperationLimits
+	overhead    BatchOverhead
 }
 
-func NewBatch(limits *OperationLimits, metadataDeserializationGasLimit int64) batchBlueprint {
+func NewBatch(limits *OperationLimits, metadataDeserializationGasLimit int64, overhead BatchOverhead) batchBlueprint {
 	return batchBlueprint{
 		Payouts:     make([]*AccumulatedPayoutRecipe, 0),
-		UsedStorage: 0,
-		UsedGas:     metadataDeserializationGasLimit,
+		UsedStorage: overhead.StorageLimit,
+		UsedGas:     metadataDeserializationGasLimit + overhead.GasLimit,
 		Op:          codec.NewOp().WithSource(tezos.ZeroAddress).WithBranch(tezos.MustParseBlockHash("BM4VEjb3EGdgNgJhwfVUsUqPYvZWJUHdmKKgabuDkwy6SmUKDve")), // dummy address
 		limits: OperationLimits{
 			HardGasLimitPerOperation:     limits.HardGasLimitPerOperation * 95 / 100,     // little reserve
 			HardStorageLimitPerOperation: limits.HardStorageLimitPerOperation * 95 / 100, // little reserve
 			MaxOperationDataLength:       limits.MaxOperationDataLength * 95 / 100,       // little reserve
 		},
+		overhead: overhead,
 	}
 }
 
@@ -40,7 +49,7 @@ func (b *batchBlueprint) AddPayout(payout *AccumulatedPayoutRecipe) bool {
 		return false
 	}
 	InjectTransferContents(b.Op, payout.Recipient, payout)
-	if len(b.Op.Bytes()) > b.limits.MaxOperationDataLength-constants.DEFAULT_BATCHING_OPERATION_DATA_BUFFER {
+	if len(b.Op.Bytes())+b.overhead.Bytes > b.limits.MaxOperationDataLength-constants.DEFAULT_BATCHING_OPERATION_DATA_BUFFER {
 		return false
 	}
 	b.UsedStorage += payout.OpLimits.StorageLimit
@@ -56,30 +65,50 @@ func (b *batchBlueprint) ToBatch() RecipeBatch {
 
 type RecipeBatch []*AccumulatedPayoutRecipe
 
-func (b RecipeBatch) ToOpExecutionContext(signer SignerEngine, transactor TransactorEngine) (*OpExecutionContext, error) {
-	op := codec.NewOp().WithSource(signer.GetPKH())
-	op.WithTTL(constants.MAX_OPERATION_TTL)
-
+// getContentLimits returns limits of the operation contents, deserialization gas of the whole batch is added to the first one
+func (b RecipeBatch) getContentLimits() []tezos.Limits {
 	serializationGasLimit := lo.Reduce(b, func(acc int64, p *AccumulatedPayoutRecipe, _ int) int64 {
 		return acc + p.OpLimits.DeserializationGasLimit
 	}, int64(0))
 
-	for i, p := range b {
+	return lo.Map(b, func(p *AccumulatedPayoutRecipe, i int) tezos.Limits {
 		buffer := int64(0)
 		if i == 0 {
 			buffer = serializationGasLimit
 		}
-		InjectTransferContentsWithLimits(op, signer.GetPKH(), p, tezos.Limits{
+		return tezos.Limits{
 			Fee:          p.OpLimits.TransactionFee,
 			GasLimit:     p.OpLimits.GasLimit + buffer,
 			StorageLimit: p.OpLimits.StorageLimit,
-		})
+		}
+	})
+}
+
+// ToOp builds unsigned operation of the batch, branch and counters are completed by the transactor unless set
+func (b RecipeBatch) ToOp(source tezos.Address, key tezos.Key, branch tezos.BlockHash, transactor TransactorEngine) (*codec.Op, error) {
+	op := codec.NewOp().WithSource(source)
+	op.WithTTL(constants.MAX_OPERATION_TTL)
+	if branch.IsValid() {
+		op.WithBranch(branch)
 	}
 
-	err := transactor.Complete(op, signer.GetKey())
+	limits := b.getContentLimits()
+	for i, p := range b {
+		InjectTransferContentsWithLimits(op, source, p, limits[i])
+	}
+
+	err := transactor.Complete(op, key)
 	if err != nil {
 		return nil, errors.Join(constants.ErrFailedToCompleteOperation, err)
 	}
+	return op, nil
+}
+
+func (b RecipeBatch) ToOpExecutionContext(signer SignerEngine, transactor TransactorEngine) (*OpExecutionContext, error) {
+	op, err := b.ToOp(signer.GetPKH(), signer.GetKey(), tezos.ZeroBlockHash, transactor)
+	if err != nil {
+		return nil, err
+	}
 
 	slog.Debug("new op context", "op", op.Bytes(), "op_hash", op.Hash())
 	err = signer.Sign(op)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"math/rand"
+	"sync/atomic"
 	"time"
 
 	"github.com/tez-capital/tezpay/constants"
@@ -21,6 +22,7 @@ type cycleMonitor struct {
 	cancelContext context.CancelFunc
 	rpc           *rpc.Client
 	options       CycleMonitorOptions
+	currentCycle  atomic.Int64
 }
 
 func NewCycleMonitor(ctx context.Context, rpc *rpc.Client, options CycleMonitorOptions) (CycleMonitor, error) {
@@ -70,6 +72,7 @@ func (monitor *cycleMonitor) CreateBlockHeaderMonitor() error {
 				continue
 			}
 			cycle := metadata.LevelInfo.Cycle
+			monitor.currentCycle.Store(cycle)
 
 			if metadata.LevelInfo.CyclePosition >= monitor.options.NotificationDelay {
 				monitor.Cycle <- cycle
@@ -113,3 +116,7 @@ func (monitor *cycleMonitor) WaitForNextCompletedCycle(lastProcessedCycle int64)
 func (monitor *cycleMonitor) GetCycleChannel() chan int64 {
 	return monitor.Cycle
 }
+
+func (monitor *cycleMonitor) GetCurrentCycle() int64 {
+	return monitor.currentCycle.Load()
+}
@@ -42,6 +42,10 @@ func (monitor *dummyCycleMonitor) GetCycleChannel() chan int64 {
 	return monitor.Cycle
 }
 
+func (monitor *dummyCycleMonitor) GetCurrentCycle() int64 {
+	return monitor.end
+}
+
 func (monitor *dummyCycleMonitor) WaitForNextCompletedCycle(lastProcessedCycle int64) (int64, error) {
 	cycle, ok := waitForNextCompletedCycle(lastProcessedCycle, monitor)
 	if !ok {
@@ -18,6 +18,13 @@ const (
 	OPERATION_STATUS_UNKNOWN    OperationStatus = "unknown"
 )
 
+type TransactionInfo struct {
+	OpHash    tezos.OpHash
+	Amount    tezos.Z
+	Status    OperationStatus
+	Timestamp time.Time
+}
+
 type CollectorEngine interface {
 	GetId() string
 	RefreshParams() error
@@ -26,6 +33,9 @@ type CollectorEngine interface {
 	GetCycleStakingData(baker tezos.Address, cycle int64) (*BakersCycleData, error)
 	GetCyclesInDateRange(startDate time.Time, endDate time.Time) ([]int64, error)
 	WasOperationApplied(opHash tezos.OpHash) (OperationStatus, error)
+	GetTransactions(source tezos.Address, target tezos.Address, since time.Time, until time.Time) ([]TransactionInfo, error)
+	// returns cycle in which current delegators delegated to the baker, keyed by delegator address
+	GetDelegationStartCycles(baker tezos.Address) (map[string]int64, error)
 	GetBranch(offset int64) (tezos.BlockHash, error)
 	Simulate(o *codec.Op, publicKey tezos.Key) (*rpc.Receipt, error)
 	GetBalance(pkh tezos.Address) (tezos.Z, error)
@@ -33,6 +43,8 @@ type CollectorEngine interface {
 	SendAnalytics(bakerId string, version string)
 	GetCurrentProtocol() (tezos.ProtocolHash, error)
 	IsRevealed(addr tezos.Address) (bool, error)
+	// returns revealed public key of the address
+	GetManagerKey(addr tezos.Address) (tezos.Key, error)
 }
 
 type SignerEngine interface {
@@ -43,9 +55,29 @@ type SignerEngine interface {
 	GetSigner() signer.Signer
 }
 
+// BytesSignerEngine signs arbitrary data, e.g. packed multisig payloads
+type BytesSignerEngine interface {
+	SignBytes(data []byte) (tezos.Signature, error)
+}
+
+// BatchOverheadTransactor wraps batches into an operation with additional costs, e.g. multisig call
+type BatchOverheadTransactor interface {
+	GetBatchOverhead() (BatchOverhead, error)
+}
+
+// MempoolTransactor looks up operations waiting for inclusion
+type MempoolTransactor interface {
+	IsInMempool(opHash tezos.OpHash) (bool, error)
+}
+
 type OpResult interface {
 	GetOpHash() tezos.OpHash
+	// head level at the time the operation was injected
+	GetInjectionLevel() int64
+	// WaitForInclusion waits until the operation is included in a block or head reaches the level, reports whether it was included
+	WaitForInclusion(level int64) (bool, error)
 	WaitForApply() error
+	GetStatus() (OperationStatus, error)
 }
 
 type TransactorEngine interface {
@@ -65,6 +97,8 @@ type NotificatorEngine interface {
 
 type CycleMonitor interface {
 	GetCycleChannel() chan int64
+	// last cycle observed on chain head
+	GetCurrentCycle() int64
 	Cancel()
 	Terminate()
 	CreateBlockHeaderMonitor() error
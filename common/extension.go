@@ -72,6 +72,38 @@ type ExtensionDefinition struct {
 	RetryDelay    *int                        `json:"retry_delay,omitempty"`
 	Timeout       *int                        `json:"timeout,omitempty"`
 	WaitForStart  int                         `json:"wait_for_start,omitempty"`
+	// headers sent with requests of http and ws extensions, e.g. authorization
+	Headers   map[string]string          `json:"headers,omitempty"`
+	Tls       *ExtensionTlsOptions       `json:"tls,omitempty"`
+	Reconnect *ExtensionReconnectOptions `json:"reconnect,omitempty"`
+	// restricts changes of data made by read-write hooks, keyed by hook id ("all" applies to hooks without own entry)
+	Permissions map[enums.EExtensionHook]ExtensionPermissions `json:"permissions,omitempty"`
+}
+
+// ExtensionPermissions lists what read-write hook may change, nothing but listed is allowed
+type ExtensionPermissions struct {
+	// items may be removed from lists, e.g. payout candidates
+	AllowRemove bool `json:"allow_remove,omitempty"`
+	AllowAdd    bool `json:"allow_add,omitempty"`
+	// fields which may be changed, e.g. "fee", "fee_rate", "note"
+	MutableFields []string `json:"mutable_fields,omitempty"`
+	// total of payout amounts may not be increased unless allowed
+	AllowTotalIncrease bool `json:"allow_total_increase,omitempty"`
+}
+
+type ExtensionTlsOptions struct {
+	// pem encoded certificate authority used to verify the extension, system pool is used if not set
+	CaFile string `json:"ca_file,omitempty"`
+	// client certificate and key for mutual tls
+	CertFile           string `json:"cert_file,omitempty"`
+	KeyFile            string `json:"key_file,omitempty"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"`
+}
+
+type ExtensionReconnectOptions struct {
+	Attempts int `json:"attempts,omitempty"`
+	// delay between attempts in seconds
+	Delay int `json:"delay,omitempty"`
 }
 
 func (d ExtensionDefinition) GetLifespan() enums.EExtensionLifespan {
@@ -100,6 +132,31 @@ func (d ExtensionDefinition) GetRetryDelay() int {
 	return 5
 }
 
+// GetPermissions returns permissions of the hook, nil means the hook is not restricted
+func (d ExtensionDefinition) GetPermissions(hook enums.EExtensionHook) *ExtensionPermissions {
+	if permissions, ok := d.Permissions[hook]; ok {
+		return &permissions
+	}
+	if permissions, ok := d.Permissions[enums.EXTENSION_HOOK_ALL]; ok {
+		return &permissions
+	}
+	return nil
+}
+
+func (d ExtensionDefinition) GetReconnectAttempts() int {
+	if d.Reconnect != nil && d.Reconnect.Attempts > 0 {
+		return d.Reconnect.Attempts
+	}
+	return 1
+}
+
+func (d ExtensionDefinition) GetReconnectDelay() int {
+	if d.Reconnect != nil && d.Reconnect.Delay > 0 {
+		return d.Reconnect.Delay
+	}
+	return 1
+}
+
 type ExtensionInitializationResult struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
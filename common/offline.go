@@ -0,0 +1,158 @@
+package common
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// OfflinePayout carries individual recipes of accumulated payout, they are not part of its json
+type OfflinePayout struct {
+	Payout  *AccumulatedPayoutRecipe `json:"payout"`
+	Recipes []*PayoutRecipe          `json:"recipes"`
+}
+
+// OfflineBatch is forged but unsigned operation of a batch together with payouts it carries
+type OfflineBatch struct {
+	Source    tezos.Address   `json:"source"`
+	Operation string          `json:"operation"`
+	Signature string          `json:"signature,omitempty"`
+	Payouts   []OfflinePayout `json:"payouts"`
+}
+
+func NewOfflineBatch(op *codec.Op, batch RecipeBatch) *OfflineBatch {
+	return &OfflineBatch{
+		Source:    op.Source,
+		Operation: hex.EncodeToString(op.Bytes()),
+		Payouts: lo.Map(batch, func(payout *AccumulatedPayoutRecipe, _ int) OfflinePayout {
+			return OfflinePayout{Payout: payout, Recipes: payout.Recipes}
+		}),
+	}
+}
+
+func (b *OfflineBatch) GetRecipeBatch() RecipeBatch {
+	return lo.FilterMap(b.Payouts, func(payout OfflinePayout, _ int) (*AccumulatedPayoutRecipe, bool) {
+		if payout.Payout == nil {
+			return nil, false
+		}
+		payout.Payout.Recipes = payout.Recipes
+		return payout.Payout, true
+	})
+}
+
+func (b *OfflineBatch) IsSigned() bool {
+	return b.Signature != ""
+}
+
+// GetOp decodes the forged operation and attaches signature if the batch is signed
+func (b *OfflineBatch) GetOp() (*codec.Op, error) {
+	data, err := hex.DecodeString(b.Operation)
+	if err != nil {
+		return nil, errors.Join(constants.ErrOfflineBatchInvalid, err)
+	}
+	op, err := codec.DecodeOp(data)
+	if err != nil {
+		return nil, errors.Join(constants.ErrOfflineBatchInvalid, err)
+	}
+	op.WithSource(b.Source)
+	if b.IsSigned() {
+		signature, err := tezos.ParseSignature(b.Signature)
+		if err != nil {
+			return nil, errors.Join(constants.ErrOfflineBatchInvalid, err)
+		}
+		op.WithSignature(signature)
+	}
+	return op, nil
+}
+
+// Verify checks that the forged operation transfers exactly the payouts listed in the batch,
+// each content is compared with the content forged from its payout (destination, amount, fa parameters and limits)
+func (b *OfflineBatch) Verify() error {
+	op, err := b.GetOp()
+	if err != nil {
+		return err
+	}
+	payouts := b.GetRecipeBatch()
+	if len(op.Contents) != len(payouts) {
+		return errors.Join(constants.ErrOfflineBatchInvalid, fmt.Errorf("operation has %d contents, batch has %d payouts", len(op.Contents), len(payouts)))
+	}
+	if lo.SomeBy(payouts, func(payout *AccumulatedPayoutRecipe) bool { return payout.OpLimits == nil }) {
+		return errors.Join(constants.ErrOfflineBatchInvalid, errors.New("payouts are missing operation limits"))
+	}
+	limits := payouts.getContentLimits()
+	for i, content := range op.Contents {
+		tx, ok := content.(*codec.Transaction)
+		if !ok {
+			return errors.Join(constants.ErrOfflineBatchInvalid, fmt.Errorf("content %d is not a transaction", i))
+		}
+		payout := payouts[i]
+		if !tx.Source.Equal(b.Source) {
+			return errors.Join(constants.ErrOfflineBatchInvalid, fmt.Errorf("content %d does not match source", i))
+		}
+		if tx.Limits() != limits[i] {
+			return errors.Join(constants.ErrOfflineBatchInvalid, fmt.Errorf("content %d limits %+v do not match payout limits %+v", i, tx.Limits(), limits[i]))
+		}
+
+		expected := codec.NewOp().WithSource(b.Source)
+		if err := InjectTransferContentsWithLimits(expected, b.Source, payout, limits[i]); err != nil {
+			return errors.Join(constants.ErrOfflineBatchInvalid, err)
+		}
+		expected.Contents[0].WithCounter(tx.GetCounter())
+		forged, err := tx.MarshalBinary()
+		if err != nil {
+			return errors.Join(constants.ErrOfflineBatchInvalid, err)
+		}
+		expectedForged, err := expected.Contents[0].MarshalBinary()
+		if err != nil {
+			return errors.Join(constants.ErrOfflineBatchInvalid, err)
+		}
+		if !bytes.Equal(forged, expectedForged) {
+			return errors.Join(constants.ErrOfflineBatchInvalid, fmt.Errorf("content %d does not match payout to %s", i, payout.Recipient.String()))
+		}
+	}
+	return nil
+}
+
+func (b *OfflineBatch) Sign(signer SignerEngine) error {
+	if !signer.GetPKH().Equal(b.Source) {
+		return errors.Join(constants.ErrOfflineBatchInvalid, fmt.Errorf("batch source %s does not match signer %s", b.Source.String(), signer.GetPKH().String()))
+	}
+	if err := b.Verify(); err != nil {
+		return err
+	}
+	op, err := b.GetOp()
+	if err != nil {
+		return err
+	}
+	op.Signature = tezos.InvalidSignature
+	if err := signer.Sign(op); err != nil {
+		return errors.Join(constants.ErrFailedToSignOperation, err)
+	}
+	b.Signature = op.Signature.String()
+	return nil
+}
+
+// OfflinePayouts holds everything needed to broadcast and report payouts signed on another host
+type OfflinePayouts struct {
+	Baker                          tezos.Address           `json:"baker"`
+	Cycles                         []int64                 `json:"cycles"`
+	Blueprints                     []*CyclePayoutBlueprint `json:"blueprints,omitempty"`
+	InvalidPayouts                 []PayoutRecipe          `json:"invalid_payouts,omitempty"`
+	ReportsOfPastSuccessfulPayouts []PayoutReport          `json:"reports_of_past_successful_payouts,omitempty"`
+	// batches are stored in separate files
+	Batches []*OfflineBatch `json:"-"`
+}
+
+func (payouts *OfflinePayouts) ToPreparePayoutsResult() *PreparePayoutsResult {
+	return &PreparePayoutsResult{
+		Blueprints:                     payouts.Blueprints,
+		InvalidPayouts:                 payouts.InvalidPayouts,
+		ReportsOfPastSuccessfulPayouts: payouts.ReportsOfPastSuccessfulPayouts,
+	}
+}
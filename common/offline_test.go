@@ -0,0 +1,134 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/signer"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type testKeySigner struct {
+	key tezos.PrivateKey
+}
+
+func (s *testKeySigner) GetId() string            { return "testKeySigner" }
+func (s *testKeySigner) Sign(op *codec.Op) error  { return op.Sign(s.key) }
+func (s *testKeySigner) GetPKH() tezos.Address    { return s.key.Address() }
+func (s *testKeySigner) GetKey() tezos.Key        { return s.key.Public() }
+func (s *testKeySigner) GetSigner() signer.Signer { return signer.NewFromKey(s.key) }
+
+func newTestKeySigner() *testKeySigner {
+	key, _ := tezos.GenerateKey(tezos.KeyTypeEd25519)
+	return &testKeySigner{key: key}
+}
+
+func TestOfflineBatch(t *testing.T) {
+	assert := assert.New(t)
+
+	payoutSigner := newTestKeySigner()
+	batch := make(RecipeBatch, 0, 2)
+	for _, amount := range []int64{1_000_000, 2_000_000} {
+		recipe := PayoutRecipe{
+			Baker:     getRandomAddress(),
+			Delegator: getRandomAddress(),
+			Cycle:     800,
+			Recipient: getRandomAddress(),
+			Kind:      enums.PAYOUT_KIND_DELEGATOR_REWARD,
+			TxKind:    enums.PAYOUT_TX_KIND_TEZ,
+			Amount:    tezos.NewZ(amount),
+			IsValid:   true,
+		}
+		accumulated := recipe.AsAccumulated()
+		accumulated.OpLimits = &OpLimits{TransactionFee: 1000, GasLimit: 2000, StorageLimit: 100, DeserializationGasLimit: 50}
+		batch = append(batch, accumulated)
+	}
+
+	offlineBatch := newTestOfflineBatch(assert, payoutSigner, batch)
+
+	assert.Nil(offlineBatch.Verify())
+	assert.False(offlineBatch.IsSigned())
+	recipeBatch := offlineBatch.GetRecipeBatch()
+	assert.Len(recipeBatch, 2)
+	assert.Equal(batch[1].Recipes[0].GetShortIdentifier(), recipeBatch[1].Recipes[0].GetShortIdentifier())
+
+	assert.True(errors.Is(offlineBatch.Sign(newTestKeySigner()), constants.ErrOfflineBatchInvalid))
+	assert.Nil(offlineBatch.Sign(payoutSigner))
+	assert.True(offlineBatch.IsSigned())
+
+	signedOp, err := offlineBatch.GetOp()
+	assert.Nil(err)
+	assert.Equal(int64(11), signedOp.Contents[1].GetCounter())
+	assert.Nil(payoutSigner.GetKey().Verify(signedOp.Digest(), signedOp.Signature))
+
+	// payouts listed in the batch have to match the operation
+	offlineBatch.Payouts[1].Recipes[0].Amount = tezos.NewZ(3_000_000)
+	assert.True(errors.Is(offlineBatch.Verify(), constants.ErrOfflineBatchInvalid))
+	offlineBatch.Payouts[1].Recipes[0].Amount = tezos.NewZ(2_000_000)
+	assert.Nil(offlineBatch.Verify())
+	offlineBatch.Payouts[1].Payout.OpLimits.TransactionFee = 999
+	assert.True(errors.Is(offlineBatch.Verify(), constants.ErrOfflineBatchInvalid))
+	offlineBatch.Payouts[1].Payout.OpLimits = nil
+	assert.True(errors.Is(offlineBatch.Verify(), constants.ErrOfflineBatchInvalid))
+}
+
+func newTestOfflineBatch(assert *assert.Assertions, signer *testKeySigner, batch RecipeBatch) *OfflineBatch {
+	op := codec.NewOp().WithSource(signer.GetPKH()).WithBranch(tezos.MustParseBlockHash("BM4VEjb3EGdgNgJhwfVUsUqPYvZWJUHdmKKgabuDkwy6SmUKDve"))
+	for i, limits := range batch.getContentLimits() {
+		InjectTransferContentsWithLimits(op, signer.GetPKH(), batch[i], limits)
+		op.Contents[i].WithCounter(int64(10 + i))
+	}
+
+	data, err := json.Marshal(NewOfflineBatch(op, batch))
+	assert.Nil(err)
+	offlineBatch := &OfflineBatch{}
+	assert.Nil(json.Unmarshal(data, offlineBatch))
+	return offlineBatch
+}
+
+func TestOfflineBatchVerifyFATransfers(t *testing.T) {
+	assert := assert.New(t)
+
+	payoutSigner := newTestKeySigner()
+	contract := tezos.MustParseAddress("KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn")
+	recipient := getRandomAddress()
+	batch := make(RecipeBatch, 0, 2)
+	for _, txKind := range []enums.EPayoutTransactionKind{enums.PAYOUT_TX_KIND_FA1_2, enums.PAYOUT_TX_KIND_FA2} {
+		recipe := PayoutRecipe{
+			Baker:      getRandomAddress(),
+			Delegator:  getRandomAddress(),
+			Cycle:      800,
+			Recipient:  recipient,
+			Kind:       enums.PAYOUT_KIND_DELEGATOR_REWARD,
+			TxKind:     txKind,
+			FAContract: contract,
+			FATokenId:  tezos.NewZ(1),
+			Amount:     tezos.NewZ(5_000),
+			IsValid:    true,
+		}
+		accumulated := recipe.AsAccumulated()
+		accumulated.OpLimits = &OpLimits{TransactionFee: 1000, GasLimit: 5000, StorageLimit: 100}
+		batch = append(batch, accumulated)
+	}
+
+	offlineBatch := newTestOfflineBatch(assert, payoutSigner, batch)
+	assert.Nil(offlineBatch.Verify())
+
+	// transfer parameters are forged, amount, token id and recipient are checked
+	offlineBatch.Payouts[0].Recipes[0].Amount = tezos.NewZ(6_000)
+	assert.True(errors.Is(offlineBatch.Verify(), constants.ErrOfflineBatchInvalid))
+	offlineBatch.Payouts[0].Recipes[0].Amount = tezos.NewZ(5_000)
+
+	offlineBatch.Payouts[1].Payout.FATokenId = tezos.NewZ(2)
+	assert.True(errors.Is(offlineBatch.Verify(), constants.ErrOfflineBatchInvalid))
+	offlineBatch.Payouts[1].Payout.FATokenId = tezos.NewZ(1)
+	assert.Nil(offlineBatch.Verify())
+
+	offlineBatch.Payouts[1].Payout.Recipient = getRandomAddress()
+	assert.True(errors.Is(offlineBatch.Verify(), constants.ErrOfflineBatchInvalid))
+}
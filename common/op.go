@@ -1,6 +1,12 @@
 package common
 
 import (
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+
+	"github.com/samber/lo"
 	"github.com/tez-capital/tezpay/constants"
 	"github.com/tez-capital/tezpay/constants/enums"
 	"github.com/trilitech/tzgo/codec"
@@ -9,12 +15,26 @@ import (
 	"github.com/trilitech/tzgo/tezos"
 )
 
+// FeeBumpingOptions controls replacement of operations not included in time by the same operation with higher fees
+type FeeBumpingOptions struct {
+	AfterBlocks int64   `json:"after_blocks,omitempty"`
+	FeeIncrease float64 `json:"fee_increase,omitempty"`
+	MaxBumps    int     `json:"max_bumps,omitempty"`
+}
+
 type OpExecutionContext struct {
 	Op         *codec.Op
 	Transactor TransactorEngine
 	result     OpResult
 
 	batch RecipeBatch
+
+	opts       *rpc.CallOptions
+	signer     SignerEngine
+	feeBumping *FeeBumpingOptions
+	bumps      int
+	// results of all dispatched versions of the operation, replaced ones could be applied too
+	results []OpResult
 }
 
 func InitOpExecutionContext(op *codec.Op, transactor TransactorEngine, batch RecipeBatch) *OpExecutionContext {
@@ -26,6 +46,13 @@ func InitOpExecutionContext(op *codec.Op, transactor TransactorEngine, batch Rec
 	}
 }
 
+// WithFeeBumping allows to re-sign the operation with higher fees if it is not included in time
+func (ctx *OpExecutionContext) WithFeeBumping(signer SignerEngine, options *FeeBumpingOptions) *OpExecutionContext {
+	ctx.signer = signer
+	ctx.feeBumping = options
+	return ctx
+}
+
 func (ctx *OpExecutionContext) GetOpHash() tezos.OpHash {
 	if ctx.result == nil {
 		return tezos.ZeroOpHash
@@ -33,19 +60,113 @@ func (ctx *OpExecutionContext) GetOpHash() tezos.OpHash {
 	return ctx.result.GetOpHash()
 }
 
-func (ctx *OpExecutionContext) Dispatch(opts *rpc.CallOptions) error {
-	result, err := ctx.Transactor.Dispatch(ctx.Op, opts)
+func (ctx *OpExecutionContext) canBumpFee() bool {
+	return ctx.feeBumping != nil && ctx.signer != nil && ctx.bumps < ctx.feeBumping.MaxBumps
+}
+
+func (ctx *OpExecutionContext) dispatch() error {
+	result, err := ctx.Transactor.Dispatch(ctx.Op, ctx.opts)
 	if err != nil {
 		return err
 	}
 	ctx.result = result
-	return err
+	ctx.results = append(ctx.results, result)
+	return nil
 }
 
-func (ctx *OpExecutionContext) WaitForApply() error {
+func (ctx *OpExecutionContext) Dispatch(opts *rpc.CallOptions) error {
+	ctx.opts = opts
+	return ctx.dispatch()
+}
+
+// closeOpResult releases resources held by the result, e.g. block observers
+func closeOpResult(result OpResult) {
+	if closer, ok := result.(io.Closer); ok {
+		closer.Close()
+	}
+}
+
+func (ctx *OpExecutionContext) closeResults() {
+	for _, result := range ctx.results {
+		closeOpResult(result)
+	}
+}
+
+// bumpFee re-signs the operation with increased fees and injects it with the same counters to replace the previous one
+func (ctx *OpExecutionContext) bumpFee() error {
+	for _, content := range ctx.Op.Contents {
+		limits := content.Limits()
+		limits.Fee = int64(math.Ceil(float64(limits.Fee) * (1 + ctx.feeBumping.FeeIncrease)))
+		content.WithLimits(limits)
+	}
+	ctx.Op.Signature = tezos.InvalidSignature
+	if err := ctx.signer.Sign(ctx.Op); err != nil {
+		return errors.Join(constants.ErrFailedToSignOperation, err)
+	}
+	ctx.bumps++
+	superseded := ctx.result
+	if err := ctx.dispatch(); err != nil {
+		return err
+	}
+	// superseded operation is still checked for inclusion through operation status
+	closeOpResult(superseded)
+	return nil
+}
+
+// findIncludedResult returns dispatched version of the operation which was included, replaced ones could be included too
+func (ctx *OpExecutionContext) findIncludedResult() (OpResult, bool) {
+	return lo.Find(ctx.results, func(result OpResult) bool {
+		included, err := result.WaitForInclusion(0)
+		return err == nil && included
+	})
+}
+
+// WaitForInclusion waits until the operation is included in a block. Fee is bumped if the operation is not included
+// within after_blocks from its injection, operations not included within MAX_OPERATION_TTL blocks are expired
+func (ctx *OpExecutionContext) WaitForInclusion() error {
 	if ctx.result == nil {
 		return constants.ErrOperationNotDispatched
 	}
+	for {
+		canBumpFee := ctx.canBumpFee()
+		level := ctx.result.GetInjectionLevel() + constants.MAX_OPERATION_TTL
+		if canBumpFee {
+			level = ctx.result.GetInjectionLevel() + ctx.feeBumping.AfterBlocks
+		}
+		included, err := ctx.result.WaitForInclusion(level)
+		if err != nil {
+			return err
+		}
+		if included {
+			return nil
+		}
+		if len(ctx.results) > 1 {
+			if result, ok := ctx.findIncludedResult(); ok {
+				slog.Info("replaced operation was included", "op_hash", result.GetOpHash(), "latest_op_hash", ctx.result.GetOpHash())
+				ctx.result = result
+				return nil
+			}
+		}
+		if !canBumpFee {
+			return constants.ErrOperationNotIncluded
+		}
+		slog.Info("operation not included in time, bumping fee", "op_hash", ctx.result.GetOpHash(), "after_blocks", ctx.feeBumping.AfterBlocks, "bump", ctx.bumps+1)
+		if err := ctx.bumpFee(); err != nil {
+			// replacement is rejected if the operation got included meanwhile
+			if result, ok := ctx.findIncludedResult(); ok {
+				ctx.result = result
+				return nil
+			}
+			return errors.Join(constants.ErrFeeBumpFailed, err)
+		}
+	}
+}
+
+func (ctx *OpExecutionContext) WaitForApply() error {
+	if err := ctx.WaitForInclusion(); err != nil {
+		ctx.closeResults()
+		return err
+	}
 	return ctx.result.WaitForApply()
 }
 
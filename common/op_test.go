@@ -0,0 +1,138 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/rpc"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// testChain advances head level as operations are waited for
+type testChain struct {
+	level int64
+}
+
+type testOpResult struct {
+	chain          *testChain
+	hash           tezos.OpHash
+	injectionLevel int64
+	// level of the block including the operation, 0 if it is never included
+	includedAt int64
+	err        error
+	closed     bool
+}
+
+func (r *testOpResult) GetOpHash() tezos.OpHash  { return r.hash }
+func (r *testOpResult) GetInjectionLevel() int64 { return r.injectionLevel }
+func (r *testOpResult) WaitForInclusion(level int64) (bool, error) {
+	target := max(level, r.chain.level)
+	if r.includedAt > 0 && r.includedAt <= target {
+		r.chain.level = max(r.chain.level, r.includedAt)
+		return true, nil
+	}
+	r.chain.level = target
+	return false, nil
+}
+func (r *testOpResult) WaitForApply() error { return r.err }
+func (r *testOpResult) Close() error {
+	r.closed = true
+	return nil
+}
+func (r *testOpResult) GetStatus() (OperationStatus, error) {
+	return OPERATION_STATUS_UNKNOWN, nil
+}
+
+// testBumpTransactor injects prepared results in order of dispatches, inclusion levels are relative to injection
+type testBumpTransactor struct {
+	TransactorEngine
+	chain    testChain
+	included []int64
+	results  []*testOpResult
+	fees     []int64
+}
+
+func (transactor *testBumpTransactor) Dispatch(op *codec.Op, opts *rpc.CallOptions) (OpResult, error) {
+	if len(transactor.results) >= len(transactor.included) {
+		return nil, errors.New("rejected")
+	}
+	result := &testOpResult{
+		chain:          &transactor.chain,
+		hash:           op.Hash(),
+		injectionLevel: transactor.chain.level,
+	}
+	if included := transactor.included[len(transactor.results)]; included > 0 {
+		result.includedAt = transactor.chain.level + included
+	}
+	transactor.fees = append(transactor.fees, op.Contents[0].Limits().Fee)
+	transactor.results = append(transactor.results, result)
+	return result, nil
+}
+
+func newTestBumpOpExecutionContext(transactor *testBumpTransactor) *OpExecutionContext {
+	transactor.chain.level = 100
+	signer := newTestKeySigner()
+	op := codec.NewOp().WithSource(signer.GetPKH()).WithBranch(tezos.MustParseBlockHash("BM4VEjb3EGdgNgJhwfVUsUqPYvZWJUHdmKKgabuDkwy6SmUKDve"))
+	op.WithTransfer(getRandomAddress(), 1_000_000).WithLimits([]tezos.Limits{{Fee: 1000, GasLimit: 2000}}, 0)
+	op.Sign(signer.key)
+
+	return InitOpExecutionContext(op, transactor, RecipeBatch{}).WithFeeBumping(signer, &FeeBumpingOptions{
+		AfterBlocks: 5,
+		FeeIncrease: .2,
+		MaxBumps:    2,
+	})
+}
+
+func TestOpExecutionContextFeeBumping(t *testing.T) {
+	assert := assert.New(t)
+
+	// not included within after_blocks from injection
+	transactor := &testBumpTransactor{included: []int64{0, 2}}
+	ctx := newTestBumpOpExecutionContext(transactor)
+	assert.Nil(ctx.Dispatch(nil))
+	original := ctx.GetOpHash()
+	assert.Nil(ctx.WaitForApply())
+	assert.Equal([]int64{1000, 1200}, transactor.fees)
+	assert.Equal(int64(105), transactor.results[1].injectionLevel)
+	assert.Equal(int64(107), transactor.chain.level)
+	assert.NotEqual(original, ctx.GetOpHash())
+	assert.Equal(transactor.results[1].hash, ctx.GetOpHash())
+	// superseded operation stops observing blocks
+	assert.True(transactor.results[0].closed)
+	assert.False(transactor.results[1].closed)
+
+	// included operation is not bumped while waiting for confirmations
+	transactor = &testBumpTransactor{included: []int64{3}}
+	ctx = newTestBumpOpExecutionContext(transactor)
+	assert.Nil(ctx.Dispatch(&rpc.CallOptions{Confirmations: 10}))
+	assert.Nil(ctx.WaitForApply())
+	assert.Equal([]int64{1000}, transactor.fees)
+
+	// replaced operation got included, its hash is reported
+	transactor = &testBumpTransactor{included: []int64{7, 0}}
+	ctx = newTestBumpOpExecutionContext(transactor)
+	assert.Nil(ctx.Dispatch(nil))
+	assert.Nil(ctx.WaitForApply())
+	assert.Equal([]int64{1000, 1200}, transactor.fees)
+	assert.Equal(transactor.results[0].hash, ctx.GetOpHash())
+
+	// bumps are exhausted, last operation expires
+	transactor = &testBumpTransactor{included: []int64{0, 0, 0}}
+	ctx = newTestBumpOpExecutionContext(transactor)
+	assert.Nil(ctx.Dispatch(nil))
+	assert.True(errors.Is(ctx.WaitForApply(), constants.ErrOperationNotIncluded))
+	assert.Equal([]int64{1000, 1200, 1440}, transactor.fees)
+	for _, result := range transactor.results {
+		assert.True(result.closed)
+	}
+	assert.Equal(int64(110+constants.MAX_OPERATION_TTL), transactor.chain.level)
+
+	// replacement rejected
+	transactor = &testBumpTransactor{included: []int64{0}}
+	ctx = newTestBumpOpExecutionContext(transactor)
+	assert.Nil(ctx.Dispatch(nil))
+	assert.True(errors.Is(ctx.WaitForApply(), constants.ErrFeeBumpFailed))
+}
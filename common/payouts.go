@@ -463,26 +463,57 @@ func (recipe *AccumulatedPayoutRecipe) DeepClone() *AccumulatedPayoutRecipe {
 }
 
 type CyclePayoutSummary struct {
-	Delegators               int       `json:"delegators"`
-	PaidDelegators           int       `json:"paid_delegators"`
-	OwnStakedBalance         tezos.Z   `json:"own_staked_balance"`
-	OwnDelegatedBalance      tezos.Z   `json:"own_delegated_balance"`
-	ExternalStakedBalance    tezos.Z   `json:"external_staked_balance"`
-	ExternalDelegatedBalance tezos.Z   `json:"external_delegated_balance"`
-	EarnedBlockFees          tezos.Z   `json:"cycle_earned_fees"`
-	EarnedRewards            tezos.Z   `json:"cycle_earned_rewards"`
-	EarnedTotal              tezos.Z   `json:"cycle_earned_total"`
-	DistributedRewards       tezos.Z   `json:"distributed_rewards"`
-	NotDistributedRewards    tezos.Z   `json:"not_distributed_rewards"`
-	BondIncome               tezos.Z   `json:"bond_income"`
-	FeeIncome                tezos.Z   `json:"fee_income"`
-	IncomeTotal              tezos.Z   `json:"total_income"`
-	TxFeesPaidForRewards     tezos.Z   `json:"tx_fees_paid_for_rewards"`
-	TxFeesPaid               tezos.Z   `json:"tx_fees_paid"`
-	DonatedBonds             tezos.Z   `json:"donated_bonds"`
-	DonatedFees              tezos.Z   `json:"donated_fees"`
-	DonatedTotal             tezos.Z   `json:"donated_total"`
-	Timestamp                time.Time `json:"timestamp"`
+	Delegators                   int       `json:"delegators"`
+	PaidDelegators               int       `json:"paid_delegators"`
+	OwnStakedBalance             tezos.Z   `json:"own_staked_balance"`
+	OwnDelegatedBalance          tezos.Z   `json:"own_delegated_balance"`
+	ExternalStakedBalance        tezos.Z   `json:"external_staked_balance"`
+	ExternalDelegatedBalance     tezos.Z   `json:"external_delegated_balance"`
+	EarnedBlockFees              tezos.Z   `json:"cycle_earned_fees"`
+	EarnedRewards                tezos.Z   `json:"cycle_earned_rewards"`
+	EarnedTotal                  tezos.Z   `json:"cycle_earned_total"`
+	DistributedRewards           tezos.Z   `json:"distributed_rewards"`
+	NotDistributedRewards        tezos.Z   `json:"not_distributed_rewards"`
+	BondIncome                   tezos.Z   `json:"bond_income"`
+	FeeIncome                    tezos.Z   `json:"fee_income"`
+	IncomeTotal                  tezos.Z   `json:"total_income"`
+	TxFeesPaidForRewards         tezos.Z   `json:"tx_fees_paid_for_rewards"`
+	TxFeesPaid                   tezos.Z   `json:"tx_fees_paid"`
+	DonatedBonds                 tezos.Z   `json:"donated_bonds"`
+	DonatedFees                  tezos.Z   `json:"donated_fees"`
+	DonatedTotal                 tezos.Z   `json:"donated_total"`
+	StakingRewardsEdge           tezos.Z   `json:"cycle_staking_rewards_edge"`
+	StakingRewardsShared         tezos.Z   `json:"cycle_staking_rewards_paid_by_protocol"`
+	StakingFeeIncome             tezos.Z   `json:"staking_fee_income"`
+	DistributedStakingRewards    tezos.Z   `json:"distributed_staking_rewards"`
+	NotDistributedStakingRewards tezos.Z   `json:"not_distributed_staking_rewards"`
+	Timestamp                    time.Time `json:"timestamp"`
+}
+
+// AddAmounts adds balances, rewards and fees of another summary, delegator counts are left as they are
+func (summary *CyclePayoutSummary) AddAmounts(another *CyclePayoutSummary) {
+	summary.OwnStakedBalance = summary.OwnStakedBalance.Add(another.OwnStakedBalance)
+	summary.OwnDelegatedBalance = summary.OwnDelegatedBalance.Add(another.OwnDelegatedBalance)
+	summary.ExternalStakedBalance = summary.ExternalStakedBalance.Add(another.ExternalStakedBalance)
+	summary.ExternalDelegatedBalance = summary.ExternalDelegatedBalance.Add(another.ExternalDelegatedBalance)
+	summary.EarnedBlockFees = summary.EarnedBlockFees.Add(another.EarnedBlockFees)
+	summary.EarnedRewards = summary.EarnedRewards.Add(another.EarnedRewards)
+	summary.EarnedTotal = summary.EarnedTotal.Add(another.EarnedTotal)
+	summary.DistributedRewards = summary.DistributedRewards.Add(another.DistributedRewards)
+	summary.NotDistributedRewards = summary.NotDistributedRewards.Add(another.NotDistributedRewards)
+	summary.BondIncome = summary.BondIncome.Add(another.BondIncome)
+	summary.FeeIncome = summary.FeeIncome.Add(another.FeeIncome)
+	summary.IncomeTotal = summary.IncomeTotal.Add(another.IncomeTotal)
+	summary.TxFeesPaid = summary.TxFeesPaid.Add(another.TxFeesPaid)
+	summary.TxFeesPaidForRewards = summary.TxFeesPaidForRewards.Add(another.TxFeesPaidForRewards)
+	summary.DonatedBonds = summary.DonatedBonds.Add(another.DonatedBonds)
+	summary.DonatedFees = summary.DonatedFees.Add(another.DonatedFees)
+	summary.DonatedTotal = summary.DonatedTotal.Add(another.DonatedTotal)
+	summary.StakingRewardsEdge = summary.StakingRewardsEdge.Add(another.StakingRewardsEdge)
+	summary.StakingRewardsShared = summary.StakingRewardsShared.Add(another.StakingRewardsShared)
+	summary.StakingFeeIncome = summary.StakingFeeIncome.Add(another.StakingFeeIncome)
+	summary.DistributedStakingRewards = summary.DistributedStakingRewards.Add(another.DistributedStakingRewards)
+	summary.NotDistributedStakingRewards = summary.NotDistributedStakingRewards.Add(another.NotDistributedStakingRewards)
 }
 
 type PayoutSummary struct {
@@ -510,23 +541,7 @@ func (summary *PayoutSummary) AddCycleSummary(cycle int64, another *CyclePayoutS
 	slices.Sort(summary.Cycles)
 	summary.CycleSummaries[cycle] = *another
 
-	summary.OwnStakedBalance = summary.OwnStakedBalance.Add(another.OwnStakedBalance)
-	summary.OwnDelegatedBalance = summary.OwnDelegatedBalance.Add(another.OwnDelegatedBalance)
-	summary.ExternalStakedBalance = summary.ExternalStakedBalance.Add(another.ExternalStakedBalance)
-	summary.ExternalDelegatedBalance = summary.ExternalDelegatedBalance.Add(another.ExternalDelegatedBalance)
-	summary.EarnedBlockFees = summary.EarnedBlockFees.Add(another.EarnedBlockFees)
-	summary.EarnedRewards = summary.EarnedRewards.Add(another.EarnedRewards)
-	summary.EarnedTotal = summary.EarnedTotal.Add(another.EarnedTotal)
-	summary.DistributedRewards = summary.DistributedRewards.Add(another.DistributedRewards)
-	summary.NotDistributedRewards = summary.NotDistributedRewards.Add(another.NotDistributedRewards)
-	summary.BondIncome = summary.BondIncome.Add(another.BondIncome)
-	summary.FeeIncome = summary.FeeIncome.Add(another.FeeIncome)
-	summary.IncomeTotal = summary.IncomeTotal.Add(another.IncomeTotal)
-	summary.TxFeesPaid = summary.TxFeesPaid.Add(another.TxFeesPaid)
-	summary.TxFeesPaidForRewards = summary.TxFeesPaidForRewards.Add(another.TxFeesPaidForRewards)
-	summary.DonatedBonds = summary.DonatedBonds.Add(another.DonatedBonds)
-	summary.DonatedFees = summary.DonatedFees.Add(another.DonatedFees)
-	summary.DonatedTotal = summary.DonatedTotal.Add(another.DonatedTotal)
+	summary.CyclePayoutSummary.AddAmounts(another)
 }
 
 type CyclePayoutBlueprint struct {
@@ -546,12 +561,16 @@ type CyclePayoutBlueprint struct {
 	DonatedBonds             tezos.Z   `json:"donated_bonds"`
 	DonatedFees              tezos.Z   `json:"donated_fees"`
 	DonatedTotal             tezos.Z   `json:"donated_total"`
+	StakingRewardsEdge       tezos.Z   `json:"cycle_staking_rewards_edge"`
+	StakingRewardsShared     tezos.Z   `json:"cycle_staking_rewards_paid_by_protocol"`
+	StakingFeeIncome         tezos.Z   `json:"staking_fee_income"`
 	Timestamp                time.Time `json:"timestamp"`
 }
 
 type GeneratePayoutsEngineContext struct {
 	collector   CollectorEngine
 	signer      SignerEngine
+	reporter    ReporterEngine
 	adminNotify func(msg string)
 }
 
@@ -571,6 +590,17 @@ func (engines *GeneratePayoutsEngineContext) GetCollector() CollectorEngine {
 	return engines.collector
 }
 
+// WithReporter sets reporter used to look up payout history, it is optional for generation
+func (engines *GeneratePayoutsEngineContext) WithReporter(reporter ReporterEngine) *GeneratePayoutsEngineContext {
+	engines.reporter = reporter
+	return engines
+}
+
+// GetReporter returns reporter with payout history, nil if not available
+func (engines *GeneratePayoutsEngineContext) GetReporter() ReporterEngine {
+	return engines.reporter
+}
+
 func (engines *GeneratePayoutsEngineContext) AdminNotify(msg string) {
 	if engines.adminNotify != nil {
 		engines.adminNotify(msg)
@@ -652,6 +682,7 @@ type PreparePayoutsOptions struct {
 	Accumulate               bool `json:"accumulate,omitempty"`
 	SkipBalanceCheck         bool `json:"skip_balance_check,omitempty"`
 	WaitForSufficientBalance bool `json:"wait_for_sufficient_balance,omitempty"`
+	UpdateOwedLedger         bool `json:"update_owed_ledger,omitempty"`
 }
 
 type PreparePayoutsResult struct {
@@ -659,6 +690,7 @@ type PreparePayoutsResult struct {
 	ValidPayouts                         []*AccumulatedPayoutRecipe `json:"payouts,omitempty"`
 	InvalidPayouts                       []PayoutRecipe             `json:"invalid_payouts,omitempty"`
 	ReportsOfPastSuccessfulPayouts       []PayoutReport             `json:"reports_of_past_successful_payouts,omitempty"`
+	HeldPayouts                          []PayoutReport             `json:"held_payouts,omitempty"`
 	BatchMetadataDeserializationGasLimit int64                      `json:"batch_metadata_deserialization_gas_limit,omitempty"`
 }
 
@@ -672,14 +704,16 @@ func (result *PreparePayoutsResult) GetCycles() []int64 {
 }
 
 type ExecutePayoutsEngineContext struct {
+	collector   CollectorEngine
 	signer      SignerEngine
 	transactor  TransactorEngine
 	reporter    ReporterEngine
 	adminNotify func(msg string)
 }
 
-func NewExecutePayoutsEngineContext(signer SignerEngine, transactor TransactorEngine, reporter ReporterEngine, adminNotify func(msg string)) *ExecutePayoutsEngineContext {
+func NewExecutePayoutsEngineContext(collector CollectorEngine, signer SignerEngine, transactor TransactorEngine, reporter ReporterEngine, adminNotify func(msg string)) *ExecutePayoutsEngineContext {
 	return &ExecutePayoutsEngineContext{
+		collector:   collector,
 		signer:      signer,
 		transactor:  transactor,
 		reporter:    reporter,
@@ -687,6 +721,10 @@ func NewExecutePayoutsEngineContext(signer SignerEngine, transactor TransactorEn
 	}
 }
 
+func (engines *ExecutePayoutsEngineContext) GetCollector() CollectorEngine {
+	return engines.collector
+}
+
 func (engines *ExecutePayoutsEngineContext) GetSigner() SignerEngine {
 	return engines.signer
 }
@@ -706,6 +744,9 @@ func (engines *ExecutePayoutsEngineContext) AdminNotify(msg string) {
 }
 
 func (engines *ExecutePayoutsEngineContext) Validate() error {
+	if engines.collector == nil {
+		return errors.Join(constants.ErrMissingEngine, constants.ErrMissingCollectorEngine)
+	}
 	if engines.signer == nil {
 		return errors.Join(constants.ErrMissingEngine, constants.ErrMissingSignerEngine)
 	}
@@ -0,0 +1,34 @@
+package common
+
+import "time"
+
+type ReconcileStatus string
+
+const (
+	RECONCILE_STATUS_PAID       ReconcileStatus = "paid"
+	RECONCILE_STATUS_FAILED     ReconcileStatus = "failed"
+	RECONCILE_STATUS_NOT_LANDED ReconcileStatus = "not landed"
+	RECONCILE_STATUS_UNRESOLVED ReconcileStatus = "unresolved"
+)
+
+type ReconciledPayout struct {
+	Original   PayoutReport    `json:"original"`
+	Reconciled PayoutReport    `json:"reconciled"`
+	Status     ReconcileStatus `json:"status"`
+	Reason     string          `json:"reason,omitempty"`
+}
+
+func (rp *ReconciledPayout) IsChanged() bool {
+	return rp.Status != RECONCILE_STATUS_UNRESOLVED
+}
+
+type ReconcilePayoutsResult struct {
+	// all reports of the cycle with in-flight ones settled
+	Reports    []PayoutReport     `json:"reports"`
+	Reconciled []ReconciledPayout `json:"reconciled"`
+}
+
+type ReconcilePayoutsOptions struct {
+	// used to decide whether missing operation may still land, defaults to time.Now()
+	Now time.Time
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/constants"
 	"github.com/tez-capital/tezpay/constants/enums"
 	"github.com/trilitech/tzgo/base58"
 	"github.com/trilitech/tzgo/tezos"
@@ -118,6 +119,11 @@ func (pr PayoutReport) GetDestinationIdentifier() string {
 	return base58.Encode(hashBytes[:])
 }
 
+// IsInFlight reports whether payout was recorded before execution and its outcome was never written
+func (pr PayoutReport) IsInFlight() bool {
+	return !pr.IsSuccess && pr.Note == constants.ErrPayoutRecordedBeforeExecution.Error()
+}
+
 func (pr *PayoutReport) ToTableRowData() []string {
 	note := pr.Note
 	if pr.OpHash != tezos.ZeroOpHash {
@@ -1,38 +1,47 @@
 package common
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tez-capital/tezpay/constants"
 	"github.com/tez-capital/tezpay/constants/enums"
 	"github.com/trilitech/tzgo/tezos"
 )
 
 type Delegator struct {
-	Address          tezos.Address
-	DelegatedBalance tezos.Z
-	StakedBalance    tezos.Z
-	Emptied          bool
+	Address          tezos.Address `json:"address"`
+	DelegatedBalance tezos.Z       `json:"delegated_balance"`
+	StakedBalance    tezos.Z       `json:"staked_balance"`
+	Emptied          bool          `json:"emptied"`
 }
 
 type BakersCycleData struct {
-	OwnDelegatedBalance               tezos.Z
-	ExternalDelegatedBalance          tezos.Z
-	BlockDelegatedRewards             tezos.Z
-	IdealBlockDelegatedRewards        tezos.Z
-	AttestationsDelegatedRewards      tezos.Z
-	IdealAttestationsDelegatedRewards tezos.Z
-	DalDelegatedRewards               tezos.Z
-	IdealDalDelegatedRewards          tezos.Z
-	BlockDelegatedFees                tezos.Z
-	DelegatorsCount                   int32
-
-	OwnStakedBalance              tezos.Z
-	ExternalStakedBalance         tezos.Z
-	BlockStakingRewardsEdge       tezos.Z
-	AttestationStakingRewardsEdge tezos.Z
-	BlockStakingFees              tezos.Z
-	StakersCount                  int32
-
-	FrozenDepositLimit tezos.Z
-	Delegators         []Delegator
+	OwnDelegatedBalance               tezos.Z `json:"own_delegated_balance"`
+	ExternalDelegatedBalance          tezos.Z `json:"external_delegated_balance"`
+	BlockDelegatedRewards             tezos.Z `json:"block_delegated_rewards"`
+	IdealBlockDelegatedRewards        tezos.Z `json:"ideal_block_delegated_rewards"`
+	AttestationsDelegatedRewards      tezos.Z `json:"attestations_delegated_rewards"`
+	IdealAttestationsDelegatedRewards tezos.Z `json:"ideal_attestations_delegated_rewards"`
+	DalDelegatedRewards               tezos.Z `json:"dal_delegated_rewards"`
+	IdealDalDelegatedRewards          tezos.Z `json:"ideal_dal_delegated_rewards"`
+	BlockDelegatedFees                tezos.Z `json:"block_delegated_fees"`
+	DelegatorsCount                   int32   `json:"delegators_count"`
+
+	OwnStakedBalance                tezos.Z `json:"own_staked_balance"`
+	ExternalStakedBalance           tezos.Z `json:"external_staked_balance"`
+	BlockStakingRewardsEdge         tezos.Z `json:"block_staking_rewards_edge"`
+	AttestationStakingRewardsEdge   tezos.Z `json:"attestation_staking_rewards_edge"`
+	DalStakingRewardsEdge           tezos.Z `json:"dal_staking_rewards_edge"`
+	BlockStakingRewardsShared       tezos.Z `json:"block_staking_rewards_shared"` // paid by the protocol directly to stakers
+	AttestationStakingRewardsShared tezos.Z `json:"attestation_staking_rewards_shared"`
+	DalStakingRewardsShared         tezos.Z `json:"dal_staking_rewards_shared"`
+	BlockStakingFees                tezos.Z `json:"block_staking_fees"`
+	StakersCount                    int32   `json:"stakers_count"`
+
+	FrozenDepositLimit tezos.Z     `json:"frozen_deposit_limit"`
+	Delegators         []Delegator `json:"delegators"`
 }
 
 type ShareInfo struct {
@@ -48,6 +57,16 @@ func (cycleData *BakersCycleData) getIdealDelegatedRewards() tezos.Z {
 	return cycleData.IdealBlockDelegatedRewards.Add(cycleData.IdealAttestationsDelegatedRewards).Add(cycleData.IdealDalDelegatedRewards).Add(cycleData.BlockDelegatedFees)
 }
 
+// GetStakingRewardsEdge returns the part of external stakers' rewards the protocol assigned to the baker
+func (cycleData *BakersCycleData) GetStakingRewardsEdge() tezos.Z {
+	return cycleData.BlockStakingRewardsEdge.Add(cycleData.AttestationStakingRewardsEdge).Add(cycleData.DalStakingRewardsEdge)
+}
+
+// GetStakingRewardsShared returns the part of external stakers' rewards the protocol paid directly to stakers
+func (cycleData *BakersCycleData) GetStakingRewardsShared() tezos.Z {
+	return cycleData.BlockStakingRewardsShared.Add(cycleData.AttestationStakingRewardsShared).Add(cycleData.DalStakingRewardsShared)
+}
+
 // GetTotalDelegatedRewards returns the total rewards for the cycle based on payout mode
 func (cycleData *BakersCycleData) GetTotalDelegatedRewards(payoutMode enums.EPayoutMode) tezos.Z {
 	switch payoutMode {
@@ -66,6 +85,64 @@ func (cycleData *BakersCycleData) GetBakerStakedBalance() tezos.Z {
 	return cycleData.OwnStakedBalance
 }
 
+// Validate checks cycle data for negative balances and duplicated delegators,
+// cycle data may be altered by extensions so we do not trust it blindly
+func (cycleData *BakersCycleData) Validate() error {
+	if cycleData == nil {
+		return errors.Join(constants.ErrInvalidCycleData, errors.New("missing cycle data"))
+	}
+	problems := make([]string, 0)
+	checkNotNegative := func(name string, value tezos.Z) {
+		if value.IsNeg() {
+			problems = append(problems, fmt.Sprintf("%s is negative: %s", name, value.String()))
+		}
+	}
+
+	checkNotNegative("own delegated balance", cycleData.OwnDelegatedBalance)
+	checkNotNegative("external delegated balance", cycleData.ExternalDelegatedBalance)
+	checkNotNegative("block delegated rewards", cycleData.BlockDelegatedRewards)
+	checkNotNegative("ideal block delegated rewards", cycleData.IdealBlockDelegatedRewards)
+	checkNotNegative("attestations delegated rewards", cycleData.AttestationsDelegatedRewards)
+	checkNotNegative("ideal attestations delegated rewards", cycleData.IdealAttestationsDelegatedRewards)
+	checkNotNegative("dal delegated rewards", cycleData.DalDelegatedRewards)
+	checkNotNegative("ideal dal delegated rewards", cycleData.IdealDalDelegatedRewards)
+	checkNotNegative("block delegated fees", cycleData.BlockDelegatedFees)
+	checkNotNegative("own staked balance", cycleData.OwnStakedBalance)
+	checkNotNegative("external staked balance", cycleData.ExternalStakedBalance)
+	checkNotNegative("block staking rewards edge", cycleData.BlockStakingRewardsEdge)
+	checkNotNegative("attestation staking rewards edge", cycleData.AttestationStakingRewardsEdge)
+	checkNotNegative("dal staking rewards edge", cycleData.DalStakingRewardsEdge)
+	checkNotNegative("block staking rewards shared", cycleData.BlockStakingRewardsShared)
+	checkNotNegative("attestation staking rewards shared", cycleData.AttestationStakingRewardsShared)
+	checkNotNegative("dal staking rewards shared", cycleData.DalStakingRewardsShared)
+	checkNotNegative("block staking fees", cycleData.BlockStakingFees)
+	checkNotNegative("frozen deposit limit", cycleData.FrozenDepositLimit)
+	if cycleData.DelegatorsCount < 0 || cycleData.StakersCount < 0 {
+		problems = append(problems, "delegators and stakers count can not be negative")
+	}
+
+	seen := make(map[string]struct{}, len(cycleData.Delegators))
+	for _, delegator := range cycleData.Delegators {
+		address := delegator.Address.String()
+		if !delegator.Address.IsValid() {
+			problems = append(problems, fmt.Sprintf("delegator %s has invalid address", address))
+			continue
+		}
+		if _, ok := seen[address]; ok {
+			problems = append(problems, fmt.Sprintf("delegator %s is duplicated", address))
+			continue
+		}
+		seen[address] = struct{}{}
+		checkNotNegative(fmt.Sprintf("delegated balance of %s", address), delegator.DelegatedBalance)
+		checkNotNegative(fmt.Sprintf("staked balance of %s", address), delegator.StakedBalance)
+	}
+
+	if len(problems) > 0 {
+		return errors.Join(constants.ErrInvalidCycleData, errors.New(strings.Join(problems, "; ")))
+	}
+	return nil
+}
+
 type OperationLimits struct {
 	HardGasLimitPerOperation     int64
 	HardStorageLimitPerOperation int64
@@ -0,0 +1,38 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func TestBakersCycleDataValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	delegator := tezos.MustParseAddress("tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM")
+	cycleData := &BakersCycleData{
+		OwnDelegatedBalance: tezos.NewZ(1000),
+		Delegators: []Delegator{
+			{Address: delegator, DelegatedBalance: tezos.NewZ(100)},
+			{Address: tezos.MustParseAddress("tz1hZvgjekGo7DmQjWh7XnY5eLQD8wNYPczE"), StakedBalance: tezos.NewZ(100)},
+		},
+	}
+	assert.Nil(cycleData.Validate())
+
+	var missing *BakersCycleData
+	assert.True(errors.Is(missing.Validate(), constants.ErrInvalidCycleData))
+
+	cycleData.BlockDelegatedRewards = tezos.NewZ(-1)
+	assert.True(errors.Is(cycleData.Validate(), constants.ErrInvalidCycleData))
+	cycleData.BlockDelegatedRewards = tezos.Zero
+
+	cycleData.Delegators[1].DelegatedBalance = tezos.NewZ(-1)
+	assert.ErrorContains(cycleData.Validate(), "delegated balance of tz1hZvgjekGo7DmQjWh7XnY5eLQD8wNYPczE is negative")
+	cycleData.Delegators[1].DelegatedBalance = tezos.Zero
+
+	cycleData.Delegators = append(cycleData.Delegators, Delegator{Address: delegator})
+	assert.ErrorContains(cycleData.Validate(), "delegator tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM is duplicated")
+}
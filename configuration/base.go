@@ -1,12 +1,14 @@
 package configuration
 
 import (
+	"cmp"
 	"encoding/json"
 	"log/slog"
 	"math"
 	"os"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/trilitech/tzgo/tezos"
 
@@ -16,6 +18,7 @@ import (
 	tezpay_configuration "github.com/tez-capital/tezpay/configuration/v"
 	"github.com/tez-capital/tezpay/constants"
 	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/redirects"
 	"github.com/tez-capital/tezpay/state"
 )
 
@@ -40,113 +43,374 @@ func preprocessDonationMap(donations map[string]float64) map[string]float64 {
 	return donations
 }
 
-func ConfigurationToRuntimeConfiguration(configuration *LatestConfigurationType) (*RuntimeConfiguration, error) {
-	delegatorFeeOverrides := make(map[string]float64)
-	for k, addresses := range configuration.Delegators.FeeOverrides {
+func feeScheduleToRuntime(schedule []tezpay_configuration.FeeScheduleEntryV0) RuntimeFeeSchedule {
+	if len(schedule) == 0 {
+		return nil
+	}
+	result := lo.Map(schedule, func(entry tezpay_configuration.FeeScheduleEntryV0, _ int) RuntimeFeeScheduleEntry {
+		return RuntimeFeeScheduleEntry{
+			FromCycle: entry.FromCycle,
+			Fee:       entry.Fee,
+		}
+	})
+	slices.SortStableFunc(result, func(a, b RuntimeFeeScheduleEntry) int {
+		return cmp.Compare(a.FromCycle, b.FromCycle)
+	})
+	return result
+}
+
+// parseFeeOverrideKey parses fee_overrides key in form '<fee>' or '<fee>@<cycle>'
+func parseFeeOverrideKey(key string) (fee float64, fromCycle int64, err error) {
+	feePart, cyclePart, hasCycle := strings.Cut(key, "@")
+	fee, err = strconv.ParseFloat(strings.TrimSpace(feePart), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	if hasCycle {
+		fromCycle, err = strconv.ParseInt(strings.TrimSpace(cyclePart), 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	return fee, fromCycle, nil
+}
+
+func feeTiersToRuntime(tiers []tezpay_configuration.FeeTierV0) RuntimeFeeTiers {
+	if len(tiers) == 0 {
+		return nil
+	}
+	result := lo.Map(tiers, func(tier tezpay_configuration.FeeTierV0, _ int) RuntimeFeeTier {
+		var maximumBalance *tezos.Z
+		if tier.MaximumBalance != nil {
+			mb := FloatAmountToMutez(*tier.MaximumBalance)
+			maximumBalance = &mb
+		}
+		return RuntimeFeeTier{
+			MinimumBalance: FloatAmountToMutez(tier.MinimumBalance),
+			MaximumBalance: maximumBalance,
+			Fee:            tier.Fee,
+		}
+	})
+	slices.SortStableFunc(result, func(a, b RuntimeFeeTier) int {
+		return a.MinimumBalance.Cmp(b.MinimumBalance)
+	})
+	return result
+}
+
+func loyaltyRulesToRuntime(rules []tezpay_configuration.LoyaltyRuleV0) RuntimeLoyaltyRules {
+	if len(rules) == 0 {
+		return nil
+	}
+	result := lo.Map(rules, func(rule tezpay_configuration.LoyaltyRuleV0, _ int) RuntimeLoyaltyRule {
+		return RuntimeLoyaltyRule{
+			AfterCycles: rule.AfterCycles,
+			Discount:    rule.Discount,
+		}
+	})
+	slices.SortStableFunc(result, func(a, b RuntimeLoyaltyRule) int {
+		return cmp.Compare(a.AfterCycles, b.AfterCycles)
+	})
+	return result
+}
+
+func delegatorsConfigurationToRuntime(delegators *tezpay_configuration.DelegatorsConfigurationV0) (RuntimeDelegatorsConfiguration, error) {
+	delegatorFeeOverrides := make(map[string]RuntimeDelegatorOverride)
+	for k, addresses := range delegators.FeeOverrides {
+		fee, fromCycle, err := parseFeeOverrideKey(k)
+		if err != nil {
+			return RuntimeDelegatorsConfiguration{}, err
+		}
 		for _, a := range addresses {
-			fee, err := strconv.ParseFloat(k, 64)
-			if err != nil {
-				return nil, err
+			feeOverride := delegatorFeeOverrides[a.String()]
+			if fromCycle == 0 {
+				feeOverride.Fee = &fee
+			} else {
+				feeOverride.FeeSchedule = append(feeOverride.FeeSchedule, RuntimeFeeScheduleEntry{FromCycle: fromCycle, Fee: fee})
 			}
-			delegatorFeeOverrides[a.String()] = fee
+			delegatorFeeOverrides[a.String()] = feeOverride
 		}
 	}
 
-	delegatorOverrides := lo.MapEntries(configuration.Delegators.Overrides, func(k string, delegatorOverride tezpay_configuration.DelegatorOverrideV0) (string, RuntimeDelegatorOverride) {
+	delegatorOverrides := lo.MapEntries(delegators.Overrides, func(k string, delegatorOverride tezpay_configuration.DelegatorOverrideV0) (string, RuntimeDelegatorOverride) {
 		var stakeLimit *tezos.Z = nil
 		if delegatorOverride.MaximumBalance != nil {
 			sl := FloatAmountToMutez(*delegatorOverride.MaximumBalance)
 			stakeLimit = &sl
 		}
+		var minimumPayoutAmount *tezos.Z = nil
+		if delegatorOverride.MinimumPayoutAmount != nil {
+			mpa := FloatAmountToMutez(*delegatorOverride.MinimumPayoutAmount)
+			minimumPayoutAmount = &mpa
+		}
 		return k, RuntimeDelegatorOverride{
 			Recipient:                    delegatorOverride.Recipient,
 			Fee:                          delegatorOverride.Fee,
+			FeeSchedule:                  feeScheduleToRuntime(delegatorOverride.FeeSchedule),
+			StakingFee:                   delegatorOverride.StakingFee,
 			MinimumBalance:               FloatAmountToMutez(delegatorOverride.MinimumBalance),
 			IsBakerPayingTxFee:           delegatorOverride.IsBakerPayingTxFee,
 			IsBakerPayingAllocationTxFee: delegatorOverride.IsBakerPayingAllocationTxFee,
 			MaximumBalance:               stakeLimit,
+			PayoutInterval:               delegatorOverride.PayoutInterval,
+			MinimumPayoutAmount:          minimumPayoutAmount,
 		}
 	})
-	for k, v := range delegatorFeeOverrides {
-		fee := v
+	for k, feeOverride := range delegatorFeeOverrides {
+		slices.SortStableFunc(feeOverride.FeeSchedule, func(a, b RuntimeFeeScheduleEntry) int {
+			return cmp.Compare(a.FromCycle, b.FromCycle)
+		})
 		if delegatorOverride, ok := delegatorOverrides[k]; ok {
+			// explicit overrides take precedence over shortcuts
 			if delegatorOverride.Fee == nil {
-				delegatorOverride.Fee = &fee
+				delegatorOverride.Fee = feeOverride.Fee
 			}
+			if len(delegatorOverride.FeeSchedule) == 0 {
+				delegatorOverride.FeeSchedule = feeOverride.FeeSchedule
+			}
+			delegatorOverrides[k] = delegatorOverride
 			continue
 		}
-		delegatorOverrides[k] = RuntimeDelegatorOverride{
-			Fee: &fee,
-		}
+		delegatorOverrides[k] = feeOverride
+	}
+
+	delegatorBellowMinimumBalanceRewardDestination := enums.REWARD_DESTINATION_NONE
+	if delegators.Requirements.BellowMinimumBalanceRewardDestination != nil {
+		delegatorBellowMinimumBalanceRewardDestination = *delegators.Requirements.BellowMinimumBalanceRewardDestination
+	}
+
+	feeTiersBalance := delegators.FeeTiersBalance
+	if feeTiersBalance == "" {
+		feeTiersBalance = enums.FEE_TIER_BALANCE_TOTAL
 	}
 
-	walletMode := configuration.PayoutConfiguration.WalletMode
+	return RuntimeDelegatorsConfiguration{
+		Requirements: RuntimeDelegatorRequirements{
+			MinimumBalance:                        FloatAmountToMutez(delegators.Requirements.MinimumBalance),
+			BellowMinimumBalanceRewardDestination: delegatorBellowMinimumBalanceRewardDestination,
+		},
+		Overrides:       delegatorOverrides,
+		Ignore:          delegators.Ignore,
+		Prefilter:       delegators.Prefilter,
+		FeeTiers:        feeTiersToRuntime(delegators.FeeTiers),
+		FeeTiersBalance: feeTiersBalance,
+		Loyalty:         loyaltyRulesToRuntime(delegators.Loyalty),
+	}, nil
+}
+
+func payoutConfigurationToRuntime(payouts *tezpay_configuration.PayoutConfigurationV0) RuntimePayoutConfiguration {
+	walletMode := payouts.WalletMode
 	if walletMode == "" {
 		walletMode = enums.WALLET_MODE_LOCAL_PRIVATE_KEY
 	}
-	payoutMode := configuration.PayoutConfiguration.PayoutMode
+	payoutMode := payouts.PayoutMode
 	if payoutMode == "" {
 		payoutMode = enums.PAYOUT_MODE_ACTUAL
 	}
 
 	gasLimitBuffer := int64(constants.DEFAULT_TX_GAS_LIMIT_BUFFER)
-	if configuration.PayoutConfiguration.TxGasLimitBuffer != nil {
-		gasLimitBuffer = *configuration.PayoutConfiguration.TxGasLimitBuffer
+	if payouts.TxGasLimitBuffer != nil {
+		gasLimitBuffer = *payouts.TxGasLimitBuffer
 	}
 
 	ktGasLimitBuffer := int64(constants.DEFAULT_KT_TX_GAS_LIMIT_BUFFER)
-	if configuration.PayoutConfiguration.KtTxGasLimitBuffer != nil {
-		ktGasLimitBuffer = *configuration.PayoutConfiguration.KtTxGasLimitBuffer
+	if payouts.KtTxGasLimitBuffer != nil {
+		ktGasLimitBuffer = *payouts.KtTxGasLimitBuffer
 	}
 
 	deserializaGasBuffer := int64(constants.DEFAULT_TX_DESERIALIZATION_GAS_BUFFER)
-	if configuration.PayoutConfiguration.TxDeserializationGasBuffer != nil {
-		deserializaGasBuffer = *configuration.PayoutConfiguration.TxDeserializationGasBuffer
+	if payouts.TxDeserializationGasBuffer != nil {
+		deserializaGasBuffer = *payouts.TxDeserializationGasBuffer
 	}
 
 	feeBuffer := int64(constants.DEFAULT_TX_FEE_BUFFER)
-	if configuration.PayoutConfiguration.TxFeeBuffer != nil {
-		feeBuffer = *configuration.PayoutConfiguration.TxFeeBuffer
+	if payouts.TxFeeBuffer != nil {
+		feeBuffer = *payouts.TxFeeBuffer
 	}
 
 	ktFeeBuffer := int64(constants.DEFAULT_KT_TX_FEE_BUFFER)
-	if configuration.PayoutConfiguration.KtTxFeeBuffer != nil {
-		ktFeeBuffer = *configuration.PayoutConfiguration.KtTxFeeBuffer
+	if payouts.KtTxFeeBuffer != nil {
+		ktFeeBuffer = *payouts.KtTxFeeBuffer
+	}
+
+	minimumPayoutDelayBlocks := constants.DEFAULT_CYCLE_MONITOR_MINIMUM_DELAY
+	if payouts.MinimumDelayBlocks != nil && *payouts.MaximumDelayBlocks > 0 {
+		minimumPayoutDelayBlocks = *payouts.MinimumDelayBlocks
+	}
+
+	maximumPayoutDelayBlocks := constants.DEFAULT_CYCLE_MONITOR_MAXIMUM_DELAY
+	if payouts.MaximumDelayBlocks != nil && *payouts.MaximumDelayBlocks > 0 {
+		maximumPayoutDelayBlocks = *payouts.MaximumDelayBlocks
+	}
+
+	simulationBatchSize := constants.DEFAULT_SIMULATION_TX_BATCH_SIZE
+	if payouts.SimulationBatchSize != nil && *payouts.SimulationBatchSize > 0 {
+		simulationBatchSize = *payouts.SimulationBatchSize
+	}
+
+	maxBatchesInFlight := constants.DEFAULT_MAX_BATCHES_IN_FLIGHT
+	if payouts.MaxBatchesInFlight != nil && *payouts.MaxBatchesInFlight > 0 {
+		maxBatchesInFlight = *payouts.MaxBatchesInFlight
+	}
+
+	return RuntimePayoutConfiguration{
+		WalletMode:                 walletMode,
+		PayoutMode:                 payoutMode,
+		Fee:                        payouts.Fee,
+		FeeSchedule:                feeScheduleToRuntime(payouts.FeeSchedule),
+		StakingFee:                 payouts.StakingFee,
+		IsPayingTxFee:              payouts.IsPayingTxFee,
+		IsPayingAllocationTxFee:    payouts.IsPayingAllocationTxFee,
+		MinimumAmount:              FloatAmountToMutez(payouts.MinimumAmount),
+		IgnoreEmptyAccounts:        payouts.IgnoreEmptyAccounts,
+		TxGasLimitBuffer:           gasLimitBuffer,
+		KtTxGasLimitBuffer:         ktGasLimitBuffer,
+		TxDeserializationGasBuffer: deserializaGasBuffer,
+		TxFeeBuffer:                feeBuffer,
+		KtTxFeeBuffer:              ktFeeBuffer,
+		MinimumDelayBlocks:         minimumPayoutDelayBlocks,
+		MaximumDelayBlocks:         maximumPayoutDelayBlocks,
+		SimulationBatchSize:        simulationBatchSize,
+		MaxBatchesInFlight:         maxBatchesInFlight,
+		FeeBumping:                 feeBumpingToRuntime(payouts.FeeBumping),
+		Multisig:                   multisigToRuntime(payouts.Multisig),
+	}
+}
+
+func feeBumpingToRuntime(feeBumping *tezpay_configuration.FeeBumpingConfigurationV0) *common.FeeBumpingOptions {
+	if feeBumping == nil {
+		return nil
+	}
+	result := &common.FeeBumpingOptions{
+		AfterBlocks: constants.DEFAULT_FEE_BUMPING_AFTER_BLOCKS,
+		FeeIncrease: constants.DEFAULT_FEE_BUMPING_FEE_INCREASE,
+		MaxBumps:    constants.DEFAULT_FEE_BUMPING_MAX_BUMPS,
+	}
+	if feeBumping.AfterBlocks != nil {
+		result.AfterBlocks = *feeBumping.AfterBlocks
+	}
+	if feeBumping.FeeIncrease != nil {
+		result.FeeIncrease = *feeBumping.FeeIncrease
 	}
+	if feeBumping.MaxBumps != nil {
+		result.MaxBumps = *feeBumping.MaxBumps
+	}
+	return result
+}
 
+func multisigToRuntime(multisig *tezpay_configuration.MultisigConfigurationV0) *RuntimeMultisigConfiguration {
+	if multisig == nil {
+		return nil
+	}
+	return &RuntimeMultisigConfiguration{
+		Contract: multisig.Contract,
+		Signers:  multisig.Signers,
+	}
+}
+
+func incomeRecipientsToRuntime(incomeRecipients *tezpay_configuration.IncomeRecipientsV0) RuntimeIncomeRecipients {
 	donate := constants.DEFAULT_DONATION_PERCENTAGE
-	if configuration.IncomeRecipients.Donate != nil {
-		donate = *configuration.IncomeRecipients.Donate
+	if incomeRecipients.Donate != nil {
+		donate = *incomeRecipients.Donate
 	}
 
 	donateBonds := donate
-	if configuration.IncomeRecipients.DonateBonds != nil {
-		donateBonds = *configuration.IncomeRecipients.DonateBonds
+	if incomeRecipients.DonateBonds != nil {
+		donateBonds = *incomeRecipients.DonateBonds
 	}
 
 	donateFees := donate
-	if configuration.IncomeRecipients.DonateFees != nil {
-		donateFees = *configuration.IncomeRecipients.DonateFees
+	if incomeRecipients.DonateFees != nil {
+		donateFees = *incomeRecipients.DonateFees
 	}
 
-	delegatorBellowMinimumBalanceRewardDestination := enums.REWARD_DESTINATION_NONE
-	if configuration.Delegators.Requirements.BellowMinimumBalanceRewardDestination != nil {
-		delegatorBellowMinimumBalanceRewardDestination = *configuration.Delegators.Requirements.BellowMinimumBalanceRewardDestination
+	return RuntimeIncomeRecipients{
+		Bonds:       incomeRecipients.Bonds,
+		Fees:        incomeRecipients.Fees,
+		Donations:   preprocessDonationMap(incomeRecipients.Donations),
+		DonateFees:  donateFees,
+		DonateBonds: donateBonds,
 	}
+}
 
-	minimumPayoutDelayBlocks := constants.DEFAULT_CYCLE_MONITOR_MINIMUM_DELAY
-	if configuration.PayoutConfiguration.MinimumDelayBlocks != nil && *configuration.PayoutConfiguration.MaximumDelayBlocks > 0 {
-		minimumPayoutDelayBlocks = *configuration.PayoutConfiguration.MinimumDelayBlocks
+func bakerProfilesToRuntime(profiles []tezpay_configuration.BakerProfileV0, payouts RuntimePayoutConfiguration, delegators RuntimeDelegatorsConfiguration, incomeRecipients RuntimeIncomeRecipients) ([]RuntimeBakerProfile, error) {
+	result := make([]RuntimeBakerProfile, 0, len(profiles))
+	for _, profile := range profiles {
+		runtimeProfile := RuntimeBakerProfile{
+			Name:                profile.Name,
+			BakerPKH:            profile.BakerPKH,
+			PayoutWallet:        profile.PayoutWallet,
+			PayoutConfiguration: payouts,
+			Delegators:          delegators,
+			IncomeRecipients:    incomeRecipients,
+			ReportsDirectory:    profile.ReportsDirectory,
+		}
+		if runtimeProfile.Name == "" {
+			runtimeProfile.Name = profile.BakerPKH.String()
+		}
+		if runtimeProfile.ReportsDirectory == "" {
+			runtimeProfile.ReportsDirectory = runtimeProfile.Name
+		}
+		if profile.PayoutConfiguration != nil {
+			runtimeProfile.PayoutConfiguration = payoutConfigurationToRuntime(profile.PayoutConfiguration)
+		}
+		if profile.Delegators != nil {
+			var err error
+			runtimeProfile.Delegators, err = delegatorsConfigurationToRuntime(profile.Delegators)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if profile.IncomeRecipients != nil {
+			runtimeProfile.IncomeRecipients = incomeRecipientsToRuntime(profile.IncomeRecipients)
+		}
+		result = append(result, runtimeProfile)
 	}
+	return result, nil
+}
 
-	maximumPayoutDelayBlocks := constants.DEFAULT_CYCLE_MONITOR_MAXIMUM_DELAY
-	if configuration.PayoutConfiguration.MaximumDelayBlocks != nil && *configuration.PayoutConfiguration.MaximumDelayBlocks > 0 {
-		maximumPayoutDelayBlocks = *configuration.PayoutConfiguration.MaximumDelayBlocks
+func ConfigurationToRuntimeConfiguration(configuration *LatestConfigurationType) (*RuntimeConfiguration, error) {
+	delegators, err := delegatorsConfigurationToRuntime(&configuration.Delegators)
+	if err != nil {
+		return nil, err
 	}
+	payouts := payoutConfigurationToRuntime(&configuration.PayoutConfiguration)
+	incomeRecipients := incomeRecipientsToRuntime(&configuration.IncomeRecipients)
 
-	simulationBatchSize := constants.DEFAULT_SIMULATION_TX_BATCH_SIZE
-	if configuration.PayoutConfiguration.SimulationBatchSize != nil && *configuration.PayoutConfiguration.SimulationBatchSize > 0 {
-		simulationBatchSize = *configuration.PayoutConfiguration.SimulationBatchSize
+	bakers, err := bakerProfilesToRuntime(configuration.Bakers, payouts, delegators, incomeRecipients)
+	if err != nil {
+		return nil, err
+	}
+
+	reporterKind := configuration.Reporting.Kind
+	if reporterKind == "" {
+		reporterKind = enums.REPORTER_KIND_FILESYSTEM
+	}
+
+	collectorKind := configuration.Network.Collector
+	if collectorKind == "" {
+		collectorKind = enums.COLLECTOR_KIND_DEFAULT
+	}
+
+	var crossCheck *RuntimeCollectorCrossCheck
+	if configuration.Network.CrossCheck != nil {
+		crossCheck = &RuntimeCollectorCrossCheck{
+			Collector: configuration.Network.CrossCheck.Collector,
+			TzktUrl:   configuration.Network.CrossCheck.TzktUrl,
+			Tolerance: constants.DEFAULT_CROSS_CHECK_TOLERANCE,
+			Action:    configuration.Network.CrossCheck.Action,
+		}
+		if crossCheck.Collector == "" {
+			crossCheck.Collector = enums.COLLECTOR_KIND_DEFAULT
+		}
+		if crossCheck.TzktUrl == "" {
+			crossCheck.TzktUrl = configuration.Network.TzktUrl
+		}
+		if configuration.Network.CrossCheck.Tolerance != nil {
+			crossCheck.Tolerance = *configuration.Network.CrossCheck.Tolerance
+		}
+		if crossCheck.Action == "" {
+			crossCheck.Action = enums.CROSS_CHECK_ACTION_ABORT
+		}
 	}
 
 	rpcPool := make([]string, 0, len(configuration.Network.RpcPool)+1)
@@ -160,48 +424,24 @@ func ConfigurationToRuntimeConfiguration(configuration *LatestConfigurationType)
 	}
 
 	return &RuntimeConfiguration{
-		BakerPKH: configuration.BakerPKH,
-		PayoutConfiguration: RuntimePayoutConfiguration{
-			WalletMode:                 walletMode,
-			PayoutMode:                 payoutMode,
-			Fee:                        configuration.PayoutConfiguration.Fee,
-			IsPayingTxFee:              configuration.PayoutConfiguration.IsPayingTxFee,
-			IsPayingAllocationTxFee:    configuration.PayoutConfiguration.IsPayingAllocationTxFee,
-			MinimumAmount:              FloatAmountToMutez(configuration.PayoutConfiguration.MinimumAmount),
-			IgnoreEmptyAccounts:        configuration.PayoutConfiguration.IgnoreEmptyAccounts,
-			TxGasLimitBuffer:           gasLimitBuffer,
-			KtTxGasLimitBuffer:         ktGasLimitBuffer,
-			TxDeserializationGasBuffer: deserializaGasBuffer,
-			TxFeeBuffer:                feeBuffer,
-			KtTxFeeBuffer:              ktFeeBuffer,
-			MinimumDelayBlocks:         minimumPayoutDelayBlocks,
-			MaximumDelayBlocks:         maximumPayoutDelayBlocks,
-			SimulationBatchSize:        simulationBatchSize,
-		},
-		Delegators: RuntimeDelegatorsConfiguration{
-			Requirements: RuntimeDelegatorRequirements{
-				MinimumBalance:                        FloatAmountToMutez(configuration.Delegators.Requirements.MinimumBalance),
-				BellowMinimumBalanceRewardDestination: delegatorBellowMinimumBalanceRewardDestination,
-			},
-			Overrides: delegatorOverrides,
-			Ignore:    configuration.Delegators.Ignore,
-			Prefilter: configuration.Delegators.Prefilter,
-		},
-		IncomeRecipients: RuntimeIncomeRecipients{
-			Bonds:       configuration.IncomeRecipients.Bonds,
-			Fees:        configuration.IncomeRecipients.Fees,
-			Donations:   preprocessDonationMap(configuration.IncomeRecipients.Donations),
-			DonateFees:  donateFees,
-			DonateBonds: donateBonds,
-		},
+		BakerPKH:            configuration.BakerPKH,
+		PayoutConfiguration: payouts,
+		Delegators:          delegators,
+		IncomeRecipients:    incomeRecipients,
 		Network: RuntimeNetworkConfiguration{
 			RpcPool:                rpcPool,
 			TzktUrl:                configuration.Network.TzktUrl,
+			Collector:              collectorKind,
+			CrossCheck:             crossCheck,
 			Explorer:               configuration.Network.Explorer,
 			DoNotPaySmartContracts: configuration.Network.DoNotPaySmartContracts,
 			IgnoreProtocolChanges:  configuration.Network.IgnoreProtocolChanges,
 		},
 		Overdelegation: configuration.Overdelegation,
+		Reporting: RuntimeReportingConfiguration{
+			Kind:     reporterKind,
+			Database: configuration.Reporting.Database,
+		},
 		NotificationConfigurations: lo.Map(configuration.NotificationConfigurations, func(item json.RawMessage, index int) RuntimeNotificatorConfiguration {
 			var isValid bool
 			var notificatorConfigurationBase tezpay_configuration.NotificatorConfigurationBase
@@ -217,6 +457,7 @@ func ConfigurationToRuntimeConfiguration(configuration *LatestConfigurationType)
 			}
 		}),
 		Extensions:       configuration.Extensions,
+		Bakers:           bakers,
 		SourceBytes:      []byte{},
 		DisableAnalytics: configuration.DisableAnalytics,
 	}, nil
@@ -251,10 +492,26 @@ func Load() (*RuntimeConfiguration, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err = loadRedirects(runtime); err != nil {
+		return nil, err
+	}
 	err = runtime.Validate()
 	return runtime, err
 }
 
+// loadRedirects applies approved registrations of the redirect registry to delegators of each baker
+func loadRedirects(runtime *RuntimeConfiguration) error {
+	registry, err := redirects.Load(state.Global.GetRedirectRegistryFilePath())
+	if err != nil {
+		return err
+	}
+	runtime.Delegators.Redirects = registry.GetApprovedRedirects(runtime.BakerPKH)
+	for i := range runtime.Bakers {
+		runtime.Bakers[i].Delegators.Redirects = registry.GetApprovedRedirects(runtime.Bakers[i].BakerPKH)
+	}
+	return nil
+}
+
 func LoadFromString(configurationBytes []byte) (*RuntimeConfiguration, error) {
 	slog.Debug("loading version info")
 	versionInfo := common.ConfigurationVersionInfo{}
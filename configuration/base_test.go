@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	test_assert "github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
 	tezpay_configuration "github.com/tez-capital/tezpay/configuration/v"
+	"github.com/tez-capital/tezpay/constants/enums"
 	"github.com/trilitech/tzgo/tezos"
 )
 
@@ -81,3 +83,179 @@ func TestConfigurationToRuntimeConfiguration(t *testing.T) {
 	assert.NotNil(err)
 	assert.True(strings.Contains(err.Error(), "fee must be between 0 and 1"))
 }
+
+func TestBakerProfilesToRuntimeConfiguration(t *testing.T) {
+	assert := test_assert.New(t)
+
+	baker1, _ := tezos.ParseAddress("tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM")
+	baker2, _ := tezos.ParseAddress("tz1hZvgjekGo7DmQjWh7XnY5eLQD8wNYPczE")
+	fee := .1
+	source := tezpay_configuration.GetDefaultV0()
+	source.BakerPKH = baker1
+	source.PayoutConfiguration.Fee = .05
+	source.Bakers = []tezpay_configuration.BakerProfileV0{
+		{BakerPKH: baker1},
+		{
+			Name:     "second",
+			BakerPKH: baker2,
+			PayoutConfiguration: &tezpay_configuration.PayoutConfigurationV0{
+				Fee: .1,
+			},
+			Delegators: &tezpay_configuration.DelegatorsConfigurationV0{
+				FeeOverrides: map[string][]tezos.Address{
+					"0.1": {tezos.BurnAddress},
+				},
+			},
+		},
+	}
+
+	runtime, err := ConfigurationToRuntimeConfiguration(&source)
+	assert.Nil(err)
+	assert.Nil(runtime.Validate())
+	assert.True(runtime.IsMultiBaker())
+	assert.Len(runtime.Bakers, 2)
+
+	first := runtime.ForBaker(&runtime.Bakers[0])
+	assert.Equal(baker1.String(), runtime.Bakers[0].Name)
+	assert.Equal(baker1.String(), first.Reporting.Subdirectory)
+	assert.Equal(.05, first.PayoutConfiguration.Fee)
+	assert.False(first.IsMultiBaker())
+
+	second := runtime.ForBaker(&runtime.Bakers[1])
+	assert.Equal(baker2, second.BakerPKH)
+	assert.Equal("second", second.Reporting.Subdirectory)
+	assert.Equal(fee, second.PayoutConfiguration.Fee)
+	assert.Equal(fee, *second.Delegators.Overrides[tezos.BurnAddress.String()].Fee)
+	assert.Equal(runtime.Network.RpcPool, second.Network.RpcPool)
+
+	source.Bakers[1].BakerPKH = baker1
+	runtime, _ = ConfigurationToRuntimeConfiguration(&source)
+	err = runtime.Validate()
+	assert.NotNil(err)
+	assert.True(strings.Contains(err.Error(), "is used by multiple profiles"))
+
+	source.Bakers[1].BakerPKH = baker2
+	source.Bakers[1].ReportsDirectory = "../second"
+	runtime, _ = ConfigurationToRuntimeConfiguration(&source)
+	err = runtime.Validate()
+	assert.NotNil(err)
+	assert.True(strings.Contains(err.Error(), "configuration.bakers.second.reports_directory"))
+}
+
+func TestFeeSchedulesToRuntimeConfiguration(t *testing.T) {
+	assert := test_assert.New(t)
+
+	fee := .1
+	source := tezpay_configuration.GetDefaultV0()
+	source.PayoutConfiguration.Fee = .05
+	source.PayoutConfiguration.FeeSchedule = []tezpay_configuration.FeeScheduleEntryV0{
+		{FromCycle: 900, Fee: .08},
+		{FromCycle: 801, Fee: .07},
+	}
+	source.Delegators.Overrides = map[string]tezpay_configuration.DelegatorOverrideV0{
+		tezos.InvalidAddress.String(): {
+			Fee: &fee,
+		},
+	}
+	source.Delegators.FeeOverrides = map[string][]tezos.Address{
+		"0.2":     {tezos.BurnAddress},
+		"0.3@850": {tezos.BurnAddress, tezos.InvalidAddress},
+	}
+
+	runtime, err := ConfigurationToRuntimeConfiguration(&source)
+	assert.Nil(err)
+	assert.Nil(runtime.Validate())
+	assert.Equal(int64(801), runtime.PayoutConfiguration.FeeSchedule[0].FromCycle)
+	assert.Equal(.05, runtime.PayoutConfiguration.GetFee(800))
+	assert.Equal(.07, runtime.PayoutConfiguration.GetFee(899))
+	assert.Equal(.08, runtime.PayoutConfiguration.GetFee(900))
+
+	burn := runtime.Delegators.Overrides[tezos.BurnAddress.String()]
+	assert.Equal(.2, burn.GetFee(849, .05))
+	assert.Equal(.3, burn.GetFee(850, .05))
+
+	invalid := runtime.Delegators.Overrides[tezos.InvalidAddress.String()]
+	assert.Equal(.1, invalid.GetFee(849, .05))
+	assert.Equal(.3, invalid.GetFee(850, .05))
+
+	source.Delegators.FeeOverrides = map[string][]tezos.Address{
+		"0.3@cycle": {tezos.BurnAddress},
+	}
+	_, err = ConfigurationToRuntimeConfiguration(&source)
+	assert.NotNil(err)
+
+	source.Delegators.FeeOverrides = nil
+	source.PayoutConfiguration.FeeSchedule = []tezpay_configuration.FeeScheduleEntryV0{
+		{FromCycle: 801, Fee: 1.5},
+	}
+	runtime, _ = ConfigurationToRuntimeConfiguration(&source)
+	err = runtime.Validate()
+	assert.NotNil(err)
+	assert.True(strings.Contains(err.Error(), "configuration.payouts.fee_schedule[0].fee"))
+}
+
+func TestFeeTiersValidation(t *testing.T) {
+	assert := test_assert.New(t)
+
+	thousand := 1000.
+	tenThousand := 10000.
+	source := tezpay_configuration.GetDefaultV0()
+	source.Delegators.FeeTiers = []tezpay_configuration.FeeTierV0{
+		{MinimumBalance: thousand, MaximumBalance: &tenThousand, Fee: .06},
+		{MinimumBalance: tenThousand, Fee: .04},
+		{MaximumBalance: &thousand, Fee: .08},
+	}
+
+	runtime, err := ConfigurationToRuntimeConfiguration(&source)
+	assert.Nil(err)
+	assert.Nil(runtime.Validate())
+	assert.Equal(.08, runtime.Delegators.FeeTiers[0].Fee)
+	assert.Equal(enums.FEE_TIER_BALANCE_TOTAL, runtime.Delegators.FeeTiersBalance)
+
+	gapStart := 1500.
+	source.Delegators.FeeTiers[0].MinimumBalance = gapStart
+	runtime, _ = ConfigurationToRuntimeConfiguration(&source)
+	err = runtime.Validate()
+	assert.NotNil(err)
+	assert.True(strings.Contains(err.Error(), "gap between tier ending at 1000 tez and tier starting at 1500 tez"))
+
+	source.Delegators.FeeTiers[0].MinimumBalance = 500
+	runtime, _ = ConfigurationToRuntimeConfiguration(&source)
+	err = runtime.Validate()
+	assert.NotNil(err)
+	assert.True(strings.Contains(err.Error(), "overlaps with tier ending at 1000 tez"))
+
+	source.Delegators.FeeTiers[0].MinimumBalance = thousand
+	source.Delegators.FeeTiers[0].MaximumBalance = nil
+	runtime, _ = ConfigurationToRuntimeConfiguration(&source)
+	err = runtime.Validate()
+	assert.NotNil(err)
+	assert.True(strings.Contains(err.Error(), "overlaps with unbounded tier"))
+}
+
+func TestExtensionsValidation(t *testing.T) {
+	assert := test_assert.New(t)
+
+	source := tezpay_configuration.GetDefaultV0()
+	source.Extensions = []tezpay_configuration.ExtensionConfigurationV0{
+		{Name: "stdio", Kind: enums.EXTENSION_STDIO_RPC, Command: "extension"},
+		{Name: "http", Kind: enums.EXTENSION_HTTP_RPC, Url: "https://extension.example.com/rpc", Headers: map[string]string{"Authorization": "Bearer token"}},
+		{Name: "ws", Kind: enums.EXTENSION_WS_RPC, Url: "wss://extension.example.com/rpc", Reconnect: &common.ExtensionReconnectOptions{Attempts: 3, Delay: 1}},
+	}
+	runtime, err := ConfigurationToRuntimeConfiguration(&source)
+	assert.Nil(err)
+	assert.Nil(runtime.Validate())
+
+	source.Extensions[2].Url = "https://extension.example.com/rpc"
+	runtime, _ = ConfigurationToRuntimeConfiguration(&source)
+	err = runtime.Validate()
+	assert.NotNil(err)
+	assert.True(strings.Contains(err.Error(), "configuration.extensions.ws.url - 'https://extension.example.com/rpc' has to be valid ws/wss url"))
+
+	source.Extensions[2].Url = "wss://extension.example.com/rpc"
+	source.Extensions[1].Tls = &common.ExtensionTlsOptions{CertFile: "client.pem"}
+	runtime, _ = ConfigurationToRuntimeConfiguration(&source)
+	err = runtime.Validate()
+	assert.NotNil(err)
+	assert.True(strings.Contains(err.Error(), "tls.cert_file and configuration.extensions.http.tls.key_file have to be set together"))
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"math"
 
+	"github.com/tez-capital/tezpay/common"
 	tezpay_configuration "github.com/tez-capital/tezpay/configuration/v"
 	"github.com/tez-capital/tezpay/constants"
 	"github.com/tez-capital/tezpay/constants/enums"
@@ -16,20 +17,107 @@ type RuntimeDelegatorRequirements struct {
 	BellowMinimumBalanceRewardDestination enums.ERewardDestination
 }
 
+type RuntimeFeeScheduleEntry struct {
+	FromCycle int64   `json:"from_cycle"`
+	Fee       float64 `json:"fee"`
+}
+
+// RuntimeFeeSchedule is sorted by FromCycle in ascending order
+type RuntimeFeeSchedule []RuntimeFeeScheduleEntry
+
+// GetFee returns fee of the last entry starting at or before the cycle, fallback if there is none
+func (schedule RuntimeFeeSchedule) GetFee(cycle int64, fallback float64) float64 {
+	fee := fallback
+	for _, entry := range schedule {
+		if entry.FromCycle > cycle {
+			break
+		}
+		fee = entry.Fee
+	}
+	return fee
+}
+
 type RuntimeDelegatorOverride struct {
-	Recipient                    tezos.Address `json:"recipient,omitempty"`
-	Fee                          *float64      `json:"fee,omitempty"`
-	MinimumBalance               tezos.Z       `json:"minimum_balance,omitempty"`
-	IsBakerPayingTxFee           *bool         `json:"baker_pays_transaction_fee,omitempty"`
-	IsBakerPayingAllocationTxFee *bool         `json:"baker_pays_allocation_fee,omitempty"`
-	MaximumBalance               *tezos.Z      `json:"maximum_balance,omitempty"`
+	Recipient                    tezos.Address      `json:"recipient,omitempty"`
+	Fee                          *float64           `json:"fee,omitempty"`
+	FeeSchedule                  RuntimeFeeSchedule `json:"fee_schedule,omitempty"`
+	StakingFee                   *float64           `json:"staking_fee,omitempty"`
+	MinimumBalance               tezos.Z            `json:"minimum_balance,omitempty"`
+	IsBakerPayingTxFee           *bool              `json:"baker_pays_transaction_fee,omitempty"`
+	IsBakerPayingAllocationTxFee *bool              `json:"baker_pays_allocation_fee,omitempty"`
+	MaximumBalance               *tezos.Z           `json:"maximum_balance,omitempty"`
+	PayoutInterval               int64              `json:"payout_interval,omitempty"`
+	MinimumPayoutAmount          *tezos.Z           `json:"minimum_payout_amount,omitempty"`
+}
+
+// DefersPayouts reports whether unpaid rewards of the delegator are carried forward instead of being paid every cycle
+func (override *RuntimeDelegatorOverride) DefersPayouts() bool {
+	return override.PayoutInterval > 1 || override.MinimumPayoutAmount != nil
+}
+
+type RuntimeFeeTier struct {
+	MinimumBalance tezos.Z  `json:"minimum_balance"`
+	MaximumBalance *tezos.Z `json:"maximum_balance,omitempty"`
+	Fee            float64  `json:"fee"`
+}
+
+func (tier *RuntimeFeeTier) Contains(balance tezos.Z) bool {
+	return !balance.IsLess(tier.MinimumBalance) && (tier.MaximumBalance == nil || balance.IsLess(*tier.MaximumBalance))
+}
+
+// RuntimeFeeTiers is sorted by MinimumBalance in ascending order
+type RuntimeFeeTiers []RuntimeFeeTier
+
+// GetFee returns fee of the tier containing the balance
+func (tiers RuntimeFeeTiers) GetFee(balance tezos.Z) (float64, bool) {
+	for _, tier := range tiers {
+		if tier.Contains(balance) {
+			return tier.Fee, true
+		}
+	}
+	return 0, false
+}
+
+type RuntimeLoyaltyRule struct {
+	AfterCycles int64   `json:"after_cycles"`
+	Discount    float64 `json:"discount"`
+}
+
+// RuntimeLoyaltyRules is sorted by AfterCycles in ascending order
+type RuntimeLoyaltyRules []RuntimeLoyaltyRule
+
+// GetRule returns rule with the highest AfterCycles satisfied by the number of loyal cycles
+func (rules RuntimeLoyaltyRules) GetRule(loyalCycles int64) (RuntimeLoyaltyRule, bool) {
+	var result RuntimeLoyaltyRule
+	found := false
+	for _, rule := range rules {
+		if rule.AfterCycles > loyalCycles {
+			break
+		}
+		result = rule
+		found = true
+	}
+	return result, found
+}
+
+// GetMaximumCycles returns the number of loyal cycles needed to satisfy all rules
+func (rules RuntimeLoyaltyRules) GetMaximumCycles() int64 {
+	if len(rules) == 0 {
+		return 0
+	}
+	return rules[len(rules)-1].AfterCycles
 }
 
 type RuntimeDelegatorsConfiguration struct {
-	Requirements RuntimeDelegatorRequirements        `json:"requirements,omitempty"`
-	Overrides    map[string]RuntimeDelegatorOverride `json:"overrides,omitempty"`
-	Ignore       []tezos.Address                     `json:"ignore,omitempty"`
-	Prefilter    []tezos.Address                     `json:"prefilter,omitempty"`
+	Requirements    RuntimeDelegatorRequirements        `json:"requirements,omitempty"`
+	Overrides       map[string]RuntimeDelegatorOverride `json:"overrides,omitempty"`
+	Ignore          []tezos.Address                     `json:"ignore,omitempty"`
+	Prefilter       []tezos.Address                     `json:"prefilter,omitempty"`
+	FeeTiers        RuntimeFeeTiers                     `json:"fee_tiers,omitempty"`
+	FeeTiersBalance enums.EFeeTierBalance               `json:"fee_tiers_balance,omitempty"`
+	Loyalty         RuntimeLoyaltyRules                 `json:"loyalty,omitempty"`
+	// approved redirects of the redirect registry keyed by delegator address
+	Redirects map[string]tezos.Address `json:"redirects,omitempty"`
 }
 
 type RuntimeNotificatorConfiguration struct {
@@ -40,21 +128,31 @@ type RuntimeNotificatorConfiguration struct {
 }
 
 type RuntimePayoutConfiguration struct {
-	WalletMode                 enums.EWalletMode `json:"wallet_mode,omitempty"`
-	PayoutMode                 enums.EPayoutMode `json:"payout_mode,omitempty"`
-	Fee                        float64           `json:"fee,omitempty"`
-	IsPayingTxFee              bool              `json:"baker_pays_transaction_fee,omitempty"`
-	IsPayingAllocationTxFee    bool              `json:"baker_pays_allocation_fee,omitempty"`
-	MinimumAmount              tezos.Z           `json:"minimum_payout_amount,omitempty"`
-	IgnoreEmptyAccounts        bool              `json:"ignore_empty_accounts,omitempty"`
-	TxGasLimitBuffer           int64             `json:"transaction_gas_limit_buffer,omitempty"`
-	KtTxGasLimitBuffer         int64             `json:"kt_transaction_gas_limit_buffer,omitempty"`
-	TxDeserializationGasBuffer int64             `json:"transaction_deserialization_gas_buffer,omitempty"`
-	TxFeeBuffer                int64             `json:"transaction_fee_buffer,omitempty"`
-	KtTxFeeBuffer              int64             `json:"kt_transaction_fee_buffer,omitempty"`
-	MinimumDelayBlocks         int64             `json:"minimum_delay_blocks,omitempty"`
-	MaximumDelayBlocks         int64             `json:"maximum_delay_blocks,omitempty"`
-	SimulationBatchSize        int               `json:"simulation_batch_size,omitempty"`
+	WalletMode                 enums.EWalletMode             `json:"wallet_mode,omitempty"`
+	PayoutMode                 enums.EPayoutMode             `json:"payout_mode,omitempty"`
+	Fee                        float64                       `json:"fee,omitempty"`
+	FeeSchedule                RuntimeFeeSchedule            `json:"fee_schedule,omitempty"`
+	StakingFee                 *float64                      `json:"staking_fee,omitempty"`
+	IsPayingTxFee              bool                          `json:"baker_pays_transaction_fee,omitempty"`
+	IsPayingAllocationTxFee    bool                          `json:"baker_pays_allocation_fee,omitempty"`
+	MinimumAmount              tezos.Z                       `json:"minimum_payout_amount,omitempty"`
+	IgnoreEmptyAccounts        bool                          `json:"ignore_empty_accounts,omitempty"`
+	TxGasLimitBuffer           int64                         `json:"transaction_gas_limit_buffer,omitempty"`
+	KtTxGasLimitBuffer         int64                         `json:"kt_transaction_gas_limit_buffer,omitempty"`
+	TxDeserializationGasBuffer int64                         `json:"transaction_deserialization_gas_buffer,omitempty"`
+	TxFeeBuffer                int64                         `json:"transaction_fee_buffer,omitempty"`
+	KtTxFeeBuffer              int64                         `json:"kt_transaction_fee_buffer,omitempty"`
+	MinimumDelayBlocks         int64                         `json:"minimum_delay_blocks,omitempty"`
+	MaximumDelayBlocks         int64                         `json:"maximum_delay_blocks,omitempty"`
+	SimulationBatchSize        int                           `json:"simulation_batch_size,omitempty"`
+	MaxBatchesInFlight         int                           `json:"max_batches_in_flight,omitempty"`
+	FeeBumping                 *common.FeeBumpingOptions     `json:"fee_bumping,omitempty"`
+	Multisig                   *RuntimeMultisigConfiguration `json:"multisig,omitempty"`
+}
+
+type RuntimeMultisigConfiguration struct {
+	Contract tezos.Address `json:"contract"`
+	Signers  []string      `json:"signers"`
 }
 
 type RuntimeIncomeRecipients struct {
@@ -65,12 +163,37 @@ type RuntimeIncomeRecipients struct {
 	Donations   map[string]float64 `json:"donations,omitempty"`
 }
 
+type RuntimeCollectorCrossCheck struct {
+	Collector enums.ECollectorKind    `json:"collector,omitempty"`
+	TzktUrl   string                  `json:"tzkt_url,omitempty"`
+	Tolerance float64                 `json:"tolerance,omitempty"`
+	Action    enums.ECrossCheckAction `json:"action,omitempty"`
+}
+
 type RuntimeNetworkConfiguration struct {
-	RpcPool                []string `json:"rpc_pool,omitempty" comment:"Url to rpc endpoint"`
-	TzktUrl                string   `json:"tzkt_url,omitempty" comment:"Url to tzkt endpoint"`
-	Explorer               string   `json:"explorer,omitempty" comment:"Url to block explorer"`
-	DoNotPaySmartContracts bool     `json:"ignore_kt,omitempty" comment:"if true, smart contracts will not be paid out (used for testing)"`
-	IgnoreProtocolChanges  bool     `json:"ignore_protocol_changes,omitempty" comment:"if true, protocol changes will be ignored, otherwise the payout will be stopped if the protocol changes"`
+	RpcPool                []string                    `json:"rpc_pool,omitempty" comment:"Url to rpc endpoint"`
+	TzktUrl                string                      `json:"tzkt_url,omitempty" comment:"Url to tzkt endpoint"`
+	Collector              enums.ECollectorKind        `json:"collector,omitempty" comment:"source of cycle data"`
+	CrossCheck             *RuntimeCollectorCrossCheck `json:"cross_check,omitempty" comment:"second source of cycle data"`
+	Explorer               string                      `json:"explorer,omitempty" comment:"Url to block explorer"`
+	DoNotPaySmartContracts bool                        `json:"ignore_kt,omitempty" comment:"if true, smart contracts will not be paid out (used for testing)"`
+	IgnoreProtocolChanges  bool                        `json:"ignore_protocol_changes,omitempty" comment:"if true, protocol changes will be ignored, otherwise the payout will be stopped if the protocol changes"`
+}
+
+type RuntimeReportingConfiguration struct {
+	Kind         enums.EReporterKind `json:"kind,omitempty"`
+	Database     string              `json:"database,omitempty"`
+	Subdirectory string              `json:"subdirectory,omitempty"`
+}
+
+type RuntimeBakerProfile struct {
+	Name                string                         `json:"name,omitempty"`
+	BakerPKH            tezos.Address                  `json:"baker,omitempty"`
+	PayoutWallet        string                         `json:"payout_wallet,omitempty"`
+	PayoutConfiguration RuntimePayoutConfiguration     `json:"payouts,omitempty"`
+	Delegators          RuntimeDelegatorsConfiguration `json:"delegators,omitempty"`
+	IncomeRecipients    RuntimeIncomeRecipients        `json:"income_recipients,omitempty"`
+	ReportsDirectory    string                         `json:"reports_directory,omitempty"`
 }
 
 type RuntimeConfiguration struct {
@@ -80,8 +203,10 @@ type RuntimeConfiguration struct {
 	IncomeRecipients           RuntimeIncomeRecipients
 	Network                    RuntimeNetworkConfiguration
 	Overdelegation             tezpay_configuration.OverdelegationConfigurationV0
+	Reporting                  RuntimeReportingConfiguration
 	NotificationConfigurations []RuntimeNotificatorConfiguration
 	Extensions                 []tezpay_configuration.ExtensionConfigurationV0
+	Bakers                     []RuntimeBakerProfile
 	SourceBytes                []byte `json:"-"`
 	DisableAnalytics           bool   `json:"disable_analytics,omitempty"`
 	DisableKillSwitch          bool   `json:"disable_kill_switch,omitempty"`
@@ -106,19 +231,22 @@ func GetDefaultRuntimeConfiguration() RuntimeConfiguration {
 			MinimumDelayBlocks:         constants.DEFAULT_CYCLE_MONITOR_MINIMUM_DELAY,
 			MaximumDelayBlocks:         constants.DEFAULT_CYCLE_MONITOR_MAXIMUM_DELAY,
 			SimulationBatchSize:        constants.DEFAULT_SIMULATION_TX_BATCH_SIZE,
+			MaxBatchesInFlight:         constants.DEFAULT_MAX_BATCHES_IN_FLIGHT,
 		},
 		Delegators: RuntimeDelegatorsConfiguration{
 			Requirements: RuntimeDelegatorRequirements{
 				MinimumBalance:                        FloatAmountToMutez(constants.DEFAULT_DELEGATOR_MINIMUM_BALANCE),
 				BellowMinimumBalanceRewardDestination: enums.REWARD_DESTINATION_NONE,
 			},
-			Overrides: make(map[string]RuntimeDelegatorOverride),
-			Ignore:    make([]tezos.Address, 0),
-			Prefilter: make([]tezos.Address, 0),
+			Overrides:       make(map[string]RuntimeDelegatorOverride),
+			Ignore:          make([]tezos.Address, 0),
+			Prefilter:       make([]tezos.Address, 0),
+			FeeTiersBalance: enums.FEE_TIER_BALANCE_TOTAL,
 		},
 		Network: RuntimeNetworkConfiguration{
 			RpcPool:                constants.DEFAULT_RPC_POOL,
 			TzktUrl:                constants.DEFAULT_TZKT_URL,
+			Collector:              enums.COLLECTOR_KIND_DEFAULT,
 			Explorer:               constants.DEFAULT_EXPLORER_URL,
 			DoNotPaySmartContracts: false,
 			IgnoreProtocolChanges:  false,
@@ -126,6 +254,9 @@ func GetDefaultRuntimeConfiguration() RuntimeConfiguration {
 		Overdelegation: tezpay_configuration.OverdelegationConfigurationV0{
 			IsProtectionEnabled: true,
 		},
+		Reporting: RuntimeReportingConfiguration{
+			Kind: enums.REPORTER_KIND_FILESYSTEM,
+		},
 		NotificationConfigurations: make([]RuntimeNotificatorConfiguration, 0),
 		SourceBytes:                []byte{},
 		DisableAnalytics:           false,
@@ -143,3 +274,57 @@ func (configuration *RuntimeConfiguration) IsDonatingToTezCapital() bool {
 	portion := int64(math.Floor(float64(total) * 10000))
 	return portion < 10000 && (configuration.IncomeRecipients.DonateBonds > 0 || configuration.IncomeRecipients.DonateFees > 0)
 }
+
+func (configuration *RuntimeConfiguration) IsMultiBaker() bool {
+	return len(configuration.Bakers) > 0
+}
+
+// ForBaker returns copy of the configuration with baker specific parts replaced by the profile
+func (configuration *RuntimeConfiguration) ForBaker(profile *RuntimeBakerProfile) *RuntimeConfiguration {
+	result := *configuration
+	result.BakerPKH = profile.BakerPKH
+	result.PayoutConfiguration = profile.PayoutConfiguration
+	result.Delegators = profile.Delegators
+	result.IncomeRecipients = profile.IncomeRecipients
+	result.Reporting.Subdirectory = profile.ReportsDirectory
+	result.Bakers = nil
+	return &result
+}
+
+// GetPayoutAddress returns address holding the payout funds, the multisig contract if configured
+func (payouts *RuntimePayoutConfiguration) GetPayoutAddress(payoutWallet tezos.Address) tezos.Address {
+	if payouts.Multisig != nil {
+		return payouts.Multisig.Contract
+	}
+	return payoutWallet
+}
+
+// GetFee returns fee rate of the payout configuration for the cycle
+func (payouts *RuntimePayoutConfiguration) GetFee(cycle int64) float64 {
+	return payouts.FeeSchedule.GetFee(cycle, payouts.Fee)
+}
+
+// GetStakingFee returns staking fee rate for the delegator, false if staking rewards are not paid out
+func (configuration *RuntimeConfiguration) GetStakingFee(delegator tezos.Address) (float64, bool) {
+	if override, ok := configuration.Delegators.Overrides[delegator.String()]; ok && override.StakingFee != nil {
+		return *override.StakingFee, true
+	}
+	if configuration.PayoutConfiguration.StakingFee != nil {
+		return *configuration.PayoutConfiguration.StakingFee, true
+	}
+	return 0, false
+}
+
+// GetFee returns fee rate of the override for the cycle, fallback is used when override does not set fee for the cycle
+func (override *RuntimeDelegatorOverride) GetFee(cycle int64, fallback float64) float64 {
+	if override.Fee != nil {
+		fallback = *override.Fee
+	}
+	return override.FeeSchedule.GetFee(cycle, fallback)
+}
+
+// HasFeeOverride reports whether fee of the delegator is set explicitly by override
+func (delegators *RuntimeDelegatorsConfiguration) HasFeeOverride(delegator tezos.Address) bool {
+	override, ok := delegators.Overrides[delegator.String()]
+	return ok && (override.Fee != nil || len(override.FeeSchedule) > 0)
+}
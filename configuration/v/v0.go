@@ -24,31 +24,63 @@ type DelegatorRequirementsV0 struct {
 	BellowMinimumBalanceRewardDestination *enums.ERewardDestination `json:"below_minimum_reward_destination,omitempty" comment:"Reward destination for delegators with balance below the minimum balance (possible values: 'none', 'everyone')"`
 }
 
+type FeeScheduleEntryV0 struct {
+	FromCycle int64   `json:"from_cycle" comment:"first cycle the fee applies to"`
+	Fee       float64 `json:"fee" comment:"fee to charge from the cycle onwards (until the next entry)"`
+}
+
 type DelegatorOverrideV0 struct {
-	Recipient                    tezos.Address `json:"recipient,omitempty" comment:"Redirects payout to the recipient 'address'"`
-	Fee                          *float64      `json:"fee,omitempty" comment:"Overrides the fee for the delegator"`
-	MinimumBalance               float64       `json:"minimum_balance,omitempty" comment:"Overrides the minimum balance requirement for the delegator"`
-	IsBakerPayingTxFee           *bool         `json:"baker_pays_transaction_fee,omitempty" comment:"Overrides the baker paying the transaction fee"`
-	IsBakerPayingAllocationTxFee *bool         `json:"baker_pays_allocation_fee,omitempty" comment:"Overrides the baker paying the allocation transaction fee"`
-	MaximumBalance               *float64      `json:"maximum_balance,omitempty" comment:"The maximum balance for the delegator (for overdelegation situation you can limit how much of a delegator balance is taken into account)"`
+	Recipient                    tezos.Address        `json:"recipient,omitempty" comment:"Redirects payout to the recipient 'address'"`
+	Fee                          *float64             `json:"fee,omitempty" comment:"Overrides the fee for the delegator"`
+	FeeSchedule                  []FeeScheduleEntryV0 `json:"fee_schedule,omitempty" comment:"Overrides the fee for the delegator from specific cycles, 'fee' is used for cycles before the first entry"`
+	StakingFee                   *float64             `json:"staking_fee,omitempty" comment:"Overrides the staking fee for the delegator"`
+	MinimumBalance               float64              `json:"minimum_balance,omitempty" comment:"Overrides the minimum balance requirement for the delegator"`
+	IsBakerPayingTxFee           *bool                `json:"baker_pays_transaction_fee,omitempty" comment:"Overrides the baker paying the transaction fee"`
+	IsBakerPayingAllocationTxFee *bool                `json:"baker_pays_allocation_fee,omitempty" comment:"Overrides the baker paying the allocation transaction fee"`
+	MaximumBalance               *float64             `json:"maximum_balance,omitempty" comment:"The maximum balance for the delegator (for overdelegation situation you can limit how much of a delegator balance is taken into account)"`
+	PayoutInterval               int64                `json:"payout_interval,omitempty" comment:"Pays out the delegator only once rewards of this many cycles are accumulated, unpaid rewards are carried forward to next payouts"`
+	MinimumPayoutAmount          *float64             `json:"minimum_payout_amount,omitempty" comment:"Overrides the minimum payout amount for the delegator, rewards below it are carried forward to next payouts"`
+}
+
+type FeeTierV0 struct {
+	MinimumBalance float64  `json:"minimum_balance,omitempty" comment:"minimum balance of the tier in tez (inclusive)"`
+	MaximumBalance *float64 `json:"maximum_balance,omitempty" comment:"maximum balance of the tier in tez (exclusive), if not set the tier has no upper limit"`
+	Fee            float64  `json:"fee" comment:"fee to charge delegators within the tier"`
+}
+
+type LoyaltyRuleV0 struct {
+	AfterCycles int64   `json:"after_cycles" comment:"number of consecutive cycles a delegator has to stay delegated for the discount to apply"`
+	Discount    float64 `json:"discount" comment:"discount subtracted from the fee (portion as decimal, e.g. 0.01 for 1%)"`
 }
 
 type DelegatorsConfigurationV0 struct {
-	Requirements DelegatorRequirementsV0        `json:"requirements,omitempty" comment:"Requirements delegators have to meet"`
-	Prefilter    []tezos.Address                `json:"prefilter,omitempty" comment:"List of only delegator addresses to consider, if empty all delegators are considered"`
-	Ignore       []tezos.Address                `json:"ignore,omitempty" comment:"List of delegator addresses to ignore - wont be included in reward set, rewards will be redistributed"`
-	Overrides    map[string]DelegatorOverrideV0 `json:"overrides,omitempty" comment:"Overrides for specific delegators"`
-	FeeOverrides map[string][]tezos.Address     `json:"fee_overrides,omitempty" comment:"Shortcuts for overriding fees for specific delegators"`
+	Requirements    DelegatorRequirementsV0        `json:"requirements,omitempty" comment:"Requirements delegators have to meet"`
+	Prefilter       []tezos.Address                `json:"prefilter,omitempty" comment:"List of only delegator addresses to consider, if empty all delegators are considered"`
+	Ignore          []tezos.Address                `json:"ignore,omitempty" comment:"List of delegator addresses to ignore - wont be included in reward set, rewards will be redistributed"`
+	Overrides       map[string]DelegatorOverrideV0 `json:"overrides,omitempty" comment:"Overrides for specific delegators"`
+	FeeOverrides    map[string][]tezos.Address     `json:"fee_overrides,omitempty" comment:"Shortcuts for overriding fees for specific delegators, use '<fee>@<cycle>' to override the fee from specific cycle"`
+	FeeTiers        []FeeTierV0                    `json:"fee_tiers,omitempty" comment:"Fees based on delegator balance (capped by 'maximum_balance' override), tiers have to be continuous and explicit overrides take precedence. Tier fees are relative to 'fee', 'fee_schedule' shifts them by the same difference"`
+	FeeTiersBalance enums.EFeeTierBalance          `json:"fee_tiers_balance,omitempty" comment:"Balance the fee tiers are evaluated against, can be 'total' (default), 'delegated' or 'staked'"`
+	Loyalty         []LoyaltyRuleV0                `json:"loyalty,omitempty" comment:"Fee discounts for long-standing delegators, the rule with the highest matching 'after_cycles' applies (fees set by overrides are not discounted)"`
 }
 
 type TezosNetworkConfigurationV0 struct {
 	// RpcUrl represents the URL to the RPC node.
-	RpcUrl                 string   `json:"rpc_url,omitempty" comment:"Url to rpc endpoint"`
-	RpcPool                []string `json:"rpc_pool,omitempty" comment:"List of RPC nodes to use. Order is important, the first one is the primary node, unless rpc_url is set."`
-	TzktUrl                string   `json:"tzkt_url,omitempty" comment:"Url to tzkt endpoint"`
-	Explorer               string   `json:"explorer,omitempty" comment:"Url to block explorer"`
-	DoNotPaySmartContracts bool     `json:"ignore_kt,omitempty" comment:"if true, smart contracts will not be paid out (used for testing)"`
-	IgnoreProtocolChanges  bool     `json:"ignore_protocol_changes,omitempty" comment:"if true, protocol changes will be ignored, otherwise the payout will be stopped if the protocol changes"`
+	RpcUrl                 string                 `json:"rpc_url,omitempty" comment:"Url to rpc endpoint"`
+	RpcPool                []string               `json:"rpc_pool,omitempty" comment:"List of RPC nodes to use. Order is important, the first one is the primary node, unless rpc_url is set."`
+	TzktUrl                string                 `json:"tzkt_url,omitempty" comment:"Url to tzkt endpoint"`
+	Collector              enums.ECollectorKind   `json:"collector,omitempty" comment:"source of cycle data, 'default' uses rpc and tzkt, 'rpc' uses rpc nodes only (requires archive node), transaction and delegation history (reconcile, loyalty) is still read from tzkt_url"`
+	CrossCheck             *CollectorCrossCheckV0 `json:"cross_check,omitempty" comment:"Second source of cycle data, cycle data are compared before paying"`
+	Explorer               string                 `json:"explorer,omitempty" comment:"Url to block explorer"`
+	DoNotPaySmartContracts bool                   `json:"ignore_kt,omitempty" comment:"if true, smart contracts will not be paid out (used for testing)"`
+	IgnoreProtocolChanges  bool                   `json:"ignore_protocol_changes,omitempty" comment:"if true, protocol changes will be ignored, otherwise the payout will be stopped if the protocol changes"`
+}
+
+type CollectorCrossCheckV0 struct {
+	Collector enums.ECollectorKind    `json:"collector,omitempty" comment:"collector used to cross check cycle data, 'default' or 'rpc'"`
+	TzktUrl   string                  `json:"tzkt_url,omitempty" comment:"Url to tzkt endpoint used by the cross check collector, defaults to network.tzkt_url"`
+	Tolerance *float64                `json:"tolerance,omitempty" comment:"Maximum relative difference of balances and rewards, e.g. 0.001 for 0.1%"`
+	Action    enums.ECrossCheckAction `json:"action,omitempty" comment:"what to do when cycle data differ, 'abort' (default) or 'notify' admin and continue"`
 }
 
 type OverdelegationConfigurationV0 struct {
@@ -56,25 +88,56 @@ type OverdelegationConfigurationV0 struct {
 }
 
 type PayoutConfigurationV0 struct {
-	WalletMode                 enums.EWalletMode `json:"wallet_mode" comment:"wallet mode to use for signing transactions, can be 'local-private-key' or 'remote-signer'"`
-	PayoutMode                 enums.EPayoutMode `json:"payout_mode" comment:"payout mode to use, can be 'actual' or 'ideal'"`
-	Fee                        float64           `json:"fee,omitempty" comment:"fee to charge delegators for the payout (portion of the reward as decimal, e.g. 0.075 for 7.5%)" validate:"required,min=0,max=1"`
-	IsPayingTxFee              bool              `json:"baker_pays_transaction_fee,omitempty" comment:"if true, baker pays the transaction fee"`
-	IsPayingAllocationTxFee    bool              `json:"baker_pays_allocation_fee,omitempty" comment:"if true, baker pays the allocation transaction fee"`
-	MinimumAmount              float64           `json:"minimum_payout_amount,omitempty" comment:"minimum amount to pay out to delegators, if the amount is less, the payout will be ignored"`
-	IgnoreEmptyAccounts        bool              `json:"ignore_empty_accounts,omitempty" comment:"if true, empty accounts will be ignored"`
-	TxGasLimitBuffer           *int64            `json:"transaction_gas_limit_buffer,omitempty" comment:"buffer for transaction gas limit"`
-	KtTxGasLimitBuffer         *int64            `json:"kt_transaction_gas_limit_buffer,omitempty" comment:"buffer for contract transaction gas limit"`
-	TxDeserializationGasBuffer *int64            `json:"transaction_deserialization_gas_buffer,omitempty" comment:"buffer for transaction deserialization gas"`
-	TxFeeBuffer                *int64            `json:"transaction_fee_buffer,omitempty" comment:"buffer for transaction fee"`
-	KtTxFeeBuffer              *int64            `json:"kt_transaction_fee_buffer,omitempty" comment:"buffer for KT transaction fee"`
-	MinimumDelayBlocks         *int64            `json:"minimum_delay_blocks,omitempty" comment:"minimum delay in blocks before the payout is executed"`
-	MaximumDelayBlocks         *int64            `json:"maximum_delay_blocks,omitempty" comment:"maximum delay in blocks before the payout is executed"`
-	SimulationBatchSize        *int              `json:"simulation_batch_size,omitempty" comment:"size of the batch for simulation (number of transactions, higher usually means faster simulation but in case of failure, more transactions will be lost and need to be simulated again)"`
+	WalletMode                 enums.EWalletMode          `json:"wallet_mode" comment:"wallet mode to use for signing transactions, can be 'local-private-key', 'remote-signer' or 'stdio-signer'"`
+	PayoutMode                 enums.EPayoutMode          `json:"payout_mode" comment:"payout mode to use, can be 'actual' or 'ideal'"`
+	Fee                        float64                    `json:"fee,omitempty" comment:"fee to charge delegators for the payout (portion of the reward as decimal, e.g. 0.075 for 7.5%)" validate:"required,min=0,max=1"`
+	FeeSchedule                []FeeScheduleEntryV0       `json:"fee_schedule,omitempty" comment:"fees to charge from specific cycles, 'fee' is used for cycles before the first entry"`
+	StakingFee                 *float64                   `json:"staking_fee,omitempty" comment:"fee to charge stakers from their staking rewards, the part of the protocol edge above it is paid back to stakers (if not set, the edge is kept by the baker and no staking rewards are paid)"`
+	IsPayingTxFee              bool                       `json:"baker_pays_transaction_fee,omitempty" comment:"if true, baker pays the transaction fee"`
+	IsPayingAllocationTxFee    bool                       `json:"baker_pays_allocation_fee,omitempty" comment:"if true, baker pays the allocation transaction fee"`
+	MinimumAmount              float64                    `json:"minimum_payout_amount,omitempty" comment:"minimum amount to pay out to delegators, if the amount is less, the payout will be ignored"`
+	IgnoreEmptyAccounts        bool                       `json:"ignore_empty_accounts,omitempty" comment:"if true, empty accounts will be ignored"`
+	TxGasLimitBuffer           *int64                     `json:"transaction_gas_limit_buffer,omitempty" comment:"buffer for transaction gas limit"`
+	KtTxGasLimitBuffer         *int64                     `json:"kt_transaction_gas_limit_buffer,omitempty" comment:"buffer for contract transaction gas limit"`
+	TxDeserializationGasBuffer *int64                     `json:"transaction_deserialization_gas_buffer,omitempty" comment:"buffer for transaction deserialization gas"`
+	TxFeeBuffer                *int64                     `json:"transaction_fee_buffer,omitempty" comment:"buffer for transaction fee"`
+	KtTxFeeBuffer              *int64                     `json:"kt_transaction_fee_buffer,omitempty" comment:"buffer for KT transaction fee"`
+	MinimumDelayBlocks         *int64                     `json:"minimum_delay_blocks,omitempty" comment:"minimum delay in blocks before the payout is executed"`
+	MaximumDelayBlocks         *int64                     `json:"maximum_delay_blocks,omitempty" comment:"maximum delay in blocks before the payout is executed"`
+	SimulationBatchSize        *int                       `json:"simulation_batch_size,omitempty" comment:"size of the batch for simulation (number of transactions, higher usually means faster simulation but in case of failure, more transactions will be lost and need to be simulated again)"`
+	MaxBatchesInFlight         *int                       `json:"max_batches_in_flight,omitempty" comment:"maximum number of batches dispatched before previous ones are confirmed, batches are injected with increasing counters and confirmed concurrently (defaults to 1 - batches are executed one by one)"`
+	FeeBumping                 *FeeBumpingConfigurationV0 `json:"fee_bumping,omitempty" comment:"replace operations not included in time by the same operation with higher fees (disabled if not set), extra fees are paid by the baker"`
+	Multisig                   *MultisigConfigurationV0   `json:"multisig,omitempty" comment:"pay out from generic multisig contract, payout wallet only pays transaction fees and submits batches signed by the multisig signers (only tez payouts are supported)"`
+}
+
+type FeeBumpingConfigurationV0 struct {
+	AfterBlocks *int64   `json:"after_blocks,omitempty" comment:"number of blocks to wait for inclusion before the fee is bumped (defaults to 5)"`
+	FeeIncrease *float64 `json:"fee_increase,omitempty" comment:"portion by which the fee is increased on each bump, at least 0.05 required by mempool to replace the operation (defaults to 0.2)"`
+	MaxBumps    *int     `json:"max_bumps,omitempty" comment:"maximum number of fee bumps of a single batch (defaults to 3)"`
+}
+
+type MultisigConfigurationV0 struct {
+	Contract tezos.Address `json:"contract" comment:"address of the generic multisig contract holding the payout funds"`
+	Signers  []string      `json:"signers" comment:"signers of the multisig, each can be 'keyfile:<path>', 'key:<private key>', 'remote:<pkh>@<url>' or 'stdio:<command>'"`
+}
+
+type ReportingConfigurationV0 struct {
+	Kind     enums.EReporterKind `json:"kind,omitempty" comment:"where payout reports are stored, can be 'filesystem' or 'sqlite'"`
+	Database string              `json:"database,omitempty" comment:"path to sqlite database file, relative paths are resolved against working directory (defaults to 'reports.db' inside reports directory)"`
 }
 
 type ExtensionConfigurationV0 = common.ExtensionDefinition
 
+type BakerProfileV0 struct {
+	Name                string                     `json:"name,omitempty" comment:"name of the baker profile used in logs and notifications (defaults to baker's public key hash)"`
+	BakerPKH            tezos.Address              `json:"baker" comment:"baker's public key hash"`
+	PayoutWallet        string                     `json:"payout_wallet,omitempty" comment:"payout wallet of the baker, can be a wallet mode, 'keyfile:<path>', 'remote:<pkh>@<url>' or 'stdio:<command>' (if not set, the main payout wallet is used)"`
+	PayoutConfiguration *PayoutConfigurationV0     `json:"payouts,omitempty" comment:"payout configuration of the baker (if not set, the main payout configuration is used)"`
+	Delegators          *DelegatorsConfigurationV0 `json:"delegators,omitempty" comment:"delegators configuration of the baker (if not set, the main delegators configuration is used)"`
+	IncomeRecipients    *IncomeRecipientsV0        `json:"income_recipients,omitempty" comment:"income recipients configuration of the baker (if not set, the main income recipients configuration is used)"`
+	ReportsDirectory    string                     `json:"reports_directory,omitempty" comment:"subdirectory of the reports directory to store baker's reports in (defaults to profile name)"`
+}
+
 type ConfigurationV0 struct {
 	Version                    uint                          `json:"tezpay_config_version" comment:"version of the configuration file"`
 	BakerPKH                   tezos.Address                 `json:"baker" comment:"baker's public key hash"`
@@ -83,8 +146,10 @@ type ConfigurationV0 struct {
 	IncomeRecipients           IncomeRecipientsV0            `json:"income_recipients,omitempty" comment:"income recipients configuration"`
 	Network                    TezosNetworkConfigurationV0   `json:"network,omitempty" comment:"tezos network configuration"`
 	Overdelegation             OverdelegationConfigurationV0 `json:"overdelegation,omitempty" comment:"overdelegation protection configuration"`
+	Reporting                  ReportingConfigurationV0      `json:"reporting,omitempty" comment:"payout reports storage configuration"`
 	NotificationConfigurations []json.RawMessage             `json:"notifications,omitempty" comment:"notification configurations"`
 	Extensions                 []ExtensionConfigurationV0    `json:"extensions,omitempty" comment:"extensions (for custom functionality)"`
+	Bakers                     []BakerProfileV0              `json:"bakers,omitempty" comment:"baker profiles to pay out in continual mode, if set only listed bakers are processed and main configuration is used as their defaults"`
 	SourceBytes                []byte                        `json:"-"`
 	DisableAnalytics           bool                          `json:"disable_analytics,omitempty" comment:"disables analytics, please consider leaving it enabled🙏"`
 	DisableKillSwitch          bool                          `json:"disable_kill_switch,omitempty" comment:"disables kill switch, please consider leaving it enabled🙏"`
@@ -119,6 +184,7 @@ func GetDefaultV0() ConfigurationV0 {
 		Network: TezosNetworkConfigurationV0{
 			RpcPool:                constants.DEFAULT_RPC_POOL,
 			TzktUrl:                constants.DEFAULT_TZKT_URL,
+			Collector:              enums.COLLECTOR_KIND_DEFAULT,
 			Explorer:               constants.DEFAULT_EXPLORER_URL,
 			DoNotPaySmartContracts: false,
 			IgnoreProtocolChanges:  false,
@@ -141,7 +207,10 @@ func GetDefaultV0() ConfigurationV0 {
 			SimulationBatchSize:        &simulationBatchSize,
 			IgnoreEmptyAccounts:        false,
 		},
-		IncomeRecipients:           IncomeRecipientsV0{},
+		IncomeRecipients: IncomeRecipientsV0{},
+		Reporting: ReportingConfigurationV0{
+			Kind: enums.REPORTER_KIND_FILESYSTEM,
+		},
 		NotificationConfigurations: make([]json.RawMessage, 0),
 		SourceBytes:                []byte{},
 		DisableAnalytics:           false,
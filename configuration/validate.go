@@ -3,10 +3,15 @@ package configuration
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
 
 	"github.com/samber/lo"
+	tezpay_configuration "github.com/tez-capital/tezpay/configuration/v"
 	"github.com/tez-capital/tezpay/constants"
 	"github.com/tez-capital/tezpay/constants/enums"
+	signer_engines "github.com/tez-capital/tezpay/engines/signer"
 	"github.com/tez-capital/tezpay/notifications"
 	"github.com/tez-capital/tezpay/utils"
 	"github.com/trilitech/tzgo/tezos"
@@ -22,66 +27,195 @@ func getPortionRangeError(id string, value float64) string {
 	return fmt.Sprintf("%s must be between 0 and 1. Current value '%.2f'", id, value)
 }
 
-func (configuration *RuntimeConfiguration) Validate() (err error) {
-	defer func() {
-		msg, _ := recover().(string)
-		if msg != "" {
-			err = errors.Join(constants.ErrConfigurationValidationFailed, errors.New(msg))
+func validateFeeSchedule(id string, schedule RuntimeFeeSchedule) {
+	for i, entry := range schedule {
+		_assert(entry.FromCycle > 0, fmt.Sprintf("%s[%d].from_cycle has to be greater than 0", id, i))
+		_assert(i == 0 || schedule[i-1].FromCycle != entry.FromCycle, fmt.Sprintf("%s - cycle %d is scheduled multiple times", id, entry.FromCycle))
+		_assert(utils.IsPortionWithin0n1(entry.Fee), getPortionRangeError(fmt.Sprintf("%s[%d].fee", id, i), entry.Fee))
+	}
+}
+
+func formatTierBalance(balance tezos.Z) string {
+	return fmt.Sprintf("%g tez", float64(balance.Int64())/constants.MUTEZ_FACTOR)
+}
+
+func validateFeeTiers(id string, tiers RuntimeFeeTiers) {
+	for i, tier := range tiers {
+		_assert(utils.IsPortionWithin0n1(tier.Fee), getPortionRangeError(fmt.Sprintf("%s[%d].fee", id, i), tier.Fee))
+		_assert(tier.MaximumBalance == nil || tier.MinimumBalance.IsLess(*tier.MaximumBalance),
+			fmt.Sprintf("%s[%d].maximum_balance has to be greater than minimum_balance", id, i))
+		if i == 0 {
+			continue
 		}
-	}()
+		previous := tiers[i-1]
+		_assert(previous.MaximumBalance != nil,
+			fmt.Sprintf("%s - tier starting at %s overlaps with unbounded tier starting at %s", id, formatTierBalance(tier.MinimumBalance), formatTierBalance(previous.MinimumBalance)))
+		_assert(!tier.MinimumBalance.IsLess(*previous.MaximumBalance),
+			fmt.Sprintf("%s - tier starting at %s overlaps with tier ending at %s", id, formatTierBalance(tier.MinimumBalance), formatTierBalance(*previous.MaximumBalance)))
+		_assert(tier.MinimumBalance.Equal(*previous.MaximumBalance),
+			fmt.Sprintf("%s - gap between tier ending at %s and tier starting at %s", id, formatTierBalance(*previous.MaximumBalance), formatTierBalance(tier.MinimumBalance)))
+	}
+}
 
-	_assert(configuration != nil, "configuration is nil")
-	_assert(lo.Contains(enums.SUPPORTED_WALLET_MODES, configuration.PayoutConfiguration.WalletMode),
-		fmt.Sprintf("configuration.payouts.wallet_mode - '%s' not supported", configuration.PayoutConfiguration.WalletMode))
-	_assert(lo.Contains(enums.SUPPORTED_PAYOUT_MODES, configuration.PayoutConfiguration.PayoutMode),
-		fmt.Sprintf("configuration.payouts.payout_mode - '%s' not supported", configuration.PayoutConfiguration.PayoutMode))
-	_assert(configuration.PayoutConfiguration.MinimumDelayBlocks <= configuration.PayoutConfiguration.MaximumDelayBlocks,
-		"configuration.payouts.minimum_delay_blocks must be less or equal to configuration.payouts.maximum_delay_blocks")
-
-	_assert(lo.Contains(enums.SUPPORTED_DELEGATOR_MINIMUM_BALANCE_REWARD_DESTINATIONS, configuration.Delegators.Requirements.BellowMinimumBalanceRewardDestination),
-		fmt.Sprintf("configuration.delegators.requirements.below_minimum_reward_destination - '%s' not supported", configuration.Delegators.Requirements.BellowMinimumBalanceRewardDestination))
-
-	_assert(utils.IsPortionWithin0n1(configuration.PayoutConfiguration.Fee),
-		getPortionRangeError("configuration.payouts.fee", configuration.PayoutConfiguration.Fee))
-	_assert(utils.IsPortionWithin0n1(configuration.IncomeRecipients.DonateFees),
-		getPortionRangeError("configuration.income_recipients.donate/fees", configuration.IncomeRecipients.DonateFees))
-	_assert(utils.IsPortionWithin0n1(configuration.IncomeRecipients.DonateBonds),
-		getPortionRangeError("configuration.income_recipients.donate/bonds", configuration.IncomeRecipients.DonateBonds))
-
-	bondsPortions := lo.Reduce(lo.Values(configuration.IncomeRecipients.Bonds), func(agg float64, val float64, _ int) float64 {
+func validateBakerConfiguration(prefix string, payouts *RuntimePayoutConfiguration, delegators *RuntimeDelegatorsConfiguration, incomeRecipients *RuntimeIncomeRecipients) {
+	_assert(lo.Contains(enums.SUPPORTED_PAYOUT_MODES, payouts.PayoutMode),
+		fmt.Sprintf("%s.payouts.payout_mode - '%s' not supported", prefix, payouts.PayoutMode))
+	_assert(payouts.MinimumDelayBlocks <= payouts.MaximumDelayBlocks,
+		fmt.Sprintf("%s.payouts.minimum_delay_blocks must be less or equal to %s.payouts.maximum_delay_blocks", prefix, prefix))
+
+	_assert(lo.Contains(enums.SUPPORTED_DELEGATOR_MINIMUM_BALANCE_REWARD_DESTINATIONS, delegators.Requirements.BellowMinimumBalanceRewardDestination),
+		fmt.Sprintf("%s.delegators.requirements.below_minimum_reward_destination - '%s' not supported", prefix, delegators.Requirements.BellowMinimumBalanceRewardDestination))
+
+	_assert(lo.Contains(enums.SUPPORTED_FEE_TIER_BALANCES, delegators.FeeTiersBalance),
+		fmt.Sprintf("%s.delegators.fee_tiers_balance - '%s' not supported", prefix, delegators.FeeTiersBalance))
+	validateFeeTiers(prefix+".delegators.fee_tiers", delegators.FeeTiers)
+	for i, rule := range delegators.Loyalty {
+		_assert(rule.AfterCycles > 0, fmt.Sprintf("%s.delegators.loyalty[%d].after_cycles has to be greater than 0", prefix, i))
+		_assert(i == 0 || delegators.Loyalty[i-1].AfterCycles != rule.AfterCycles,
+			fmt.Sprintf("%s.delegators.loyalty - multiple rules after %d cycles", prefix, rule.AfterCycles))
+		_assert(utils.IsPortionWithin0n1(rule.Discount), getPortionRangeError(fmt.Sprintf("%s.delegators.loyalty[%d].discount", prefix, i), rule.Discount))
+	}
+
+	_assert(utils.IsPortionWithin0n1(payouts.Fee),
+		getPortionRangeError(prefix+".payouts.fee", payouts.Fee))
+	validateFeeSchedule(prefix+".payouts.fee_schedule", payouts.FeeSchedule)
+	if payouts.FeeBumping != nil {
+		_assert(payouts.FeeBumping.AfterBlocks > 0, fmt.Sprintf("%s.payouts.fee_bumping.after_blocks has to be greater than 0", prefix))
+		_assert(payouts.FeeBumping.FeeIncrease >= constants.MINIMUM_FEE_BUMPING_FEE_INCREASE && payouts.FeeBumping.FeeIncrease <= 1,
+			fmt.Sprintf("%s.payouts.fee_bumping.fee_increase has to be between %g and 1", prefix, constants.MINIMUM_FEE_BUMPING_FEE_INCREASE))
+		_assert(payouts.FeeBumping.MaxBumps > 0, fmt.Sprintf("%s.payouts.fee_bumping.max_bumps has to be greater than 0", prefix))
+	}
+	if payouts.Multisig != nil {
+		_assert(payouts.Multisig.Contract.IsContract(), fmt.Sprintf("%s.payouts.multisig.contract has to be valid KT1 address", prefix))
+		_assert(len(payouts.Multisig.Signers) > 0, fmt.Sprintf("%s.payouts.multisig.signers - at least one signer required", prefix))
+	}
+	if payouts.StakingFee != nil {
+		_assert(utils.IsPortionWithin0n1(*payouts.StakingFee),
+			getPortionRangeError(prefix+".payouts.staking_fee", *payouts.StakingFee))
+	}
+	_assert(utils.IsPortionWithin0n1(incomeRecipients.DonateFees),
+		getPortionRangeError(prefix+".income_recipients.donate/fees", incomeRecipients.DonateFees))
+	_assert(utils.IsPortionWithin0n1(incomeRecipients.DonateBonds),
+		getPortionRangeError(prefix+".income_recipients.donate/bonds", incomeRecipients.DonateBonds))
+
+	bondsPortions := lo.Reduce(lo.Values(incomeRecipients.Bonds), func(agg float64, val float64, _ int) float64 {
 		return agg + val
 	}, float64(0))
-	_assert(utils.IsPortionWithin0n1(bondsPortions), getPortionRangeError("configuration.income_recipients.bonds sum", bondsPortions))
-	for k := range configuration.IncomeRecipients.Bonds {
+	_assert(utils.IsPortionWithin0n1(bondsPortions), getPortionRangeError(prefix+".income_recipients.bonds sum", bondsPortions))
+	for k := range incomeRecipients.Bonds {
 		_, err := tezos.ParseAddress(k)
-		_assert(err == nil, fmt.Sprintf("configuration.income_recipients.bonds.%s has to be valid PKH", k))
+		_assert(err == nil, fmt.Sprintf("%s.income_recipients.bonds.%s has to be valid PKH", prefix, k))
 	}
 
-	feesPortions := lo.Reduce(lo.Values(configuration.IncomeRecipients.Fees), func(agg float64, val float64, _ int) float64 {
+	feesPortions := lo.Reduce(lo.Values(incomeRecipients.Fees), func(agg float64, val float64, _ int) float64 {
 		return agg + val
 	}, float64(0))
 	_assert(utils.IsPortionWithin0n1(feesPortions),
-		getPortionRangeError("configuration.income_recipients.fees sum", feesPortions))
-	for k := range configuration.IncomeRecipients.Fees {
+		getPortionRangeError(prefix+".income_recipients.fees sum", feesPortions))
+	for k := range incomeRecipients.Fees {
 		_, err := tezos.ParseAddress(k)
-		_assert(err == nil, fmt.Sprintf("configuration.income_recipients.fees.%s has to be valid PKH", k))
+		_assert(err == nil, fmt.Sprintf("%s.income_recipients.fees.%s has to be valid PKH", prefix, k))
 	}
 
-	donatePortions := lo.Reduce(lo.Values(configuration.IncomeRecipients.Donations), func(agg float64, val float64, _ int) float64 {
+	donatePortions := lo.Reduce(lo.Values(incomeRecipients.Donations), func(agg float64, val float64, _ int) float64 {
 		return agg + val
 	}, float64(0))
 	_assert(utils.IsPortionWithin0n1(donatePortions),
-		getPortionRangeError("configuration.income_recipients.donations sum", donatePortions))
-	for k := range configuration.IncomeRecipients.Donations {
+		getPortionRangeError(prefix+".income_recipients.donations sum", donatePortions))
+	for k := range incomeRecipients.Donations {
 		_, err := tezos.ParseAddress(k)
-		_assert(err == nil, fmt.Sprintf("configuration.income_recipients.donations.%s has to be valid PKH", k))
+		_assert(err == nil, fmt.Sprintf("%s.income_recipients.donations.%s has to be valid PKH", prefix, k))
 	}
 
-	for k, v := range configuration.Delegators.Overrides {
+	for k, v := range delegators.Overrides {
 		_, err := tezos.ParseAddress(k)
-		_assert(err == nil, fmt.Sprintf("configuration.delegators.overrides.%s has to be valid PKH", k))
-		_assert(v.Fee == nil || utils.IsPortionWithin0n1(*v.Fee),
-			getPortionRangeError(fmt.Sprintf("configuration.delegators.overrides.%s fee", k), *v.Fee))
+		_assert(err == nil, fmt.Sprintf("%s.delegators.overrides.%s has to be valid PKH", prefix, k))
+		if v.Fee != nil {
+			_assert(utils.IsPortionWithin0n1(*v.Fee),
+				getPortionRangeError(fmt.Sprintf("%s.delegators.overrides.%s fee", prefix, k), *v.Fee))
+		}
+		validateFeeSchedule(fmt.Sprintf("%s.delegators.overrides.%s.fee_schedule", prefix, k), v.FeeSchedule)
+		if v.StakingFee != nil {
+			_assert(utils.IsPortionWithin0n1(*v.StakingFee),
+				getPortionRangeError(fmt.Sprintf("%s.delegators.overrides.%s staking_fee", prefix, k), *v.StakingFee))
+		}
+		_assert(v.PayoutInterval >= 0 && v.PayoutInterval <= constants.MAXIMUM_PAYOUT_INTERVAL_CYCLES,
+			fmt.Sprintf("%s.delegators.overrides.%s.payout_interval has to be between 0 and %d", prefix, k, constants.MAXIMUM_PAYOUT_INTERVAL_CYCLES))
+		_assert(v.MinimumPayoutAmount == nil || !v.MinimumPayoutAmount.IsNeg(),
+			fmt.Sprintf("%s.delegators.overrides.%s.minimum_payout_amount can not be negative", prefix, k))
+	}
+}
+
+func validateExtensions(extensions []tezpay_configuration.ExtensionConfigurationV0) {
+	for i, extension := range extensions {
+		prefix := fmt.Sprintf("configuration.extensions[%d]", i)
+		if extension.Name != "" {
+			prefix = fmt.Sprintf("configuration.extensions.%s", extension.Name)
+		}
+		_assert(lo.Contains(enums.SUPPORTED_EXTENSION_RPC_KINDS, extension.Kind),
+			fmt.Sprintf("%s.kind - '%s' not supported", prefix, extension.Kind))
+		switch extension.Kind {
+		case enums.EXTENSION_STDIO_RPC:
+			_assert(extension.Command != "", fmt.Sprintf("%s.command is required for '%s' extensions", prefix, extension.Kind))
+		case enums.EXTENSION_TCP_RPC:
+			_assert(extension.Url != "", fmt.Sprintf("%s.url is required for '%s' extensions", prefix, extension.Kind))
+		case enums.EXTENSION_HTTP_RPC, enums.EXTENSION_WS_RPC:
+			schemes := []string{"http", "https"}
+			if extension.Kind == enums.EXTENSION_WS_RPC {
+				schemes = []string{"ws", "wss"}
+			}
+			parsedUrl, err := url.Parse(extension.Url)
+			_assert(err == nil && lo.Contains(schemes, parsedUrl.Scheme) && parsedUrl.Host != "",
+				fmt.Sprintf("%s.url - '%s' has to be valid %s url", prefix, extension.Url, strings.Join(schemes, "/")))
+		}
+		if extension.Tls != nil {
+			_assert((extension.Tls.CertFile == "") == (extension.Tls.KeyFile == ""),
+				fmt.Sprintf("%s.tls.cert_file and %s.tls.key_file have to be set together", prefix, prefix))
+		}
+		if extension.Reconnect != nil {
+			_assert(extension.Reconnect.Attempts >= 0 && extension.Reconnect.Delay >= 0,
+				fmt.Sprintf("%s.reconnect.attempts and %s.reconnect.delay can not be negative", prefix, prefix))
+		}
+	}
+}
+
+func validateBakerProfiles(configuration *RuntimeConfiguration) {
+	_assert(configuration.Reporting.Database == "", "configuration.reporting.database can not be used together with configuration.bakers")
+
+	bakers := make(map[string]bool, len(configuration.Bakers))
+	directories := make(map[string]bool, len(configuration.Bakers))
+	for _, profile := range configuration.Bakers {
+		prefix := fmt.Sprintf("configuration.bakers.%s", profile.Name)
+		_assert(profile.BakerPKH.IsValid(), fmt.Sprintf("%s.baker has to be valid PKH", prefix))
+		_assert(!bakers[profile.BakerPKH.String()], fmt.Sprintf("%s.baker - '%s' is used by multiple profiles", prefix, profile.BakerPKH))
+		bakers[profile.BakerPKH.String()] = true
+
+		_assert(filepath.IsLocal(profile.ReportsDirectory), fmt.Sprintf("%s.reports_directory - '%s' has to be relative path inside reports directory", prefix, profile.ReportsDirectory))
+		reportsDirectory := filepath.Clean(profile.ReportsDirectory)
+		_assert(!directories[reportsDirectory], fmt.Sprintf("%s.reports_directory - '%s' is used by multiple profiles", prefix, profile.ReportsDirectory))
+		directories[reportsDirectory] = true
+
+		validateBakerConfiguration(prefix, &profile.PayoutConfiguration, &profile.Delegators, &profile.IncomeRecipients)
+	}
+}
+
+func (configuration *RuntimeConfiguration) Validate() (err error) {
+	defer func() {
+		msg, _ := recover().(string)
+		if msg != "" {
+			err = errors.Join(constants.ErrConfigurationValidationFailed, errors.New(msg))
+		}
+	}()
+
+	_assert(configuration != nil, "configuration is nil")
+	_assert(lo.Contains(signer_engines.GetSupportedWalletModes(), configuration.PayoutConfiguration.WalletMode),
+		fmt.Sprintf("configuration.payouts.wallet_mode - '%s' not supported", configuration.PayoutConfiguration.WalletMode))
+	_assert(lo.Contains(enums.SUPPORTED_REPORTER_KINDS, configuration.Reporting.Kind),
+		fmt.Sprintf("configuration.reporting.kind - '%s' not supported", configuration.Reporting.Kind))
+
+	validateBakerConfiguration("configuration", &configuration.PayoutConfiguration, &configuration.Delegators, &configuration.IncomeRecipients)
+	if configuration.IsMultiBaker() {
+		validateBakerProfiles(configuration)
 	}
 
 	for _, v := range configuration.NotificationConfigurations {
@@ -92,6 +226,17 @@ func (configuration *RuntimeConfiguration) Validate() (err error) {
 		_assert(err == nil, fmt.Sprintf("configuration.notifications.%s has invalid configuration - %s", v.Type, err.Error()))
 	}
 
+	validateExtensions(configuration.Extensions)
+
 	_assert(len(configuration.Network.RpcPool) > 0, "no rpc specified")
+	_assert(lo.Contains(enums.SUPPORTED_COLLECTOR_KINDS, configuration.Network.Collector),
+		fmt.Sprintf("configuration.network.collector - '%s' not supported", configuration.Network.Collector))
+	if crossCheck := configuration.Network.CrossCheck; crossCheck != nil {
+		_assert(lo.Contains(enums.SUPPORTED_COLLECTOR_KINDS, crossCheck.Collector),
+			fmt.Sprintf("configuration.network.cross_check.collector - '%s' not supported", crossCheck.Collector))
+		_assert(lo.Contains(enums.SUPPORTED_CROSS_CHECK_ACTIONS, crossCheck.Action),
+			fmt.Sprintf("configuration.network.cross_check.action - '%s' not supported", crossCheck.Action))
+		_assert(crossCheck.Tolerance >= 0 && crossCheck.Tolerance <= 1, "configuration.network.cross_check.tolerance - has to be between 0 and 1")
+	}
 	return
 }
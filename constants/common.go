@@ -20,6 +20,13 @@ const (
 	DEFAULT_TX_FEE_BUFFER                 = int64(0)
 	DEFAULT_KT_TX_FEE_BUFFER              = int64(0)
 	DEFAULT_SIMULATION_TX_BATCH_SIZE      = 50
+	DEFAULT_CROSS_CHECK_TOLERANCE         = float64(.001)
+	DEFAULT_MAX_BATCHES_IN_FLIGHT         = 1
+	DEFAULT_FEE_BUMPING_AFTER_BLOCKS      = int64(5)
+	DEFAULT_FEE_BUMPING_FEE_INCREASE      = float64(.2)
+	DEFAULT_FEE_BUMPING_MAX_BUMPS         = 3
+	// mempool replaces operation only if its fee is higher at least by 5%
+	MINIMUM_FEE_BUMPING_FEE_INCREASE = float64(.05)
 
 	// buffer for signature, branch etc.
 	DEFAULT_BATCHING_OPERATION_DATA_BUFFER = 3000
@@ -28,6 +35,14 @@ const (
 	MAX_OPERATION_TTL  = 12   // 12 blocks
 	ALLOCATION_STORAGE = 257
 
+	// generic multisig wrapper costs, signatures are checked for every key of the contract
+	MULTISIG_BASE_GAS_LIMIT      = int64(5000)
+	MULTISIG_SIGNATURE_GAS_LIMIT = int64(1500)
+	MULTISIG_TRANSFER_GAS_LIMIT  = int64(1000)
+	MULTISIG_STORAGE_LIMIT       = int64(100)
+	MULTISIG_BASE_BYTES          = 200
+	MULTISIG_SIGNATURE_BYTES     = 100
+
 	DEFAULT_CYCLE_MONITOR_MAXIMUM_DELAY = int64(1500)
 	DEFAULT_CYCLE_MONITOR_MINIMUM_DELAY = int64(500)
 
@@ -36,6 +51,7 @@ const (
 	INVALID_REPORT_FILE_NAME  = "invalid.csv"
 	REPORT_SUMMARY_FILE_NAME  = "summary.json"
 	REPORTS_DIRECTORY         = "reports"
+	REPORTS_DATABASE_FILE     = "reports.db"
 
 	DEFAULT_DONATION_ADDRESS    = "tz1UGkfyrT9yBt6U5PV7Qeui3pt3a8jffoWv"
 	DEFAULT_DONATION_PERCENTAGE = 0.05
@@ -45,6 +61,13 @@ const (
 	MAXIMUM_PAYOUT_INTERVAL_CYCLES = int64(365)
 
 	DRY_RUN_NOTE = "[DRY RUN]"
+
+	// api server
+	DEFAULT_API_HISTORY_CYCLES = 10
+	MAXIMUM_API_HISTORY_CYCLES = 100
+
+	// reconcile - how long after recording we look for the operation (in minutes)
+	RECONCILE_LOOKUP_WINDOW_MINUTES = 60
 )
 
 var (
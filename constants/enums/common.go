@@ -7,15 +7,8 @@ const (
 	WALLET_MODE_LOCAL_PRIVATE_KEY2 EWalletMode = "local_private_key"
 	WALLET_MODE_REMOTE_SIGNER      EWalletMode = "remote-signer"
 	WALLET_MODE_REMOTE_SIGNER2     EWalletMode = "remote_signer"
-)
-
-var (
-	SUPPORTED_WALLET_MODES = []EWalletMode{
-		WALLET_MODE_LOCAL_PRIVATE_KEY,
-		WALLET_MODE_LOCAL_PRIVATE_KEY2,
-		WALLET_MODE_REMOTE_SIGNER,
-		WALLET_MODE_REMOTE_SIGNER2,
-	}
+	WALLET_MODE_STDIO_SIGNER       EWalletMode = "stdio-signer"
+	WALLET_MODE_STDIO_SIGNER2      EWalletMode = "stdio_signer"
 )
 
 type EPayoutMode string
@@ -32,6 +25,48 @@ var (
 	}
 )
 
+type EReporterKind string
+
+const (
+	REPORTER_KIND_FILESYSTEM EReporterKind = "filesystem"
+	REPORTER_KIND_SQLITE     EReporterKind = "sqlite"
+)
+
+var (
+	SUPPORTED_REPORTER_KINDS = []EReporterKind{
+		REPORTER_KIND_FILESYSTEM,
+		REPORTER_KIND_SQLITE,
+	}
+)
+
+type ECollectorKind string
+
+const (
+	COLLECTOR_KIND_DEFAULT ECollectorKind = "default"
+	COLLECTOR_KIND_RPC     ECollectorKind = "rpc"
+)
+
+var (
+	SUPPORTED_COLLECTOR_KINDS = []ECollectorKind{
+		COLLECTOR_KIND_DEFAULT,
+		COLLECTOR_KIND_RPC,
+	}
+)
+
+type ECrossCheckAction string
+
+const (
+	CROSS_CHECK_ACTION_ABORT  ECrossCheckAction = "abort"
+	CROSS_CHECK_ACTION_NOTIFY ECrossCheckAction = "notify"
+)
+
+var (
+	SUPPORTED_CROSS_CHECK_ACTIONS = []ECrossCheckAction{
+		CROSS_CHECK_ACTION_ABORT,
+		CROSS_CHECK_ACTION_NOTIFY,
+	}
+)
+
 type EPayoutInvalidReason string
 
 const (
@@ -49,6 +84,7 @@ const (
 	INVALID_NOT_ENOUGH_BONDS_FOR_BAKER_FEE EPayoutInvalidReason = "NOT_ENOUGH_BONDS_FOR_BAKER_FEE"
 	INVALID_UNSUPPORTED_TX_KIND            EPayoutInvalidReason = "UNSUPPORTED_TX_KIND"
 	INVALID_MANUALLY_EXCLUDED_BY_PREFIX    EPayoutInvalidReason = "MANUALLY_EXCLUDED_BY_PREFIX"
+	INVALID_PAYOUT_DEFERRED                EPayoutInvalidReason = "PAYOUT_DEFERRED"
 	ITERMEDIATE_FAILED_TO_ESTIMATE_BATCH   EPayoutInvalidReason = "FAILED_TO_ESTIMATE_BATCH"
 )
 
@@ -59,6 +95,7 @@ const (
 	PAYOUT_KIND_BAKER_REWARD     EPayoutKind = "baker reward"
 	PAYOUT_KIND_DONATION         EPayoutKind = "donation"
 	PAYOUT_KIND_FEE_INCOME       EPayoutKind = "fee income"
+	PAYOUT_KIND_STAKING_REWARD   EPayoutKind = "staking reward"
 )
 
 func (kind EPayoutKind) ToPriority() int {
@@ -66,6 +103,8 @@ func (kind EPayoutKind) ToPriority() int {
 	switch kind {
 	case PAYOUT_KIND_DELEGATOR_REWARD:
 		return 10
+	case PAYOUT_KIND_STAKING_REWARD:
+		return 10
 	case PAYOUT_KIND_BAKER_REWARD:
 		return 9
 	case PAYOUT_KIND_DONATION:
@@ -77,6 +116,11 @@ func (kind EPayoutKind) ToPriority() int {
 	}
 }
 
+// IsDelegatorPayout returns true for rewards paid to delegators and stakers
+func (kind EPayoutKind) IsDelegatorPayout() bool {
+	return kind == PAYOUT_KIND_DELEGATOR_REWARD || kind == PAYOUT_KIND_STAKING_REWARD
+}
+
 type EPayoutTransactionKind string
 
 const (
@@ -105,3 +149,28 @@ var (
 		REWARD_DESTINATION_EVERYONE,
 	}
 )
+
+type EFeeTierBalance string
+
+const (
+	FEE_TIER_BALANCE_TOTAL     EFeeTierBalance = "total"
+	FEE_TIER_BALANCE_DELEGATED EFeeTierBalance = "delegated"
+	FEE_TIER_BALANCE_STAKED    EFeeTierBalance = "staked"
+)
+
+var (
+	SUPPORTED_FEE_TIER_BALANCES = []EFeeTierBalance{
+		FEE_TIER_BALANCE_TOTAL,
+		FEE_TIER_BALANCE_DELEGATED,
+		FEE_TIER_BALANCE_STAKED,
+	}
+)
+
+type ERedirectStatus string
+
+const (
+	REDIRECT_STATUS_PENDING    ERedirectStatus = "pending"
+	REDIRECT_STATUS_APPROVED   ERedirectStatus = "approved"
+	REDIRECT_STATUS_REVOKED    ERedirectStatus = "revoked"
+	REDIRECT_STATUS_SUPERSEDED ERedirectStatus = "superseded"
+)
@@ -12,6 +12,9 @@ const (
 var (
 	SUPPORTED_EXTENSION_RPC_KINDS = []EExtensionRpcKind{
 		EXTENSION_STDIO_RPC,
+		EXTENSION_TCP_RPC,
+		EXTENSION_HTTP_RPC,
+		EXTENSION_WS_RPC,
 	}
 )
 
@@ -44,6 +47,8 @@ const (
 
 	EXTENSION_HOOK_TEST_NOTIFY  EExtensionHook = "test-notify"
 	EXTENSION_HOOK_TEST_REQUEST EExtensionHook = "test-request"
+	// can adjust or supply cycle data collected from the chain (e.g. off-chain bonus pools)
+	EXTENSION_HOOK_AFTER_CYCLE_DATA_COLLECTED EExtensionHook = "after_cycle_data_collected"
 	// can adjust generated candidate list (inject, remove, mutate)
 	EXTENSION_HOOK_AFTER_CANDIDATES_GENERATED EExtensionHook = "after_candidates_generated"
 	// can adjust generated bond list (inject, remove, mutate)
@@ -58,8 +63,11 @@ const (
 	EXTENSION_HOOK_AFTER_PAYOUTS_PREPARED EExtensionHook = "after_payouts_prepared"
 	// can adjust accumulated payouts just before real payout, past successful payouts are accessible through separate object
 	EXTENSION_HOOK_AFTER_PAYOUTS_ACCUMULATED EExtensionHook = "after_payouts_accumulated"
+	// readonly, fired for each batch once its result is final (including recovered batches)
+	EXTENSION_HOOK_AFTER_BATCH_APPLIED EExtensionHook = "after_batch_applied"
+	// readonly, fired once all batches are executed and reports are written
+	EXTENSION_HOOK_AFTER_PAYOUTS_EXECUTED EExtensionHook = "after_payouts_executed"
 
-	// EXTENSION_HOOK_AFTER_PAYOUTS_FINALIZED            EExtensionHook = "after_payouts_finalized"
 	// EXTENSION_HOOK_AFTER_PAYOUTS_PREPARE_DISTRIBUTION EExtensionHook = "after_prepare_distribution"
 	// EXTENSION_HOOK_AFTER_REWARD_DISTRIBUTED           EExtensionHook = "after_reward_distributed"
 
@@ -70,11 +78,14 @@ const (
 
 var (
 	SUPPORTED_EXTENSION_HOOKS = []EExtensionHook{
+		EXTENSION_HOOK_AFTER_CYCLE_DATA_COLLECTED,
 		EXTENSION_HOOK_AFTER_CANDIDATES_GENERATED,
 		EXTENSION_HOOK_AFTER_BONDS_DISTRIBUTED,
 		EXTENSION_HOOK_CHECK_BALANCE,
 		EXTENSION_HOOK_ON_FEES_COLLECTION,
 		EXTENSION_HOOK_AFTER_PAYOUTS_BLUEPRINT_GENERATED,
+		EXTENSION_HOOK_AFTER_BATCH_APPLIED,
+		EXTENSION_HOOK_AFTER_PAYOUTS_EXECUTED,
 	}
 )
 
@@ -13,8 +13,10 @@ var (
 	ErrConfigurationLoadFailed            = errors.New("failed to load configuration")
 	ErrConfigurationValidationFailed      = errors.New("failed to validate configuration")
 	ErrSignerLoadFailed                   = errors.New("failed to load signer engine")
+	ErrSignerCannotSign                   = errors.New("signer holds only public key and can not sign")
 	ErrTransactorLoadFailed               = errors.New("failed to load transactor engine")
 	ErrCollectorLoadFailed                = errors.New("failed to load collector engine")
+	ErrReporterLoadFailed                 = errors.New("failed to load reporter engine")
 	ErrExtensionStoreInitializationFailed = errors.New("failed to initialize extension store")
 
 	// consfiguration
@@ -35,6 +37,17 @@ var (
 	ErrCycleDataFetchFailed       = errors.New("failed to fetch cycle data")
 	ErrCycleDataUnmarshalFailed   = errors.New("failed to unmarshal cycle data")
 	ErrOperationStatusCheckFailed = errors.New("failed to check operation status")
+	ErrTransactionsFetchFailed    = errors.New("failed to fetch transactions")
+	ErrDelegationsFetchFailed     = errors.New("failed to fetch delegations")
+	ErrNotSupportedByCollector    = errors.New("not supported by collector engine")
+	ErrCycleDataMismatch          = errors.New("cycle data of collectors do not match")
+
+	// keystore
+
+	ErrKeystoreInvalid           = errors.New("invalid keystore")
+	ErrKeystoreDecryptFailed     = errors.New("failed to decrypt keystore, invalid passphrase?")
+	ErrKeystorePassphraseMissing = errors.New("keystore passphrase not provided")
+	ErrKeystorePassphraseInvalid = errors.New("invalid keystore passphrase")
 
 	// cycle monitor
 
@@ -56,6 +69,7 @@ var (
 	ErrRevealCheckFailed                     = errors.New("failed to check if address is revealed")
 	ErrNotRevealed                           = errors.New("address is not revealed")
 	ErrCycleDataCollectionFailed             = errors.New("failed to collect cycle data")
+	ErrInvalidCycleData                      = errors.New("invalid cycle data")
 	ErrPayoutsFromFileLoadFailed             = errors.New("failed to load payouts from file")
 	ErrPayoutsFromBytesLoadFailed            = errors.New("failed to load payouts from bytes")
 	ErrPayoutsFromStdinLoadFailed            = errors.New("failed to load payouts from stdin")
@@ -73,29 +87,79 @@ var (
 	ErrExecutePayoutsUserTerminated         = errors.New("user terminated execution")
 	ErrGetChainLimitsFailed                 = errors.New("failed to get chain limits")
 
+	// reconcile
+
+	ErrPayoutNotLanded       = errors.New("payout recorded before execution did not land on chain")
+	ErrPayoutOperationFailed = errors.New("payout operation failed on chain")
+	ErrReconcileFailed       = errors.New("failed to reconcile payouts")
+
 	// notifications
 
 	ErrUnsupportedNotificator          = errors.New("unsupported notificator")
 	ErrPayoutDidNotFitTheBatch         = errors.New("payout did not fit the batch")
 	ErrInvalidNotificatorConfiguration = errors.New("invalid notificator configuration")
 
+	// offline signing
+
+	ErrOfflineBatchInvalid         = errors.New("invalid offline batch")
+	ErrOfflineBatchNotSigned       = errors.New("offline batch is not signed")
+	ErrOfflineBatchAlreadyReported = errors.New("payouts of offline batch are already reported")
+	ErrOfflinePayoutsLoadFailed    = errors.New("failed to load offline payouts")
+	ErrOfflinePayoutsSaveFailed    = errors.New("failed to save offline payouts")
+
+	// multisig
+
+	ErrMultisigStorageInvalid       = errors.New("invalid multisig contract storage")
+	ErrMultisigUnsupportedTransfer  = errors.New("transfer not supported by multisig payouts")
+	ErrMultisigThresholdNotMet      = errors.New("not enough multisig signatures collected")
+	ErrMultisigSignerCannotSignData = errors.New("signer can not sign multisig payload")
+
+	// redirects
+
+	ErrRedirectRegistryLoadFailed   = errors.New("failed to load redirect registry")
+	ErrRedirectRegistrySaveFailed   = errors.New("failed to save redirect registry")
+	ErrRedirectSignatureInvalid     = errors.New("invalid redirect signature")
+	ErrRedirectKeyMismatch          = errors.New("public key does not belong to the delegator")
+	ErrRedirectDelegatorNotRevealed = errors.New("delegator public key is not revealed")
+	ErrRedirectInvalid              = errors.New("invalid redirect request")
+	ErrRedirectOutdated             = errors.New("redirect request is older than the last one of the delegator")
+	ErrRedirectNotFound             = errors.New("redirect registration not found")
+	ErrRedirectInvalidStatus        = errors.New("redirect registration can not be changed in its status")
+
+	// owed ledger
+
+	ErrOwedLedgerLoadFailed = errors.New("failed to load owed ledger")
+	ErrOwedLedgerSaveFailed = errors.New("failed to save owed ledger")
+
 	// operations
 
 	ErrOperationContextCreationFailed  = errors.New("failed to create operation context")
 	ErrOperationBroadcastFailed        = errors.New("failed to broadcast operation")
 	ErrOperationConfirmationFailed     = errors.New("failed to confirm operation")
 	ErrOperationNotDispatched          = errors.New("operation not dispatched")
+	ErrOperationNotIncluded            = errors.New("operation not included")
+	ErrOperationNotConfirmed           = errors.New("operation not confirmed")
 	ErrOperationInvalidContractAddress = errors.New("invalid contract address")
 	ErrOperationInvalidLimits          = errors.New("invalid limits")
 	ErrOperationFailed                 = errors.New("operation failed")
+	ErrFeeBumpFailed                   = errors.New("failed to bump operation fee")
 
 	// extensions
 
-	ErrExtensionLoadFailed          = errors.New("failed to load extension")
-	ErrUnsupportedExtensionHook     = errors.New("unsupported extension hook")
-	ErrUnsupportedExtensionHookMode = errors.New("unsupported extension hook mode")
-	ErrUnsupportedExtensionKind     = errors.New("unsupported extension kind")
-	ErrExtensionHookMissingData     = errors.New("no data forwarded to hook, cannot execute")
+	ErrExtensionLoadFailed             = errors.New("failed to load extension")
+	ErrUnsupportedExtensionHook        = errors.New("unsupported extension hook")
+	ErrUnsupportedExtensionHookMode    = errors.New("unsupported extension hook mode")
+	ErrUnsupportedExtensionKind        = errors.New("unsupported extension kind")
+	ErrExtensionHookMissingData        = errors.New("no data forwarded to hook, cannot execute")
+	ErrExtensionTlsConfigurationFailed = errors.New("failed to configure extension tls")
+	ErrExtensionConnectionFailed       = errors.New("failed to connect to extension")
+	ErrExtensionPermissionDenied       = errors.New("extension changed hook data beyond its permissions")
+	ErrExtensionHookCaptureLoadFailed  = errors.New("failed to load captured hook data")
+	ErrExtensionScaffoldFailed         = errors.New("failed to scaffold extension")
+
+	// reports import
+
+	ErrReportsImportFailed = errors.New("failed to import reports")
 
 	// tzkt client
 	ErrTzktVersionCheckFailed = errors.New("failed to check tzkt version")
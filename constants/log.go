@@ -14,6 +14,7 @@ const (
 	LOG_FIELD_CYCLE_PAYOUT_BLUEPRINT  = "cycle_payout_blueprint"
 	LOG_FIELD_SUMMARY                 = "summary"
 	LOG_FIELD_REPORTS_OF_PAST_PAYOUTS = "reports_of_past_payouts"
+	LOG_FIELD_HELD_PAYOUTS            = "held_payouts"
 	LOG_FIELD_ACCUMULATED_PAYOUTS     = "accumulated_payouts"
 	LOG_FIELD_VALID_PAYOUTS           = "valid_payouts"
 	LOG_FIELD_INVALID_PAYOUTS         = "invalid_payouts"
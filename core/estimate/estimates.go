@@ -35,6 +35,25 @@ func splitIntoBatches[T any](candidates []T, capacity int) [][]T {
 	return batches
 }
 
+// multisigEstimationTransfer replaces amount with a single mutez, funds are held by the multisig contract
+// and transfer costs do not depend on the amount
+type multisigEstimationTransfer struct {
+	common.TransferArgs
+}
+
+func (t multisigEstimationTransfer) GetAmount() tezos.Z {
+	return tezos.NewZ(1)
+}
+
+func getEstimationTransfers[T common.TransferArgs](batch []T, ctx *EstimationContext) []common.TransferArgs {
+	return lo.Map(batch, func(p T, _ int) common.TransferArgs {
+		if ctx.Configuration.PayoutConfiguration.Multisig != nil {
+			return multisigEstimationTransfer{p}
+		}
+		return p
+	})
+}
+
 func buildOpForEstimation[T common.TransferArgs](payoutKey tezos.Key, batch []T, injectBurnTransactions bool) (*codec.Op, error) {
 	var err error
 	op := codec.NewOp().WithSource(payoutKey.Address())
@@ -58,7 +77,7 @@ func estimateBatchFees[T common.TransferArgs](batch []T, ctx *EstimationContext)
 		err     error
 		receipt *rpc.Receipt
 	)
-	op, err := buildOpForEstimation(ctx.PayoutKey, batch, true)
+	op, err := buildOpForEstimation(ctx.PayoutKey, getEstimationTransfers(batch, ctx), true)
 
 	if err != nil {
 		return nil, err
@@ -100,7 +119,7 @@ func estimateBatchFees[T common.TransferArgs](batch []T, ctx *EstimationContext)
 			return nil, err
 		}
 		// rebuild op for estimates
-		op, err := buildOpForEstimation(ctx.PayoutKey, []T{batch[i]}, false)
+		op, err := buildOpForEstimation(ctx.PayoutKey, getEstimationTransfers([]T{batch[i]}, ctx), false)
 		if err != nil {
 			return nil, err
 		}
@@ -111,6 +130,10 @@ func estimateBatchFees[T common.TransferArgs](batch []T, ctx *EstimationContext)
 			feeBuffer = ctx.Configuration.PayoutConfiguration.KtTxFeeBuffer
 			gasLimitBuffer = ctx.Configuration.PayoutConfiguration.KtTxGasLimitBuffer
 		}
+		if ctx.Configuration.PayoutConfiguration.Multisig != nil {
+			// transfer is emitted by the multisig lambda
+			gasLimitBuffer += constants.MULTISIG_TRANSFER_GAS_LIMIT
+		}
 
 		common.InjectLimits(op, []tezos.Limits{{
 			GasLimit:     p.GasUsed + gasLimitBuffer,
@@ -5,6 +5,7 @@ import (
 	"github.com/tez-capital/tezpay/configuration"
 	"github.com/tez-capital/tezpay/constants"
 	"github.com/tez-capital/tezpay/core/execute"
+	"github.com/trilitech/tzgo/tezos"
 )
 
 func ExecutePayouts(preparationResult *common.PreparePayoutsResult, config *configuration.RuntimeConfiguration, engineContext *common.ExecutePayoutsEngineContext, options *common.ExecutePayoutsOptions) (*common.ExecutePayoutsResult, error) {
@@ -29,3 +30,57 @@ func ExecutePayouts(preparationResult *common.PreparePayoutsResult, config *conf
 		Summary:      ctx.StageData.Summary,
 	}, nil
 }
+
+// ExportUnsignedPayouts splits payouts into batches and forges them against the branch to be signed on another host
+func ExportUnsignedPayouts(preparationResult *common.PreparePayoutsResult, config *configuration.RuntimeConfiguration, engineContext *common.ExecutePayoutsEngineContext, branch tezos.BlockHash, options *common.ExecutePayoutsOptions) (*common.OfflinePayouts, error) {
+	if config == nil {
+		return nil, constants.ErrMissingConfiguration
+	}
+
+	ctx, err := execute.NewPayoutExecutionContext(preparationResult, config, engineContext, options)
+	if err != nil {
+		return nil, err
+	}
+	ctx.StageData.Branch = branch
+
+	ctx, err = WrapContext[*execute.PayoutExecutionContext, *common.ExecutePayoutsOptions](ctx).ExecuteStages(options,
+		execute.SplitIntoBatches,
+		execute.ForgeUnsignedBatches).Unwrap()
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.OfflinePayouts{
+		Baker:                          config.BakerPKH,
+		Cycles:                         preparationResult.GetCycles(),
+		Blueprints:                     preparationResult.Blueprints,
+		InvalidPayouts:                 preparationResult.InvalidPayouts,
+		ReportsOfPastSuccessfulPayouts: preparationResult.ReportsOfPastSuccessfulPayouts,
+		Batches:                        ctx.StageData.OfflineBatches,
+	}, nil
+}
+
+// BroadcastSignedPayouts executes batches signed on another host the same way as ExecutePayouts
+func BroadcastSignedPayouts(offlinePayouts *common.OfflinePayouts, config *configuration.RuntimeConfiguration, engineContext *common.ExecutePayoutsEngineContext, options *common.ExecutePayoutsOptions) (*common.ExecutePayoutsResult, error) {
+	if config == nil {
+		return nil, constants.ErrMissingConfiguration
+	}
+
+	ctx, err := execute.NewPayoutExecutionContext(offlinePayouts.ToPreparePayoutsResult(), config, engineContext, options)
+	if err != nil {
+		return nil, err
+	}
+	ctx.StageData.OfflineBatches = offlinePayouts.Batches
+
+	ctx, err = WrapContext[*execute.PayoutExecutionContext, *common.ExecutePayoutsOptions](ctx).ExecuteStages(options,
+		execute.LoadSignedBatches,
+		execute.ExecutePayouts).Unwrap()
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.ExecutePayoutsResult{
+		BatchResults: ctx.StageData.BatchResults,
+		Summary:      ctx.StageData.Summary,
+	}, nil
+}
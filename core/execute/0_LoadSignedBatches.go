@@ -0,0 +1,71 @@
+package execute
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/utils"
+	"github.com/trilitech/tzgo/codec"
+)
+
+// LoadSignedBatches replaces batch splitting when broadcasting batches signed on another host
+func LoadSignedBatches(ctx *PayoutExecutionContext, options *common.ExecutePayoutsOptions) (*PayoutExecutionContext, error) {
+	logger := ctx.logger.With("phase", "load_signed_batches")
+	logger.Info("loading signed batches", "batches_count", len(ctx.StageData.OfflineBatches))
+
+	batches := make([]common.RecipeBatch, 0, len(ctx.StageData.OfflineBatches))
+	signedOps := make([]*codec.Op, 0, len(ctx.StageData.OfflineBatches))
+	for i, offlineBatch := range ctx.StageData.OfflineBatches {
+		if !offlineBatch.IsSigned() {
+			return nil, errors.Join(constants.ErrOfflineBatchNotSigned, fmt.Errorf("batch %d", i+1))
+		}
+		if err := offlineBatch.Verify(); err != nil {
+			return nil, errors.Join(fmt.Errorf("batch %d", i+1), err)
+		}
+		op, err := offlineBatch.GetOp()
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, offlineBatch.GetRecipeBatch())
+		signedOps = append(signedOps, op)
+	}
+
+	// reports written after the export mean the batches were already broadcasted (or attempted to)
+	reporter := ctx.GetReporter()
+	cycles := lo.Uniq(lo.FlatMap(batches, func(batch common.RecipeBatch, _ int) []int64 {
+		return lo.FlatMap(batch, func(payout *common.AccumulatedPayoutRecipe, _ int) []int64 {
+			return lo.Map(payout.Recipes, func(recipe *common.PayoutRecipe, _ int) int64 { return recipe.Cycle })
+		})
+	}))
+	pastReportIds := lo.Map(ctx.StageData.ReportsOfPastSuccesfulPayouts, func(report common.PayoutReport, _ int) string { return report.Id })
+	reportedIds := make([]string, 0)
+	for _, cycle := range cycles {
+		reports, err := reporter.GetExistingReports(cycle)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, errors.Join(constants.ErrPayoutsFromFileLoadFailed, fmt.Errorf("cycle: %d", cycle), err)
+		}
+		for _, report := range utils.FilterReportsByBaker(reports, ctx.configuration.BakerPKH) {
+			if !slices.Contains(pastReportIds, report.Id) {
+				reportedIds = append(reportedIds, report.Id)
+			}
+		}
+	}
+	for _, batch := range batches {
+		for _, payout := range batch {
+			for _, recipe := range payout.Recipes {
+				if slices.Contains(reportedIds, recipe.GetShortIdentifier()) {
+					return nil, errors.Join(constants.ErrOfflineBatchAlreadyReported, fmt.Errorf("payout of cycle %d to %s", recipe.Cycle, recipe.Recipient.String()))
+				}
+			}
+		}
+	}
+
+	ctx.StageData.Batches = batches
+	ctx.StageData.SignedOps = signedOps
+	return ctx, nil
+}
@@ -11,14 +11,14 @@ import (
 	"github.com/trilitech/tzgo/tezos"
 )
 
-func splitIntoBatches(payouts []*common.AccumulatedPayoutRecipe, limits *common.OperationLimits, metadataDeserializationGasLimit int64) ([]common.RecipeBatch, error) {
+func splitIntoBatches(payouts []*common.AccumulatedPayoutRecipe, limits *common.OperationLimits, metadataDeserializationGasLimit int64, overhead common.BatchOverhead) ([]common.RecipeBatch, error) {
 	batches := make([]common.RecipeBatch, 0)
-	batchBlueprint := common.NewBatch(limits, metadataDeserializationGasLimit)
+	batchBlueprint := common.NewBatch(limits, metadataDeserializationGasLimit, overhead)
 
 	for _, payout := range payouts {
 		if !batchBlueprint.AddPayout(payout) {
 			batches = append(batches, batchBlueprint.ToBatch())
-			batchBlueprint = common.NewBatch(limits, metadataDeserializationGasLimit)
+			batchBlueprint = common.NewBatch(limits, metadataDeserializationGasLimit, overhead)
 			if !batchBlueprint.AddPayout(payout) {
 				return nil, constants.ErrPayoutDidNotFitTheBatch
 			}
@@ -60,9 +60,16 @@ func SplitIntoBatches(ctx *PayoutExecutionContext, options *common.ExecutePayout
 	}
 	toBatch = append(toBatch, classicTezRecipes)
 
+	// costs of the multisig wrapper, transfers inside of the lambda are accounted in payout limits
+	overhead := common.BatchOverhead{}
+	if transactor, ok := ctx.GetTransactor().(common.BatchOverheadTransactor); ok {
+		if overhead, err = transactor.GetBatchOverhead(); err != nil {
+			return nil, err
+		}
+	}
 	stageBatches := make([]common.RecipeBatch, 0)
 	for _, batch := range toBatch {
-		batches, err := splitIntoBatches(batch, ctx.StageData.Limits, ctx.BatchMetadataDeserializationGasLimit)
+		batches, err := splitIntoBatches(batch, ctx.StageData.Limits, ctx.BatchMetadataDeserializationGasLimit, overhead)
 		if err != nil {
 			return nil, err
 		}
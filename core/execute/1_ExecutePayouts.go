@@ -9,18 +9,26 @@ import (
 	"github.com/samber/lo"
 	"github.com/tez-capital/tezpay/common"
 	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/metrics"
 	"github.com/tez-capital/tezpay/state"
 	"github.com/tez-capital/tezpay/utils"
 )
 
-func buildBatchExecutionContext(ctx *PayoutExecutionContext, logger *slog.Logger, batchId string, batch common.RecipeBatch) (*common.OpExecutionContext, error) {
+func buildBatchExecutionContext(ctx *PayoutExecutionContext, logger *slog.Logger, transactor common.TransactorEngine, batchIndex int, batchId string, batch common.RecipeBatch) (*common.OpExecutionContext, error) {
 	logger = logger.With("batch_id", batchId)
 	if state.Global.GetWantsOutputJson() {
 		logger.Info("creating batch", "recipes", batch, "phase", "executing_batch")
 	} else {
 		logger.Info("creating batch", "tx_count", len(batch), "phase", "executing_batch")
 	}
-	return batch.ToOpExecutionContext(ctx.GetSigner(), ctx.GetTransactor())
+	if ctx.StageData.SignedOps != nil {
+		return common.InitOpExecutionContext(ctx.StageData.SignedOps[batchIndex], transactor, batch), nil
+	}
+	opExecCtx, err := batch.ToOpExecutionContext(ctx.GetSigner(), transactor)
+	if err != nil {
+		return nil, err
+	}
+	return opExecCtx.WithFeeBumping(ctx.GetSigner(), ctx.GetConfiguration().PayoutConfiguration.FeeBumping), nil
 }
 
 func druRunExecuteBatch(ctx *PayoutExecutionContext, logger *slog.Logger, batchId string, opExecCtx *common.OpExecutionContext) *common.BatchResult {
@@ -32,67 +40,156 @@ func druRunExecuteBatch(ctx *PayoutExecutionContext, logger *slog.Logger, batchI
 	return opExecCtx.AsSuccessBatchResult()
 }
 
-func executeBatch(ctx *PayoutExecutionContext, logger *slog.Logger, batchId string, opExecCtx *common.OpExecutionContext) *common.BatchResult {
+// dispatchBatch returns failed result if the batch could not be broadcasted
+func dispatchBatch(logger *slog.Logger, opExecCtx *common.OpExecutionContext) *common.BatchResult {
 	logger.Info("broadcasting batch")
 	err := opExecCtx.Dispatch(nil)
 	if err != nil {
 		logger.Warn("failed to broadcast batch", "error", err.Error(), "phase", "batch_execution_finished")
-		return opExecCtx.AsFailedBatchResult(errors.Join(constants.ErrOperationBroadcastFailed, err))
+		metrics.BatchesFailed.WithLabelValues("broadcast").Inc()
+		result := opExecCtx.AsFailedBatchResult(errors.Join(constants.ErrOperationBroadcastFailed, err))
+		// operation may reach mempool even if broadcast failed, hash of the signed operation is kept to look it up
+		result.OpHash = opExecCtx.Op.Hash()
+		return result
+	}
+	metrics.BatchesDispatched.Inc()
+	return nil
+}
+
+// waitForBatchInclusion returns failed result if the batch was not included in a block
+func waitForBatchInclusion(logger *slog.Logger, opExecCtx *common.OpExecutionContext) *common.BatchResult {
+	logger.Debug("waiting for inclusion", "op_hash", opExecCtx.GetOpHash())
+	if err := opExecCtx.WaitForInclusion(); err != nil {
+		logger.Warn("batch was not included", "error", err.Error(), "phase", "batch_execution_finished")
+		metrics.BatchesFailed.WithLabelValues("confirmation").Inc()
+		return opExecCtx.AsFailedBatchResult(errors.Join(constants.ErrOperationConfirmationFailed, err))
 	}
+	return nil
+}
 
+func confirmBatch(ctx *PayoutExecutionContext, logger *slog.Logger, opExecCtx *common.OpExecutionContext) *common.BatchResult {
 	logger.Info("waiting for confirmation", "op_reference", utils.GetOpReference(opExecCtx.GetOpHash(), ctx.GetConfiguration().Network.Explorer), "op_hash", opExecCtx.GetOpHash(), "phase", "batch_waiting_for_confirmation")
-	ctx.protectedSection.Pause() // pause protected section to allow confirmation canceling
-	err = opExecCtx.WaitForApply()
-	ctx.protectedSection.Resume() // resume protected section
+	err := opExecCtx.WaitForApply()
 	if err != nil {
 		logger.Warn("failed to apply batch", "error", err.Error(), "phase", "batch_execution_finished")
+		metrics.BatchesFailed.WithLabelValues("confirmation").Inc()
 		return opExecCtx.AsFailedBatchResult(errors.Join(constants.ErrOperationConfirmationFailed, err))
 	}
 
 	logger.Info("batch successful", "phase", "batch_execution_finished")
+	metrics.BatchesConfirmed.Inc()
 	return opExecCtx.AsSuccessBatchResult()
 }
 
+func getMaxBatchesInFlight(ctx *PayoutExecutionContext) int {
+	payoutConfiguration := ctx.GetConfiguration().PayoutConfiguration
+	maxInFlight := payoutConfiguration.MaxBatchesInFlight
+	if maxInFlight > 1 && payoutConfiguration.Multisig != nil {
+		ctx.logger.Warn("multisig batches can not be dispatched in parallel, multisig counter is consumed by each batch", "max_batches_in_flight", maxInFlight)
+		return 1
+	}
+	return max(maxInFlight, 1)
+}
+
+// canRecoverBatches reports whether failed batches can be split and signed again,
+// signed operations can not be changed and multisig wraps whole batch so payouts can not be simulated separately
+func canRecoverBatches(ctx *PayoutExecutionContext, options *common.ExecutePayoutsOptions) bool {
+	return !options.DryRun && ctx.StageData.SignedOps == nil && ctx.GetConfiguration().PayoutConfiguration.Multisig == nil
+}
+
 func executePayouts(ctx *PayoutExecutionContext, options *common.ExecutePayoutsOptions) *PayoutExecutionContext {
 	logger := ctx.logger
 	batchCount := len(ctx.StageData.Batches)
-	batchesResults := make(common.BatchResults, 0)
+	maxInFlight := getMaxBatchesInFlight(ctx)
+	tracker := newBatchTracker(batchCount, maxInFlight)
+	ctx.batchApplied = newBatchAppliedNotifier(logger, options.DryRun)
+	tracker.onFinish = func(index int, result *common.BatchResult) {
+		ctx.batchApplied.notify(fmt.Sprintf("%d/%d", index+1, batchCount), result)
+	}
+
+	transactor := ctx.GetTransactor()
+	var counterTracker *counterTrackingTransactor
+	// signed operations have their counters assigned already
+	if maxInFlight > 1 && ctx.StageData.SignedOps == nil {
+		counterTracker = newCounterTrackingTransactor(transactor)
+		transactor = counterTracker
+	}
 
 	ctx.protectedSection.Start()
-	logger.Info("paying out", "batches_count", batchCount, "phase", "batch_execution_start")
+	logger.Info("paying out", "batches_count", batchCount, "max_batches_in_flight", maxInFlight, "phase", "batch_execution_start")
 	reporter := ctx.GetReporter()
 	for i, batch := range ctx.StageData.Batches {
-		if err := reporter.ReportPayouts(append(batchesResults.ToIndividualReports(), ctx.StageData.ReportsOfPastSuccesfulPayouts...)); err != nil {
+		// pause protected section to allow confirmation canceling
+		ctx.protectedSection.Pause()
+		tracker.acquire()
+		if tracker.takeFailure() && counterTracker != nil {
+			// counters of batches dispatched after the failed one may be invalid, start over from chain state
+			logger.Info("batch in flight failed, waiting for remaining batches in flight before continuing")
+			tracker.waitForBatchesInFlight()
+			counterTracker.Reset()
+		}
+		ctx.protectedSection.Resume()
+
+		if err := reporter.ReportPayouts(append(tracker.reports(), ctx.StageData.ReportsOfPastSuccesfulPayouts...)); err != nil {
 			logger.Warn("failed to write partial report of payouts", "error", err.Error())
 		}
 
 		if ctx.protectedSection.Signaled() {
-			batchesResults = append(batchesResults, common.NewFailedBatchResult(batch, constants.ErrExecutePayoutsUserTerminated))
+			tracker.finish(i, common.NewFailedBatchResult(batch, constants.ErrExecutePayoutsUserTerminated))
 			ctx.AdminNotify("Payouts execution terminated by user")
 			continue
 		}
 
 		batchId := fmt.Sprintf("%d/%d", i+1, batchCount)
-		batchExecutionContext, err := buildBatchExecutionContext(ctx, logger, batchId, batch)
+		batchLogger := logger.With("batch_id", batchId)
+		batchExecutionContext, err := buildBatchExecutionContext(ctx, logger, transactor, i, batchId, batch)
 		if err != nil {
 			logger.Warn("failed to create operation execution context", "error", err.Error(), "phase", "batch_execution_finished")
-			batchesResults = append(batchesResults, common.NewFailedBatchResult(batch, errors.Join(constants.ErrOperationContextCreationFailed, err)))
+			metrics.BatchesFailed.WithLabelValues("creation").Inc()
+			tracker.finish(i, common.NewFailedBatchResult(batch, errors.Join(constants.ErrOperationContextCreationFailed, err)))
 			continue
 		}
 
-		tmpReport := append(batchesResults.ToIndividualReports(), ctx.StageData.ReportsOfPastSuccesfulPayouts...)
 		// we record payouts as "in progress" before execution to avoid double spending in case of crash
-		tmpReport = append(tmpReport, batchExecutionContext.AsFailedBatchResult(constants.ErrPayoutRecordedBeforeExecution).ToIndividualReports()...)
-		if err := reporter.ReportPayouts(tmpReport); err != nil {
-			batchesResults = append(batchesResults, batchExecutionContext.AsFailedBatchResult(errors.Join(constants.ErrFailedToRecordPayoutsBeforeExecution, err)))
+		tracker.start(i, batchExecutionContext)
+		if err := reporter.ReportPayouts(append(tracker.reports(), ctx.StageData.ReportsOfPastSuccesfulPayouts...)); err != nil {
+			tracker.finish(i, batchExecutionContext.AsFailedBatchResult(errors.Join(constants.ErrFailedToRecordPayoutsBeforeExecution, err)))
+			metrics.BatchesFailed.WithLabelValues("report").Inc()
 			continue
 		}
 
 		if options.DryRun {
-			batchesResults = append(batchesResults, druRunExecuteBatch(ctx, logger, batchId, batchExecutionContext))
-		} else {
-			batchesResults = append(batchesResults, executeBatch(ctx, logger, batchId, batchExecutionContext))
+			go func() {
+				tracker.finish(i, druRunExecuteBatch(ctx, batchLogger, batchId, batchExecutionContext))
+			}()
+			continue
+		}
+		if result := dispatchBatch(batchLogger, batchExecutionContext); result != nil {
+			tracker.finish(i, result)
+			continue
 		}
+		if maxInFlight > 1 && i < batchCount-1 {
+			// mempool accepts only one manager operation per source, next batch is injected after this one is included
+			ctx.protectedSection.Pause()
+			result := waitForBatchInclusion(batchLogger, batchExecutionContext)
+			ctx.protectedSection.Resume()
+			if result != nil {
+				tracker.finish(i, result)
+				continue
+			}
+		}
+		go func() {
+			tracker.finish(i, confirmBatch(ctx, batchLogger, batchExecutionContext))
+		}()
+	}
+	ctx.protectedSection.Pause()
+	tracker.waitForBatchesInFlight()
+	ctx.protectedSection.Resume()
+	batchesResults := tracker.getResults()
+	if canRecoverBatches(ctx, options) {
+		var invalidPayouts []common.PayoutRecipe
+		batchesResults, invalidPayouts = newBatchRecovery(ctx, logger, ctx.GetTransactor()).run(batchesResults)
+		ctx.InvalidPayouts = append(ctx.InvalidPayouts, invalidPayouts...)
 	}
 
 	failureDetected := false
@@ -126,6 +223,8 @@ func executePayouts(ctx *PayoutExecutionContext, options *common.ExecutePayoutsO
 	ctx.StageData.Summary = *summary
 	ctx.protectedSection.Stop()
 
+	executeAfterExecutionHooks(ctx, options, invalidReports)
+
 	return ctx
 }
 
@@ -0,0 +1,132 @@
+package execute
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/tez-capital/tezpay/utils"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/rpc"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// mempoolTransactor rejects operations of the source while its previous operation is not included
+type mempoolTransactor struct {
+	common.TransactorEngine
+	pending    *mempoolOpResult
+	dispatched int
+	rejected   int
+	mtx        sync.Mutex
+}
+
+func (transactor *mempoolTransactor) Complete(op *codec.Op, key tezos.Key) error {
+	op.WithBranch(tezos.MustParseBlockHash("BM4VEjb3EGdgNgJhwfVUsUqPYvZWJUHdmKKgabuDkwy6SmUKDve"))
+	return nil
+}
+
+func (transactor *mempoolTransactor) Dispatch(op *codec.Op, opts *rpc.CallOptions) (common.OpResult, error) {
+	transactor.mtx.Lock()
+	defer transactor.mtx.Unlock()
+	if transactor.pending != nil {
+		transactor.rejected++
+		return nil, errors.New("only one manager operation per manager per block allowed")
+	}
+	transactor.dispatched++
+	transactor.pending = &mempoolOpResult{transactor: transactor, hash: op.Hash(), included: make(chan struct{})}
+	return transactor.pending, nil
+}
+
+func (transactor *mempoolTransactor) include(result *mempoolOpResult) {
+	transactor.mtx.Lock()
+	defer transactor.mtx.Unlock()
+	if transactor.pending == result {
+		transactor.pending = nil
+		close(result.included)
+	}
+}
+
+type mempoolOpResult struct {
+	transactor *mempoolTransactor
+	hash       tezos.OpHash
+	included   chan struct{}
+}
+
+func (result *mempoolOpResult) GetOpHash() tezos.OpHash  { return result.hash }
+func (result *mempoolOpResult) GetInjectionLevel() int64 { return 0 }
+func (result *mempoolOpResult) WaitForInclusion(level int64) (bool, error) {
+	result.transactor.include(result)
+	return true, nil
+}
+
+// WaitForApply waits for a block to include the operation
+func (result *mempoolOpResult) WaitForApply() error {
+	select {
+	case <-result.included:
+	case <-time.After(100 * time.Millisecond):
+		result.transactor.include(result)
+	}
+	return nil
+}
+func (result *mempoolOpResult) GetStatus() (common.OperationStatus, error) {
+	return common.OPERATION_STATUS_APPLIED, nil
+}
+
+type executeReporter struct {
+	common.ReporterEngine
+}
+
+func (reporter *executeReporter) ReportPayouts(payouts []common.PayoutReport) error { return nil }
+func (reporter *executeReporter) ReportInvalidPayouts(payouts []common.PayoutReport) error {
+	return nil
+}
+func (reporter *executeReporter) ReportCycleSummary(cycle int64, summary common.CyclePayoutSummary) error {
+	return nil
+}
+
+func TestExecutePayoutsInjectsAfterInclusion(t *testing.T) {
+	assert := assert.New(t)
+	assert.Nil(state.Init(t.TempDir(), state.StateInitOptions{}))
+
+	batches := make([]common.RecipeBatch, 3)
+	for i := range batches {
+		recipient := mock.GetRandomAddress()
+		batches[i] = common.RecipeBatch{{
+			Recipient: recipient,
+			TxKind:    enums.PAYOUT_TX_KIND_TEZ,
+			IsValid:   true,
+			OpLimits:  &common.OpLimits{},
+			Recipes:   []*common.PayoutRecipe{{Recipient: recipient, Amount: tezos.NewZ(1), IsValid: true}},
+		}}
+	}
+
+	transactor := &mempoolTransactor{}
+	config := configuration.GetDefaultRuntimeConfiguration()
+	config.PayoutConfiguration.MaxBatchesInFlight = 3
+	ctx := &PayoutExecutionContext{
+		ExecutePayoutsEngineContext: *common.NewExecutePayoutsEngineContext(mock.InitSimpleCollector(), mock.InitSimpleSigner(), transactor, &executeReporter{}, nil),
+		configuration:               &config,
+		protectedSection:            utils.NewProtectedSection("test"),
+		StageData:                   &StageData{Batches: batches},
+		logger:                      slog.Default(),
+	}
+
+	result := executePayouts(ctx, &common.ExecutePayoutsOptions{})
+	assert.Equal(0, transactor.rejected)
+	assert.Equal(3, transactor.dispatched)
+	assert.Len(result.StageData.BatchResults, 3)
+	assert.True(lo.EveryBy(result.StageData.BatchResults, func(result *common.BatchResult) bool { return result.IsSuccess }))
+	assert.Len(ctx.batchApplied.batches, 3)
+	assert.Equal([]string{"1/3", "2/3", "3/3"}, lo.Map(ctx.batchApplied.getBatches(result.StageData.BatchResults), func(data AfterBatchAppliedHookData, _ int) string {
+		return data.BatchId
+	}))
+}
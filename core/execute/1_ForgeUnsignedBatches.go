@@ -0,0 +1,35 @@
+package execute
+
+import (
+	"errors"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+)
+
+// ForgeUnsignedBatches builds operations of all batches to be signed on another host,
+// counters continue across batches because they are applied one after another
+func ForgeUnsignedBatches(ctx *PayoutExecutionContext, options *common.ExecutePayoutsOptions) (*PayoutExecutionContext, error) {
+	logger := ctx.logger.With("phase", "forge_unsigned_batches")
+	logger.Info("forging unsigned batches", "batches_count", len(ctx.StageData.Batches), "branch", ctx.StageData.Branch.String())
+
+	signer := ctx.GetSigner()
+	offlineBatches := make([]*common.OfflineBatch, 0, len(ctx.StageData.Batches))
+	nextCounter := int64(-1)
+	for _, batch := range ctx.StageData.Batches {
+		op, err := batch.ToOp(signer.GetPKH(), signer.GetKey(), ctx.StageData.Branch, ctx.GetTransactor())
+		if err != nil {
+			return nil, errors.Join(constants.ErrOperationContextCreationFailed, err)
+		}
+		for _, content := range op.Contents {
+			if nextCounter < 0 {
+				nextCounter = content.GetCounter()
+			}
+			content.WithCounter(nextCounter)
+			nextCounter++
+		}
+		offlineBatches = append(offlineBatches, common.NewOfflineBatch(op, batch))
+	}
+	ctx.StageData.OfflineBatches = offlineBatches
+	return ctx, nil
+}
@@ -0,0 +1,183 @@
+package execute
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/codec"
+)
+
+// batchRecovery isolates payouts which made their batch fail by bisecting the batch,
+// healthy halves are sent again and payouts failing simulation on their own become invalid
+type batchRecovery struct {
+	ctx        *PayoutExecutionContext
+	logger     *slog.Logger
+	transactor common.TransactorEngine
+	// results of batches which are not recovered, recovered ones are appended as they finish
+	results  common.BatchResults
+	invalid  []common.PayoutRecipe
+	attempts int
+}
+
+func newBatchRecovery(ctx *PayoutExecutionContext, logger *slog.Logger, transactor common.TransactorEngine) *batchRecovery {
+	return &batchRecovery{
+		ctx:        ctx,
+		logger:     logger.With("phase", "batch_recovery"),
+		transactor: transactor,
+		results:    make(common.BatchResults, 0),
+		invalid:    make([]common.PayoutRecipe, 0),
+	}
+}
+
+// isRecoverable checks whether funds of the failed batch surely did not leave the payout wallet
+func (recovery *batchRecovery) isRecoverable(result *common.BatchResult) bool {
+	if result.IsSuccess || len(result.Payouts) == 0 {
+		return false
+	}
+	isBroadcastFailure := errors.Is(result.Err, constants.ErrOperationBroadcastFailed)
+	if (!isBroadcastFailure && !errors.Is(result.Err, constants.ErrOperationConfirmationFailed)) || !result.OpHash.IsValid() {
+		return false
+	}
+	status, err := recovery.ctx.GetCollector().WasOperationApplied(result.OpHash)
+	if err != nil {
+		recovery.logger.Warn("failed to check status of failed batch", "op_hash", result.OpHash, "error", err.Error())
+		return false
+	}
+	if status == common.OPERATION_STATUS_FAILED {
+		return true
+	}
+	if !isBroadcastFailure || status == common.OPERATION_STATUS_APPLIED {
+		return false
+	}
+	// operation which failed to broadcast could still reach mempool of some node
+	mempool, ok := recovery.transactor.(common.MempoolTransactor)
+	if !ok {
+		return false
+	}
+	pending, err := mempool.IsInMempool(result.OpHash)
+	if err != nil {
+		recovery.logger.Warn("failed to check mempool for failed batch", "op_hash", result.OpHash, "error", err.Error())
+		return false
+	}
+	return !pending
+}
+
+func (recovery *batchRecovery) simulate(batch common.RecipeBatch) error {
+	key := recovery.ctx.GetSigner().GetKey()
+	op := codec.NewOp().WithSource(key.Address())
+	op.WithTTL(constants.MAX_OPERATION_TTL)
+	for _, p := range batch {
+		if err := common.InjectTransferContents(op, key.Address(), p); err != nil {
+			return err
+		}
+	}
+	receipt, err := recovery.ctx.GetCollector().Simulate(op, key)
+	if err != nil {
+		return err
+	}
+	if !receipt.IsSuccess() {
+		return receipt.Error()
+	}
+	return nil
+}
+
+func (recovery *batchRecovery) report(pending *common.OpExecutionContext) error {
+	reports := append(recovery.results.ToIndividualReports(), recovery.ctx.StageData.ReportsOfPastSuccesfulPayouts...)
+	if pending != nil {
+		reports = append(reports, pending.AsFailedBatchResult(constants.ErrPayoutRecordedBeforeExecution).ToIndividualReports()...)
+	}
+	return recovery.ctx.GetReporter().ReportPayouts(reports)
+}
+
+func (recovery *batchRecovery) finish(batchId string, result *common.BatchResult) {
+	recovery.results = append(recovery.results, result)
+	recovery.ctx.batchApplied.notify(batchId, result)
+}
+
+func (recovery *batchRecovery) nextBatchId() string {
+	recovery.attempts++
+	return fmt.Sprintf("recovery %d", recovery.attempts)
+}
+
+func (recovery *batchRecovery) execute(batchId string, batch common.RecipeBatch) *common.BatchResult {
+	logger := recovery.logger.With("batch_id", batchId)
+	// recovery runs only for batches signed during execution, signed operations are never looked up
+	opExecCtx, err := buildBatchExecutionContext(recovery.ctx, recovery.logger, recovery.transactor, 0, batchId, batch)
+	if err != nil {
+		return common.NewFailedBatchResult(batch, errors.Join(constants.ErrOperationContextCreationFailed, err))
+	}
+	if err := recovery.report(opExecCtx); err != nil {
+		return opExecCtx.AsFailedBatchResult(errors.Join(constants.ErrFailedToRecordPayoutsBeforeExecution, err))
+	}
+	if result := dispatchBatch(logger, opExecCtx); result != nil {
+		return result
+	}
+	// pause protected section to allow confirmation canceling
+	recovery.ctx.protectedSection.Pause()
+	defer recovery.ctx.protectedSection.Resume()
+	return confirmBatch(recovery.ctx, logger, opExecCtx)
+}
+
+func (recovery *batchRecovery) bisect(batch common.RecipeBatch) {
+	half := len(batch) / 2
+	recovery.recover(batch[:half])
+	recovery.recover(batch[half:])
+}
+
+func (recovery *batchRecovery) recover(batch common.RecipeBatch) {
+	if recovery.ctx.protectedSection.Signaled() {
+		recovery.finish(recovery.nextBatchId(), common.NewFailedBatchResult(batch, constants.ErrExecutePayoutsUserTerminated))
+		return
+	}
+
+	if err := recovery.simulate(batch); err != nil {
+		if len(batch) > 1 {
+			recovery.bisect(batch)
+			return
+		}
+		payout := batch[0]
+		recovery.logger.Warn("payout failed simulation, marking it invalid", "recipient", payout.Recipient.String(), "error", err.Error())
+		payout.IsValid = false
+		payout.Note = err.Error()
+		recovery.invalid = append(recovery.invalid, payout.DisperseToInvalid()...)
+		return
+	}
+
+	batchId := recovery.nextBatchId()
+	result := recovery.execute(batchId, batch)
+	if len(batch) > 1 && recovery.isRecoverable(result) {
+		recovery.bisect(batch)
+		return
+	}
+	recovery.finish(batchId, result)
+}
+
+// run recovers failed batches of results, results of other batches are kept as they are
+func (recovery *batchRecovery) run(results common.BatchResults) (common.BatchResults, []common.PayoutRecipe) {
+	failed := make([]*common.BatchResult, 0)
+	for _, result := range results {
+		if recovery.isRecoverable(result) {
+			failed = append(failed, result)
+			continue
+		}
+		recovery.results = append(recovery.results, result)
+	}
+	if len(failed) == 0 {
+		return results, recovery.invalid
+	}
+
+	recovery.logger.Info("recovering failed batches", "batches_count", len(failed))
+	for _, result := range failed {
+		recovery.logger.Info("bisecting failed batch", "op_hash", result.OpHash, "tx_count", len(result.Payouts), "error", result.Err.Error())
+		batch := common.RecipeBatch(result.Payouts)
+		if len(batch) > 1 {
+			recovery.bisect(batch)
+			continue
+		}
+		recovery.recover(batch)
+	}
+	return recovery.results, recovery.invalid
+}
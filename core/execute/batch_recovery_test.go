@@ -0,0 +1,189 @@
+package execute
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/tez-capital/tezpay/utils"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/rpc"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// failingRecipients fails any operation containing transfer to one of the recipients
+type failingRecipients []tezos.Address
+
+func (recipients failingRecipients) check(op *codec.Op) error {
+	for _, content := range op.Contents {
+		if tx, ok := content.(*codec.Transaction); ok && lo.ContainsBy(recipients, func(a tezos.Address) bool { return a.Equal(tx.Destination) }) {
+			return errors.New("script_rejected")
+		}
+	}
+	return nil
+}
+
+type recoveryCollector struct {
+	common.CollectorEngine
+	failing failingRecipients
+	// operations found on chain, others are reported failed
+	statuses map[tezos.OpHash]common.OperationStatus
+}
+
+func (collector *recoveryCollector) Simulate(op *codec.Op, key tezos.Key) (*rpc.Receipt, error) {
+	if err := collector.failing.check(op); err != nil {
+		return nil, err
+	}
+	return &rpc.Receipt{Op: &rpc.Operation{}}, nil
+}
+
+func (collector *recoveryCollector) WasOperationApplied(opHash tezos.OpHash) (common.OperationStatus, error) {
+	if status, ok := collector.statuses[opHash]; ok {
+		return status, nil
+	}
+	return common.OPERATION_STATUS_FAILED, nil
+}
+
+type recoveryOpResult struct {
+	hash tezos.OpHash
+	err  error
+}
+
+func (result *recoveryOpResult) GetOpHash() tezos.OpHash  { return result.hash }
+func (result *recoveryOpResult) GetInjectionLevel() int64 { return 0 }
+func (result *recoveryOpResult) WaitForInclusion(level int64) (bool, error) {
+	return true, nil
+}
+func (result *recoveryOpResult) WaitForApply() error { return result.err }
+func (result *recoveryOpResult) GetStatus() (common.OperationStatus, error) {
+	return common.OPERATION_STATUS_UNKNOWN, nil
+}
+
+type recoveryTransactor struct {
+	common.TransactorEngine
+	failing    failingRecipients
+	dispatched []int
+	mempool    []tezos.OpHash
+}
+
+func (transactor *recoveryTransactor) IsInMempool(opHash tezos.OpHash) (bool, error) {
+	return lo.Contains(transactor.mempool, opHash), nil
+}
+
+func (transactor *recoveryTransactor) Complete(op *codec.Op, key tezos.Key) error {
+	op.WithBranch(tezos.MustParseBlockHash("BM4VEjb3EGdgNgJhwfVUsUqPYvZWJUHdmKKgabuDkwy6SmUKDve"))
+	return nil
+}
+
+func (transactor *recoveryTransactor) Dispatch(op *codec.Op, opts *rpc.CallOptions) (common.OpResult, error) {
+	transactor.dispatched = append(transactor.dispatched, len(op.Contents))
+	return &recoveryOpResult{hash: op.Hash(), err: transactor.failing.check(op)}, nil
+}
+
+type recoveryReporter struct {
+	common.ReporterEngine
+}
+
+func (reporter *recoveryReporter) ReportPayouts(payouts []common.PayoutReport) error {
+	return nil
+}
+
+func newRecoveryBatch(size int) common.RecipeBatch {
+	batch := make(common.RecipeBatch, size)
+	for i := range batch {
+		recipient := mock.GetRandomAddress()
+		batch[i] = &common.AccumulatedPayoutRecipe{
+			Recipient: recipient,
+			TxKind:    enums.PAYOUT_TX_KIND_TEZ,
+			IsValid:   true,
+			OpLimits:  &common.OpLimits{},
+			Recipes:   []*common.PayoutRecipe{{Recipient: recipient, Amount: tezos.NewZ(1), IsValid: true}},
+		}
+	}
+	return batch
+}
+
+func newRecoveryExecutionContext(collector *recoveryCollector, transactor *recoveryTransactor) *PayoutExecutionContext {
+	config := configuration.GetDefaultRuntimeConfiguration()
+	return &PayoutExecutionContext{
+		ExecutePayoutsEngineContext: *common.NewExecutePayoutsEngineContext(collector, mock.InitSimpleSigner(), transactor, &recoveryReporter{}, nil),
+		configuration:               &config,
+		protectedSection:            utils.NewProtectedSection("test"),
+		StageData:                   &StageData{},
+		logger:                      slog.Default(),
+	}
+}
+
+func TestBatchRecovery(t *testing.T) {
+	assert := assert.New(t)
+	assert.Nil(state.Init(t.TempDir(), state.StateInitOptions{}))
+
+	batch := newRecoveryBatch(8)
+	failing := failingRecipients{batch[2].Recipient}
+
+	collector := &recoveryCollector{failing: failing}
+	transactor := &recoveryTransactor{failing: failing}
+	ctx := newRecoveryExecutionContext(collector, transactor)
+
+	succeeded := common.NewSuccessBatchResult(common.RecipeBatch{}, tezos.ZeroOpHash)
+	terminated := common.NewFailedBatchResult(batch[:1], constants.ErrExecutePayoutsUserTerminated)
+	failed := common.NewFailedBatchResultWithOpHash(batch, tezos.MustParseOpHash("onyUK7ZnQHzeNYbWSLL4zVATBtvLLk5GpPDv3VfoQPLtsBCjPX1"), errors.Join(constants.ErrOperationConfirmationFailed, constants.ErrOperationFailed))
+
+	ctx.batchApplied = newBatchAppliedNotifier(ctx.logger, false)
+	results, invalid := newBatchRecovery(ctx, ctx.logger, transactor).run(common.BatchResults{succeeded, terminated, failed})
+	// first half fails simulation and is split until the failing payout is isolated, second half is sent at once
+	assert.Equal([]int{2, 1, 4}, transactor.dispatched)
+	assert.Len(results, 5)
+	assert.Equal(succeeded, results[0])
+	assert.Equal(terminated, results[1])
+	assert.True(lo.EveryBy(results[2:], func(result *common.BatchResult) bool { return result.IsSuccess }))
+	assert.Equal(7, len(lo.Flatten(lo.Map(results[2:], func(result *common.BatchResult, _ int) []*common.AccumulatedPayoutRecipe { return result.Payouts }))))
+	// recovered batches are notified as they finish, their ids are kept for after_payouts_executed
+	assert.Len(ctx.batchApplied.batches, 3)
+	assert.Equal([]string{"1/5", "2/5", "recovery 1", "recovery 2", "recovery 3"}, lo.Map(ctx.batchApplied.getBatches(results), func(data AfterBatchAppliedHookData, _ int) string {
+		return data.BatchId
+	}))
+
+	assert.Len(invalid, 1)
+	assert.Equal(batch[2].Recipient, invalid[0].Recipient)
+	assert.False(invalid[0].IsValid)
+	assert.Equal("script_rejected", invalid[0].Note)
+}
+
+func TestBatchRecoveryBroadcastFailure(t *testing.T) {
+	assert := assert.New(t)
+	assert.Nil(state.Init(t.TempDir(), state.StateInitOptions{}))
+
+	pendingHash := tezos.MustParseOpHash("onyUK7ZnQHzeNYbWSLL4zVATBtvLLk5GpPDv3VfoQPLtsBCjPX1")
+	appliedHash := tezos.MustParseOpHash("oneDGhZacw99EEFaYDTtWfz5QEhUW3PPVFsHa7GShnLPuDn7gSd")
+	lostHash := tezos.OpHash{1}
+	collector := &recoveryCollector{statuses: map[tezos.OpHash]common.OperationStatus{
+		pendingHash: common.OPERATION_STATUS_UNKNOWN,
+		appliedHash: common.OPERATION_STATUS_APPLIED,
+		lostHash:    common.OPERATION_STATUS_NOT_EXISTS,
+	}}
+	transactor := &recoveryTransactor{mempool: []tezos.OpHash{pendingHash}}
+	ctx := newRecoveryExecutionContext(collector, transactor)
+
+	broadcastFailed := errors.Join(constants.ErrOperationBroadcastFailed, errors.New("timeout"))
+	pending := common.NewFailedBatchResultWithOpHash(newRecoveryBatch(2), pendingHash, broadcastFailed)
+	applied := common.NewFailedBatchResultWithOpHash(newRecoveryBatch(2), appliedHash, broadcastFailed)
+	unknown := common.NewFailedBatchResult(newRecoveryBatch(2), broadcastFailed)
+	lost := common.NewFailedBatchResultWithOpHash(newRecoveryBatch(2), lostHash, broadcastFailed)
+
+	results, invalid := newBatchRecovery(ctx, ctx.logger, transactor).run(common.BatchResults{pending, applied, unknown, lost})
+	// only operation which is neither on chain nor in mempool is sent again
+	assert.Equal([]int{1, 1}, transactor.dispatched)
+	assert.Empty(invalid)
+	assert.Len(results, 5)
+	assert.Equal(common.BatchResults{pending, applied, unknown}, results[:3])
+	assert.True(lo.EveryBy(results[3:], func(result *common.BatchResult) bool { return result.IsSuccess }))
+}
@@ -0,0 +1,99 @@
+package execute
+
+import (
+	"sync"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+)
+
+// batchTracker keeps results of batches in their order and limits number of batches in flight
+type batchTracker struct {
+	results  []*common.BatchResult
+	inFlight map[int]*common.OpExecutionContext
+	// set when batch in flight failed, counters of batches dispatched after it may be invalid
+	failed bool
+	slots  chan struct{}
+	// called once result of the batch is final
+	onFinish func(index int, result *common.BatchResult)
+	mtx      sync.Mutex
+	cond     *sync.Cond
+}
+
+func newBatchTracker(batchCount int, maxInFlight int) *batchTracker {
+	tracker := &batchTracker{
+		results:  make([]*common.BatchResult, batchCount),
+		inFlight: make(map[int]*common.OpExecutionContext),
+		slots:    make(chan struct{}, maxInFlight),
+	}
+	tracker.cond = sync.NewCond(&tracker.mtx)
+	return tracker
+}
+
+// acquire blocks until there is a free slot for next batch, each acquire has to be followed by finish
+func (tracker *batchTracker) acquire() {
+	tracker.slots <- struct{}{}
+}
+
+func (tracker *batchTracker) start(index int, opExecCtx *common.OpExecutionContext) {
+	tracker.mtx.Lock()
+	defer tracker.mtx.Unlock()
+	tracker.inFlight[index] = opExecCtx
+}
+
+func (tracker *batchTracker) finish(index int, result *common.BatchResult) {
+	// batch stays in flight until notified so waitForBatchesInFlight covers pending notifications
+	if tracker.onFinish != nil {
+		tracker.onFinish(index, result)
+	}
+	tracker.mtx.Lock()
+	tracker.results[index] = result
+	if _, ok := tracker.inFlight[index]; ok && !result.IsSuccess {
+		tracker.failed = true
+	}
+	delete(tracker.inFlight, index)
+	tracker.cond.Broadcast()
+	tracker.mtx.Unlock()
+	<-tracker.slots
+}
+
+// takeFailure reports whether any batch in flight failed since the last call
+func (tracker *batchTracker) takeFailure() bool {
+	tracker.mtx.Lock()
+	defer tracker.mtx.Unlock()
+	failed := tracker.failed
+	tracker.failed = false
+	return failed
+}
+
+func (tracker *batchTracker) waitForBatchesInFlight() {
+	tracker.mtx.Lock()
+	defer tracker.mtx.Unlock()
+	for len(tracker.inFlight) > 0 {
+		tracker.cond.Wait()
+	}
+}
+
+// reports returns reports of finished batches, batches in flight are recorded as not executed yet
+func (tracker *batchTracker) reports() []common.PayoutReport {
+	tracker.mtx.Lock()
+	defer tracker.mtx.Unlock()
+	result := make([]common.PayoutReport, 0)
+	for index, batchResult := range tracker.results {
+		if opExecCtx, ok := tracker.inFlight[index]; ok {
+			result = append(result, opExecCtx.AsFailedBatchResult(constants.ErrPayoutRecordedBeforeExecution).ToIndividualReports()...)
+			continue
+		}
+		if batchResult != nil {
+			result = append(result, batchResult.ToIndividualReports()...)
+		}
+	}
+	return result
+}
+
+func (tracker *batchTracker) getResults() common.BatchResults {
+	tracker.mtx.Lock()
+	defer tracker.mtx.Unlock()
+	return lo.Filter(tracker.results, func(result *common.BatchResult, _ int) bool { return result != nil })
+}
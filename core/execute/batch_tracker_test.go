@@ -0,0 +1,145 @@
+package execute
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// chainCounterTransactor assigns counters the way rpc does, from the last applied operation
+type chainCounterTransactor struct {
+	common.TransactorEngine
+	counter int64
+}
+
+func (transactor *chainCounterTransactor) Complete(op *codec.Op, key tezos.Key) error {
+	for i, content := range op.Contents {
+		content.WithCounter(transactor.counter + 1 + int64(i))
+	}
+	return nil
+}
+
+func getCounters(op *codec.Op) []int64 {
+	counters := make([]int64, 0, len(op.Contents))
+	for _, content := range op.Contents {
+		counters = append(counters, content.GetCounter())
+	}
+	return counters
+}
+
+func TestCounterTrackingTransactor(t *testing.T) {
+	assert := assert.New(t)
+
+	chain := &chainCounterTransactor{counter: 10}
+	transactor := newCounterTrackingTransactor(chain)
+	key := mock.InitSimpleSigner().GetKey()
+
+	first := codec.NewOp().WithTransfer(mock.GetRandomAddress(), 1).WithTransfer(mock.GetRandomAddress(), 1)
+	assert.Nil(transactor.Complete(first, key))
+	assert.Equal([]int64{11, 12}, getCounters(first))
+
+	// first operation is not applied yet, chain would assign the same counters
+	second := codec.NewOp().WithTransfer(mock.GetRandomAddress(), 1)
+	assert.Nil(transactor.Complete(second, key))
+	assert.Equal([]int64{13}, getCounters(second))
+
+	// first operation was not included, counters start over from chain state
+	transactor.Reset()
+	third := codec.NewOp().WithTransfer(mock.GetRandomAddress(), 1)
+	assert.Nil(transactor.Complete(third, key))
+	assert.Equal([]int64{11}, getCounters(third))
+}
+
+func TestBatchTracker(t *testing.T) {
+	assert := assert.New(t)
+
+	batches := make([]common.RecipeBatch, 3)
+	for i := range batches {
+		batches[i] = common.RecipeBatch{{
+			Recipient: mock.GetRandomAddress(),
+			Recipes:   []*common.PayoutRecipe{{Recipient: mock.GetRandomAddress(), Amount: tezos.NewZ(1)}},
+		}}
+	}
+
+	tracker := newBatchTracker(len(batches), 2)
+	contexts := make([]*common.OpExecutionContext, len(batches))
+	for i, batch := range batches[:2] {
+		tracker.acquire()
+		contexts[i] = common.InitOpExecutionContext(codec.NewOp(), nil, batch)
+		tracker.start(i, contexts[i])
+	}
+
+	// batches in flight are recorded before execution
+	reports := tracker.reports()
+	assert.Len(reports, 2)
+	for _, report := range reports {
+		assert.False(report.IsSuccess)
+		assert.Equal(constants.ErrPayoutRecordedBeforeExecution.Error(), report.Note)
+	}
+
+	// second batch finishes first, results keep order of batches
+	tracker.finish(1, contexts[1].AsSuccessBatchResult())
+	assert.False(tracker.takeFailure())
+	tracker.finish(0, contexts[0].AsFailedBatchResult(errors.New("failed")))
+	assert.True(tracker.takeFailure())
+	assert.False(tracker.takeFailure())
+
+	// batch which was never started does not invalidate counters
+	tracker.acquire()
+	tracker.finish(2, common.NewFailedBatchResult(batches[2], constants.ErrOperationContextCreationFailed))
+	assert.False(tracker.takeFailure())
+
+	tracker.waitForBatchesInFlight()
+	results := tracker.getResults()
+	assert.Len(results, 3)
+	assert.False(results[0].IsSuccess)
+	assert.True(results[1].IsSuccess)
+	assert.Equal(batches[2], common.RecipeBatch(results[2].Payouts))
+}
+
+func TestBatchTrackerWaitsForFinishNotification(t *testing.T) {
+	assert := assert.New(t)
+
+	batch := common.RecipeBatch{{
+		Recipient: mock.GetRandomAddress(),
+		Recipes:   []*common.PayoutRecipe{{Recipient: mock.GetRandomAddress(), Amount: tezos.NewZ(1)}},
+	}}
+	tracker := newBatchTracker(1, 1)
+	notifying := make(chan struct{})
+	release := make(chan struct{})
+	notified := false
+	tracker.onFinish = func(index int, result *common.BatchResult) {
+		close(notifying)
+		<-release
+		notified = true
+	}
+
+	tracker.acquire()
+	opExecCtx := common.InitOpExecutionContext(codec.NewOp(), nil, batch)
+	tracker.start(0, opExecCtx)
+	go tracker.finish(0, opExecCtx.AsSuccessBatchResult())
+	<-notifying
+
+	waited := make(chan struct{})
+	go func() {
+		tracker.waitForBatchesInFlight()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+		assert.Fail("waited for batches in flight before notification finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	<-waited
+	assert.True(notified)
+	assert.Len(tracker.getResults(), 1)
+}
@@ -6,14 +6,20 @@ import (
 	"github.com/tez-capital/tezpay/common"
 	"github.com/tez-capital/tezpay/configuration"
 	"github.com/tez-capital/tezpay/utils"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/tezos"
 )
 
 type StageData struct {
 	Limits                        *common.OperationLimits
 	ReportsOfPastSuccesfulPayouts []common.PayoutReport
 	Batches                       []common.RecipeBatch
-	BatchResults                  common.BatchResults
-	Summary                       common.PayoutSummary
+	// offline signing, branch used to forge batches, forged batches and their signed operations aligned with Batches
+	Branch         tezos.BlockHash
+	OfflineBatches []*common.OfflineBatch
+	SignedOps      []*codec.Op
+	BatchResults   common.BatchResults
+	Summary        common.PayoutSummary
 }
 
 type PayoutExecutionContext struct {
@@ -21,6 +27,7 @@ type PayoutExecutionContext struct {
 	configuration *configuration.RuntimeConfiguration
 
 	protectedSection *utils.ProtectedSection
+	batchApplied     *batchAppliedNotifier
 	StageData        *StageData
 
 	Payouts          []*common.AccumulatedPayoutRecipe
@@ -0,0 +1,45 @@
+package execute
+
+import (
+	"sync"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// counterTrackingTransactor continues counters after operations which are dispatched but not applied yet,
+// chain state does not reflect them so the transactor alone would assign the same counters again
+type counterTrackingTransactor struct {
+	common.TransactorEngine
+	nextCounter int64
+	mtx         sync.Mutex
+}
+
+func newCounterTrackingTransactor(transactor common.TransactorEngine) *counterTrackingTransactor {
+	return &counterTrackingTransactor{
+		TransactorEngine: transactor,
+	}
+}
+
+func (transactor *counterTrackingTransactor) Complete(op *codec.Op, key tezos.Key) error {
+	if err := transactor.TransactorEngine.Complete(op, key); err != nil {
+		return err
+	}
+	transactor.mtx.Lock()
+	defer transactor.mtx.Unlock()
+	for _, content := range op.Contents {
+		if content.GetCounter() < transactor.nextCounter {
+			content.WithCounter(transactor.nextCounter)
+		}
+		transactor.nextCounter = content.GetCounter() + 1
+	}
+	return nil
+}
+
+// Reset makes next operation use counter from chain state again
+func (transactor *counterTrackingTransactor) Reset() {
+	transactor.mtx.Lock()
+	defer transactor.mtx.Unlock()
+	transactor.nextCounter = 0
+}
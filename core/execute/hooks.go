@@ -0,0 +1,109 @@
+package execute
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/extension"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type AfterBatchAppliedHookData struct {
+	BatchId   string                `json:"batch_id"`
+	OpHash    tezos.OpHash          `json:"op_hash"`
+	IsSuccess bool                  `json:"is_success"`
+	Error     string                `json:"error,omitempty"`
+	IsDryRun  bool                  `json:"is_dry_run"`
+	Reports   []common.PayoutReport `json:"reports"`
+}
+
+type AfterPayoutsExecutedHookData struct {
+	Batches        []AfterBatchAppliedHookData `json:"batches"`
+	InvalidPayouts []common.PayoutReport       `json:"invalid_payouts"`
+	Summary        common.PayoutSummary        `json:"summary"`
+	IsDryRun       bool                        `json:"is_dry_run"`
+}
+
+func ExecuteAfterBatchApplied(data *AfterBatchAppliedHookData) error {
+	return extension.ExecuteHook(enums.EXTENSION_HOOK_AFTER_BATCH_APPLIED, "0.1", data)
+}
+
+func ExecuteAfterPayoutsExecuted(data *AfterPayoutsExecutedHookData) error {
+	return extension.ExecuteHook(enums.EXTENSION_HOOK_AFTER_PAYOUTS_EXECUTED, "0.1", data)
+}
+
+func NewAfterBatchAppliedHookData(batchId string, result *common.BatchResult, isDryRun bool) AfterBatchAppliedHookData {
+	data := AfterBatchAppliedHookData{
+		BatchId:   batchId,
+		OpHash:    result.OpHash,
+		IsSuccess: result.IsSuccess,
+		IsDryRun:  isDryRun,
+		Reports:   result.ToIndividualReports(),
+	}
+	if result.Err != nil {
+		data.Error = result.Err.Error()
+	}
+	return data
+}
+
+// batchAppliedNotifier executes after_batch_applied hook as soon as result of a batch is final,
+// hook data are kept by result to pass them to after_payouts_executed with the same batch ids
+type batchAppliedNotifier struct {
+	logger   *slog.Logger
+	isDryRun bool
+	batches  map[*common.BatchResult]AfterBatchAppliedHookData
+	mtx      sync.Mutex
+}
+
+func newBatchAppliedNotifier(logger *slog.Logger, isDryRun bool) *batchAppliedNotifier {
+	return &batchAppliedNotifier{
+		logger:   logger.With("phase", "after_batch_applied_hook"),
+		isDryRun: isDryRun,
+		batches:  make(map[*common.BatchResult]AfterBatchAppliedHookData),
+	}
+}
+
+// notify is no-op on nil notifier, payouts are already sent so failures are only logged
+func (notifier *batchAppliedNotifier) notify(batchId string, result *common.BatchResult) {
+	if notifier == nil {
+		return
+	}
+	notifier.mtx.Lock()
+	defer notifier.mtx.Unlock()
+	data := NewAfterBatchAppliedHookData(batchId, result, notifier.isDryRun)
+	notifier.batches[result] = data
+	if err := ExecuteAfterBatchApplied(&data); err != nil {
+		notifier.logger.Warn("failed to execute after batch applied hook", "batch_id", batchId, "error", err.Error())
+	}
+}
+
+// getBatches returns hook data of the results, results which were not notified get their position as batch id
+func (notifier *batchAppliedNotifier) getBatches(results common.BatchResults) []AfterBatchAppliedHookData {
+	notifier.mtx.Lock()
+	defer notifier.mtx.Unlock()
+	return lo.Map(results, func(result *common.BatchResult, i int) AfterBatchAppliedHookData {
+		if data, ok := notifier.batches[result]; ok {
+			return data
+		}
+		return NewAfterBatchAppliedHookData(fmt.Sprintf("%d/%d", i+1, len(results)), result, notifier.isDryRun)
+	})
+}
+
+// executeAfterExecutionHooks notifies extensions about sent payouts,
+// payouts are already sent so failures are only logged
+func executeAfterExecutionHooks(ctx *PayoutExecutionContext, options *common.ExecutePayoutsOptions, invalidReports []common.PayoutReport) {
+	logger := ctx.logger.With("phase", "after_execution_hooks")
+	err := ExecuteAfterPayoutsExecuted(&AfterPayoutsExecutedHookData{
+		Batches:        ctx.batchApplied.getBatches(ctx.StageData.BatchResults),
+		InvalidPayouts: invalidReports,
+		Summary:        ctx.StageData.Summary,
+		IsDryRun:       options.DryRun,
+	})
+	if err != nil {
+		logger.Warn("failed to execute after payouts executed hook", "error", err.Error())
+	}
+}
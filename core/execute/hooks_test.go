@@ -0,0 +1,40 @@
+package execute
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func TestNewAfterBatchAppliedHookData(t *testing.T) {
+	assert := assert.New(t)
+
+	delegator := mock.GetRandomAddress()
+	payout := &common.AccumulatedPayoutRecipe{
+		Delegator: delegator,
+		Recipient: delegator,
+		Recipes: []*common.PayoutRecipe{
+			{Delegator: delegator, Recipient: delegator, Cycle: 1, Kind: enums.PAYOUT_KIND_DELEGATOR_REWARD, Amount: tezos.NewZ(100)},
+			{Delegator: delegator, Recipient: delegator, Cycle: 2, Kind: enums.PAYOUT_KIND_DELEGATOR_REWARD, Amount: tezos.NewZ(200)},
+		},
+	}
+
+	success := NewAfterBatchAppliedHookData("1/2", common.NewSuccessBatchResult([]*common.AccumulatedPayoutRecipe{payout}, tezos.ZeroOpHash), true)
+	assert.True(success.IsSuccess)
+	assert.True(success.IsDryRun)
+	assert.Empty(success.Error)
+	assert.Len(success.Reports, 2)
+
+	failed := NewAfterBatchAppliedHookData("2/2", common.NewFailedBatchResult([]*common.AccumulatedPayoutRecipe{payout}, constants.ErrOperationConfirmationFailed), false)
+	assert.False(failed.IsSuccess)
+	assert.Equal(constants.ErrOperationConfirmationFailed.Error(), failed.Error)
+	for _, report := range failed.Reports {
+		assert.False(report.IsSuccess)
+		assert.Equal(failed.Error, report.Note)
+	}
+}
@@ -27,6 +27,7 @@ func GeneratePayouts(config *configuration.RuntimeConfiguration, engineContext *
 		generate.CheckConditionsAndPrepare,
 		generate.GeneratePayoutCandidates,
 		generate.DistributeBonds,
+		generate.DistributeStakingRewards,
 		generate.CollectBakerFee,
 		generate.ValidateRecipe,
 		generate.FinalizeRecipes,
@@ -20,6 +20,15 @@ func ExecuteAfterCandidateGenerated(data *AfterCandidateGeneratedHookData) error
 	return extension.ExecuteHook(enums.EXTENSION_HOOK_AFTER_CANDIDATES_GENERATED, "0.2", data)
 }
 
+type AfterCycleDataCollectedHookData struct {
+	Cycle     int64                   `json:"cycle"`
+	CycleData *common.BakersCycleData `json:"cycle_data"`
+}
+
+func ExecuteAfterCycleDataCollected(data *AfterCycleDataCollectedHookData) error {
+	return extension.ExecuteHook(enums.EXTENSION_HOOK_AFTER_CYCLE_DATA_COLLECTED, "0.1", data)
+}
+
 func GeneratePayoutCandidates(ctx *PayoutGenerationContext, options *common.GeneratePayoutsOptions) (*PayoutGenerationContext, error) {
 	configuration := ctx.GetConfiguration()
 	logger := ctx.logger.With("phase", "generate_payout_candidates")
@@ -40,9 +49,21 @@ func GeneratePayoutCandidates(ctx *PayoutGenerationContext, options *common.Gene
 		return ctx, errors.Join(constants.ErrCycleDataCollectionFailed, fmt.Errorf("collector: %s", ctx.GetCollector().GetId()), err)
 	}
 
+	cycleDataHookData := &AfterCycleDataCollectedHookData{
+		Cycle:     options.Cycle,
+		CycleData: ctx.StageData.CycleData,
+	}
+	if err = ExecuteAfterCycleDataCollected(cycleDataHookData); err != nil {
+		return ctx, err
+	}
+	if err = cycleDataHookData.CycleData.Validate(); err != nil {
+		return ctx, err
+	}
+	ctx.StageData.CycleData = cycleDataHookData.CycleData
+
 	logger.Debug("generating payout candidates")
 	payoutCandidates := lo.Map(ctx.StageData.CycleData.Delegators, func(delegator common.Delegator, _ int) PayoutCandidate {
-		payoutCandidate := DelegatorToPayoutCandidate(delegator, configuration)
+		payoutCandidate := DelegatorToPayoutCandidate(delegator, configuration, options.Cycle)
 		validationContext := payoutCandidate.ToValidationContext(ctx)
 		return *validationContext.Validate(
 			IsIgnoredValidator,
@@ -56,6 +77,14 @@ func GeneratePayoutCandidates(ctx *PayoutGenerationContext, options *common.Gene
 		).ToPayoutCandidate()
 	})
 
+	if len(configuration.Delegators.Loyalty) > 0 {
+		logger.Debug("applying loyalty discounts")
+		history := newLoyaltyHistory(ctx, options.Cycle)
+		for i := range payoutCandidates {
+			applyLoyaltyDiscount(&payoutCandidates[i], ctx, history)
+		}
+	}
+
 	hookData := &AfterCandidateGeneratedHookData{
 		Cycle:      options.Cycle,
 		Candidates: payoutCandidates,
@@ -0,0 +1,111 @@
+package generate
+
+import (
+	"fmt"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/utils"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func stakerToStakingPayout(delegator common.Delegator, stakerEdge tezos.Z, stakerShared tezos.Z, ctx *PayoutGenerationContext, options *common.GeneratePayoutsOptions) (common.PayoutRecipe, bool) {
+	configuration := ctx.GetConfiguration()
+	feeRate, ok := configuration.GetStakingFee(delegator.Address)
+	if !ok {
+		return common.PayoutRecipe{}, false
+	}
+
+	candidate := DelegatorToPayoutCandidate(delegator, configuration, options.Cycle)
+	validationContext := candidate.ToValidationContext(ctx)
+	validationContext.Validate(
+		IsIgnoredValidator,
+		IsPrefilteredValidator,
+		RecipientValidator,
+		IgnoreKtValidator,
+		RecipientNotBaker,
+		NotExcludedByAddressPrefix,
+	)
+	if candidate.IsInvalid && candidate.InvalidBecause == enums.INVALID_DELEGATOR_IGNORED {
+		return common.PayoutRecipe{}, false
+	}
+
+	// the baker can not charge more than the protocol edge, the rest of the edge is paid back to the staker
+	fee := utils.GetZPortion(stakerEdge.Add(stakerShared), feeRate)
+	if stakerEdge.IsLess(fee) {
+		fee = stakerEdge
+	}
+	amount := stakerEdge.Sub(fee)
+	utils.AssertZAmountPositiveOrZero(amount)
+	// nothing is owed when the fee takes the whole edge, such recipes would be reported every cycle
+	if amount.IsZero() {
+		return common.PayoutRecipe{}, false
+	}
+
+	recipe := common.PayoutRecipe{
+		Baker:         configuration.BakerPKH,
+		Delegator:     candidate.Source,
+		Cycle:         options.Cycle,
+		Recipient:     candidate.Recipient,
+		Kind:          enums.PAYOUT_KIND_STAKING_REWARD,
+		TxKind:        enums.PAYOUT_TX_KIND_TEZ,
+		StakedBalance: delegator.StakedBalance,
+		Amount:        amount,
+		FeeRate:       feeRate,
+		Fee:           fee,
+		IsValid:       true,
+	}
+	if !stakerShared.IsZero() {
+		recipe.Note = fmt.Sprintf("protocol paid %s directly", common.MutezToTezS(stakerShared.Int64()))
+	}
+	if candidate.IsInvalid {
+		recipe.IsValid = false
+		recipe.Note = string(candidate.InvalidBecause)
+	}
+	return recipe, true
+}
+
+// DistributeStakingRewards pays back part of the edge collected from external stakers
+// when the configured staking fee is lower than the protocol edge
+func DistributeStakingRewards(ctx *PayoutGenerationContext, options *common.GeneratePayoutsOptions) (*PayoutGenerationContext, error) {
+	logger := ctx.logger.With("phase", "distribute_staking_rewards")
+	logger.Debug("distributing staking rewards")
+
+	cycleData := ctx.StageData.CycleData
+	stakingRewardsEdge := cycleData.GetStakingRewardsEdge()
+	stakingRewardsShared := cycleData.GetStakingRewardsShared()
+	totalStakedBalance := lo.Reduce(cycleData.Delegators, func(total tezos.Z, delegator common.Delegator, _ int) tezos.Z {
+		return total.Add(delegator.StakedBalance)
+	}, tezos.Zero)
+
+	payouts := make([]common.PayoutRecipe, 0, cycleData.StakersCount)
+	if totalStakedBalance.IsZero() {
+		logger.Debug("no external stakers")
+	} else {
+		for _, delegator := range cycleData.Delegators {
+			if delegator.StakedBalance.IsZero() {
+				continue
+			}
+			stakerEdge := stakingRewardsEdge.Mul(delegator.StakedBalance).Div(totalStakedBalance)
+			stakerShared := stakingRewardsShared.Mul(delegator.StakedBalance).Div(totalStakedBalance)
+			if recipe, ok := stakerToStakingPayout(delegator, stakerEdge, stakerShared, ctx, options); ok {
+				payouts = append(payouts, recipe)
+			}
+		}
+	}
+
+	distributed := lo.Reduce(payouts, func(total tezos.Z, recipe common.PayoutRecipe, _ int) tezos.Z {
+		if !recipe.IsValid {
+			return total
+		}
+		return total.Add(recipe.Amount)
+	}, tezos.Zero)
+
+	ctx.StageData.StakingPayouts = payouts
+	ctx.StageData.StakingRewardsEdge = stakingRewardsEdge
+	ctx.StageData.StakingRewardsShared = stakingRewardsShared
+	ctx.StageData.StakingFeesAmount = stakingRewardsEdge.Sub(distributed)
+	utils.AssertZAmountPositiveOrZero(ctx.StageData.StakingFeesAmount)
+	return ctx, nil
+}
@@ -0,0 +1,86 @@
+package generate
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func TestDistributeStakingRewards(t *testing.T) {
+	assert := assert.New(t)
+	state.Init(t.TempDir(), state.StateInitOptions{})
+
+	staker := mock.GetRandomAddress()
+	overridden := mock.GetRandomAddress()
+	ignored := mock.GetRandomAddress()
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	stakingFee, overrideStakingFee := .05, .2
+	config.PayoutConfiguration.StakingFee = &stakingFee
+	config.Delegators.Overrides = map[string]configuration.RuntimeDelegatorOverride{
+		overridden.String(): {StakingFee: &overrideStakingFee},
+	}
+	config.Delegators.Ignore = []tezos.Address{ignored}
+
+	ctx := &PayoutGenerationContext{
+		GeneratePayoutsEngineContext: *common.NewGeneratePayoutsEngines(mock.InitSimpleCollector(), nil, nil),
+		configuration:                &config,
+		StageData: &StageData{
+			CycleData: &common.BakersCycleData{
+				BlockStakingRewardsEdge:         tezos.NewZ(400),
+				AttestationStakingRewardsEdge:   tezos.NewZ(600),
+				BlockStakingRewardsShared:       tezos.NewZ(3600),
+				AttestationStakingRewardsShared: tezos.NewZ(5400),
+				Delegators: []common.Delegator{
+					{Address: staker, DelegatedBalance: tezos.NewZ(1_000_000), StakedBalance: tezos.NewZ(3_000_000)},
+					{Address: overridden, StakedBalance: tezos.NewZ(1_000_000)},
+					{Address: ignored, StakedBalance: tezos.NewZ(1_000_000)},
+					{Address: mock.GetRandomAddress(), DelegatedBalance: tezos.NewZ(1_000_000)},
+				},
+			},
+		},
+		logger: slog.Default(),
+	}
+
+	ctx, err := DistributeStakingRewards(ctx, &common.GeneratePayoutsOptions{Cycle: 800})
+	assert.Nil(err)
+
+	// fee of the overridden staker is above the protocol edge, nothing to pay back
+	payouts := ctx.StageData.StakingPayouts
+	assert.Len(payouts, 1)
+
+	assert.Equal(staker, payouts[0].Delegator)
+	assert.Equal(enums.PAYOUT_KIND_STAKING_REWARD, payouts[0].Kind)
+	assert.True(payouts[0].IsValid)
+	assert.Equal(int64(300), payouts[0].Amount.Int64())
+	assert.Equal(int64(300), payouts[0].Fee.Int64())
+	assert.Equal("protocol paid 0.005400 TEZ directly", payouts[0].Note)
+
+	assert.Equal(int64(1000), ctx.StageData.StakingRewardsEdge.Int64())
+	assert.Equal(int64(9000), ctx.StageData.StakingRewardsShared.Int64())
+	assert.Equal(int64(700), ctx.StageData.StakingFeesAmount.Int64())
+
+	// no staking fee configured
+	config.PayoutConfiguration.StakingFee = nil
+	ctx, err = DistributeStakingRewards(ctx, &common.GeneratePayoutsOptions{Cycle: 800})
+	assert.Nil(err)
+	assert.Empty(ctx.StageData.StakingPayouts)
+
+	// staker with owed amount is reported even when invalid
+	lowStakingFee := .01
+	config.Delegators.Overrides[overridden.String()] = configuration.RuntimeDelegatorOverride{StakingFee: &lowStakingFee, Recipient: tezos.MustParseAddress("KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn")}
+	config.Network.DoNotPaySmartContracts = true
+	ctx, err = DistributeStakingRewards(ctx, &common.GeneratePayoutsOptions{Cycle: 800})
+	assert.Nil(err)
+	assert.Len(ctx.StageData.StakingPayouts, 1)
+	assert.Equal(overridden, ctx.StageData.StakingPayouts[0].Delegator)
+	assert.False(ctx.StageData.StakingPayouts[0].IsValid)
+	assert.Equal(string(enums.INVALID_KT_IGNORED), ctx.StageData.StakingPayouts[0].Note)
+}
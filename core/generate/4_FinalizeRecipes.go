@@ -99,6 +99,7 @@ func FinalizeRecipes(ctx *PayoutGenerationContext, options *common.GeneratePayou
 
 	payouts := make([]common.PayoutRecipe, 0)
 	payouts = append(payouts, delegatorPayouts...)
+	payouts = append(payouts, ctx.StageData.StakingPayouts...)
 	payouts = append(payouts, bondsPayouts...)
 	payouts = append(payouts, feesPayouts...)
 	payouts = append(payouts, donationPayouts...)
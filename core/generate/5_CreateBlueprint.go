@@ -37,6 +37,9 @@ func CreateBlueprint(ctx *PayoutGenerationContext, options *common.GeneratePayou
 		DonatedBonds:             stageData.DonateBondsAmount,
 		DonatedFees:              stageData.DonateFeesAmount,
 		DonatedTotal:             stageData.DonateFeesAmount.Add(stageData.DonateBondsAmount),
+		StakingRewardsEdge:       stageData.StakingRewardsEdge,
+		StakingRewardsShared:     stageData.StakingRewardsShared,
+		StakingFeeIncome:         stageData.StakingFeesAmount,
 		Timestamp:                time.Now(),
 	}
 
@@ -20,6 +20,11 @@ type StageData struct {
 	BakerFeesAmount   tezos.Z
 	DonateBondsAmount tezos.Z
 	DonateFeesAmount  tezos.Z
+
+	StakingPayouts       []common.PayoutRecipe
+	StakingRewardsEdge   tezos.Z
+	StakingRewardsShared tezos.Z
+	StakingFeesAmount    tezos.Z
 }
 
 type PayoutGenerationContext struct {
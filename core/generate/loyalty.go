@@ -0,0 +1,147 @@
+package generate
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/ledger"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// loyaltyHistory resolves number of consecutive cycles delegators stayed with the baker before the cycle
+type loyaltyHistory struct {
+	collector common.CollectorEngine
+	reporter  common.ReporterEngine
+	baker     tezos.Address
+	cycle     int64
+	depth     int64
+	logger    *slog.Logger
+
+	// nil entry means there is no history for the cycle
+	reportedDelegators    map[int64]map[string]bool
+	owedDelegators        map[int64]map[string]bool
+	delegationStartCycles map[string]int64
+	delegationStartLoaded bool
+}
+
+func newLoyaltyHistory(ctx *PayoutGenerationContext, cycle int64) *loyaltyHistory {
+	configuration := ctx.GetConfiguration()
+	return &loyaltyHistory{
+		collector:          ctx.GetCollector(),
+		reporter:           ctx.GetReporter(),
+		baker:              configuration.BakerPKH,
+		cycle:              cycle,
+		depth:              configuration.Delegators.Loyalty.GetMaximumCycles(),
+		logger:             ctx.logger.With("phase", "loyalty"),
+		reportedDelegators: make(map[int64]map[string]bool),
+	}
+}
+
+func (history *loyaltyHistory) getReportedDelegators(cycle int64) map[string]bool {
+	if delegators, ok := history.reportedDelegators[cycle]; ok {
+		return delegators
+	}
+	history.reportedDelegators[cycle] = nil
+	if history.reporter == nil {
+		return nil
+	}
+
+	reports, err := history.reporter.GetExistingReports(cycle)
+	if err != nil || len(reports) == 0 {
+		history.logger.Debug("no payout history available", "cycle", cycle)
+		return nil
+	}
+	delegators := make(map[string]bool, len(reports))
+	for _, report := range reports {
+		if report.Kind != enums.PAYOUT_KIND_DELEGATOR_REWARD {
+			continue
+		}
+		delegators[report.Delegator.String()] = true
+	}
+	history.reportedDelegators[cycle] = delegators
+	return delegators
+}
+
+// getOwedDelegators returns delegators with payouts of the cycle deferred to the owed ledger
+func (history *loyaltyHistory) getOwedDelegators(cycle int64) map[string]bool {
+	if history.owedDelegators == nil {
+		history.owedDelegators = make(map[int64]map[string]bool)
+		owedLedger, err := ledger.Load(state.Global.GetOwedLedgerFilePath())
+		if err != nil {
+			history.logger.Warn("failed to load owed ledger, loyalty is based on payout history only", "error", err.Error())
+			return nil
+		}
+		for _, recipe := range owedLedger.GetOwed(history.baker) {
+			if recipe.Kind != enums.PAYOUT_KIND_DELEGATOR_REWARD {
+				continue
+			}
+			if history.owedDelegators[recipe.Cycle] == nil {
+				history.owedDelegators[recipe.Cycle] = make(map[string]bool)
+			}
+			history.owedDelegators[recipe.Cycle][recipe.Delegator.String()] = true
+		}
+	}
+	return history.owedDelegators[cycle]
+}
+
+func (history *loyaltyHistory) getDelegationStartCycle(delegator tezos.Address) (int64, bool) {
+	if !history.delegationStartLoaded {
+		history.delegationStartLoaded = true
+		startCycles, err := history.collector.GetDelegationStartCycles(history.baker)
+		if err != nil {
+			history.logger.Warn("failed to get delegation start cycles, loyalty is based on payout history only", "error", err.Error())
+		}
+		history.delegationStartCycles = startCycles
+	}
+	startCycle, ok := history.delegationStartCycles[delegator.String()]
+	return startCycle, ok
+}
+
+// GetLoyalCycles returns number of consecutive cycles before the cycle the delegator stayed with the baker,
+// payout history is used first and delegation start is used when the history is not sufficient
+func (history *loyaltyHistory) GetLoyalCycles(delegator tezos.Address) int64 {
+	streak, unknown := int64(0), int64(0)
+	for cycle := history.cycle - 1; streak+unknown < history.depth; cycle-- {
+		reported := history.getReportedDelegators(cycle)
+		if reported[delegator.String()] || history.getOwedDelegators(cycle)[delegator.String()] {
+			streak += unknown + 1
+			unknown = 0
+			continue
+		}
+		// cycles without history, e.g. not paid yet because of payout interval, do not break the streak
+		// they count only when the delegator is found in an earlier cycle
+		if reported == nil {
+			unknown++
+			continue
+		}
+		break
+	}
+	if streak >= history.depth {
+		return streak
+	}
+
+	if startCycle, ok := history.getDelegationStartCycle(delegator); ok && history.cycle-startCycle > streak {
+		return history.cycle - startCycle
+	}
+	return streak
+}
+
+func applyLoyaltyDiscount(candidate *PayoutCandidate, ctx *PayoutGenerationContext, history *loyaltyHistory) {
+	configuration := ctx.GetConfiguration()
+	if candidate.IsInvalid || configuration.Delegators.HasFeeOverride(candidate.Source) {
+		return
+	}
+
+	loyalCycles := history.GetLoyalCycles(candidate.Source)
+	rule, ok := configuration.Delegators.Loyalty.GetRule(loyalCycles)
+	if !ok || rule.Discount == 0 {
+		return
+	}
+
+	candidate.FeeRate = math.Max(candidate.FeeRate-rule.Discount, 0)
+	candidate.Note = fmt.Sprintf("loyalty discount -%s after %d cycles", common.FloatToPercentage(rule.Discount), rule.AfterCycles)
+}
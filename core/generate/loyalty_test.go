@@ -0,0 +1,152 @@
+package generate
+
+import (
+	"log/slog"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/ledger"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type loyaltyReporter struct {
+	mock.EmptyReporter
+	reports map[int64][]common.PayoutReport
+}
+
+func (engine *loyaltyReporter) GetExistingReports(cycle int64) ([]common.PayoutReport, error) {
+	return engine.reports[cycle], nil
+}
+
+type loyaltyCollector struct {
+	mock.EmptyCollector
+	startCycles map[string]int64
+}
+
+func (engine *loyaltyCollector) GetDelegationStartCycles(baker tezos.Address) (map[string]int64, error) {
+	return engine.startCycles, nil
+}
+
+func TestApplyLoyaltyDiscount(t *testing.T) {
+	assert := assert.New(t)
+	assert.Nil(state.Init(t.TempDir(), state.StateInitOptions{}))
+
+	reported := tezos.MustParseAddress("tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM")
+	delegated := tezos.MustParseAddress("tz1hZvgjekGo7DmQjWh7XnY5eLQD8wNYPczE")
+	overridden := tezos.MustParseAddress("tz1bDXD6nNSrebqmAnnKKwnX1QdePSMCj4MX")
+
+	reports := make(map[int64][]common.PayoutReport)
+	for cycle := int64(790); cycle < 800; cycle++ {
+		reports[cycle] = []common.PayoutReport{
+			{Cycle: cycle, Kind: enums.PAYOUT_KIND_DELEGATOR_REWARD, Delegator: reported},
+			{Cycle: cycle, Kind: enums.PAYOUT_KIND_DELEGATOR_REWARD, Delegator: overridden},
+		}
+	}
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	overrideFee := .05
+	config.Delegators.Overrides = map[string]configuration.RuntimeDelegatorOverride{
+		overridden.String(): {Fee: &overrideFee},
+	}
+	config.Delegators.Loyalty = configuration.RuntimeLoyaltyRules{
+		{AfterCycles: 5, Discount: .01},
+		{AfterCycles: 20, Discount: .02},
+	}
+
+	ctx := &PayoutGenerationContext{
+		GeneratePayoutsEngineContext: *common.NewGeneratePayoutsEngines(&loyaltyCollector{
+			startCycles: map[string]int64{delegated.String(): 770},
+		}, nil, nil).WithReporter(&loyaltyReporter{reports: reports}),
+		configuration: &config,
+
+		logger: slog.Default(),
+	}
+	history := newLoyaltyHistory(ctx, 800)
+
+	assert.Equal(int64(10), history.GetLoyalCycles(reported))
+	assert.Equal(int64(30), history.GetLoyalCycles(delegated))
+
+	candidate := PayoutCandidate{Source: reported, FeeRate: .1}
+	applyLoyaltyDiscount(&candidate, ctx, history)
+	assert.InDelta(.09, candidate.FeeRate, 1e-9)
+	assert.Equal("loyalty discount -1.00% after 5 cycles", candidate.Note)
+
+	candidate = PayoutCandidate{Source: delegated, FeeRate: .1}
+	applyLoyaltyDiscount(&candidate, ctx, history)
+	assert.InDelta(.08, candidate.FeeRate, 1e-9)
+
+	candidate = PayoutCandidate{Source: overridden, FeeRate: overrideFee}
+	applyLoyaltyDiscount(&candidate, ctx, history)
+	assert.Equal(overrideFee, candidate.FeeRate)
+	assert.Empty(candidate.Note)
+
+	// gap in history and no delegation record
+	reports[795] = reports[795][1:]
+	history = newLoyaltyHistory(ctx, 800)
+	candidate = PayoutCandidate{Source: reported, FeeRate: .1}
+	applyLoyaltyDiscount(&candidate, ctx, history)
+	assert.Equal(.1, candidate.FeeRate)
+	assert.Empty(candidate.Note)
+}
+
+func TestGetLoyalCyclesWithoutHistory(t *testing.T) {
+	assert := assert.New(t)
+	assert.Nil(state.Init(t.TempDir(), state.StateInitOptions{}))
+
+	delegator := tezos.MustParseAddress("tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM")
+	deferred := tezos.MustParseAddress("tz1hZvgjekGo7DmQjWh7XnY5eLQD8wNYPczE")
+	other := tezos.MustParseAddress("tz1bDXD6nNSrebqmAnnKKwnX1QdePSMCj4MX")
+	baker := tezos.MustParseAddress("tz1UGkfyrT9yBt6U5PV7Qeui3pt3a8jffoWv")
+
+	// payout interval of 5 cycles, cycles 796 - 799 are paid together with 800
+	reports := make(map[int64][]common.PayoutReport)
+	for cycle := int64(780); cycle < 796; cycle++ {
+		reports[cycle] = []common.PayoutReport{
+			{Cycle: cycle, Kind: enums.PAYOUT_KIND_DELEGATOR_REWARD, Delegator: delegator},
+			{Cycle: cycle, Kind: enums.PAYOUT_KIND_DELEGATOR_REWARD, Delegator: other},
+		}
+		if cycle < 790 {
+			reports[cycle] = append(reports[cycle], common.PayoutReport{Cycle: cycle, Kind: enums.PAYOUT_KIND_DELEGATOR_REWARD, Delegator: deferred})
+		}
+	}
+
+	// payouts of cycles 790 - 795 were deferred to the owed ledger
+	owedLedgerFile := path.Join(t.TempDir(), "owed.json")
+	t.Setenv("OWED_LEDGER_FILE", owedLedgerFile)
+	owed := ledger.NewOwedLedger()
+	recipes := make([]common.PayoutRecipe, 0)
+	for cycle := int64(790); cycle < 796; cycle++ {
+		recipes = append(recipes, common.PayoutRecipe{Baker: baker, Cycle: cycle, Kind: enums.PAYOUT_KIND_DELEGATOR_REWARD, Delegator: deferred, Recipient: deferred})
+	}
+	owed.SetOwed(baker, recipes)
+	assert.Nil(owed.Save(owedLedgerFile))
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	config.BakerPKH = baker
+	config.Delegators.Loyalty = configuration.RuntimeLoyaltyRules{{AfterCycles: 10, Discount: .01}}
+	ctx := &PayoutGenerationContext{
+		GeneratePayoutsEngineContext: *common.NewGeneratePayoutsEngines(&loyaltyCollector{}, nil, nil).WithReporter(&loyaltyReporter{reports: reports}),
+		configuration:                &config,
+
+		logger: slog.Default(),
+	}
+
+	history := newLoyaltyHistory(ctx, 799)
+	assert.Equal(int64(10), history.GetLoyalCycles(delegator))
+	assert.Equal(int64(10), history.GetLoyalCycles(deferred))
+
+	// delegator missing in a cycle with history still breaks the streak
+	reports[793] = reports[793][1:]
+	history = newLoyaltyHistory(ctx, 799)
+	assert.Equal(int64(5), history.GetLoyalCycles(delegator))
+
+	// cycles without any history are not counted on their own
+	history = newLoyaltyHistory(ctx, 770)
+	assert.Equal(int64(0), history.GetLoyalCycles(delegator))
+}
@@ -1,6 +1,8 @@
 package generate
 
 import (
+	"math"
+
 	"github.com/tez-capital/tezpay/common"
 	"github.com/tez-capital/tezpay/configuration"
 	"github.com/tez-capital/tezpay/constants/enums"
@@ -16,6 +18,7 @@ type PayoutCandidate struct {
 	IsInvalid        bool                       `json:"is_invalid,omitempty"`
 	IsEmptied        bool                       `json:"is_emptied,omitempty"`
 	InvalidBecause   enums.EPayoutInvalidReason `json:"invalid_because,omitempty"`
+	Note             string                     `json:"note,omitempty"`
 }
 
 func (candidate *PayoutCandidate) GetDelegatedBalance() tezos.Z {
@@ -90,7 +93,7 @@ func (candidate *PayoutCandidateWithBondAmountAndFee) ToValidationContext(ctx *P
 }
 
 func (payout *PayoutCandidateWithBondAmountAndFee) ToPayoutRecipe(baker tezos.Address, cycle int64, kind enums.EPayoutKind) common.PayoutRecipe {
-	note := ""
+	note := payout.Note
 	if payout.IsInvalid {
 		note = string(payout.InvalidBecause)
 	}
@@ -116,22 +119,42 @@ func (payout *PayoutCandidateWithBondAmountAndFee) ToPayoutRecipe(baker tezos.Ad
 	}
 }
 
-func DelegatorToPayoutCandidate(delegator common.Delegator, configuration *configuration.RuntimeConfiguration) PayoutCandidate {
+func getFeeTierBalance(delegator common.Delegator, kind enums.EFeeTierBalance) tezos.Z {
+	switch kind {
+	case enums.FEE_TIER_BALANCE_DELEGATED:
+		return delegator.DelegatedBalance
+	case enums.FEE_TIER_BALANCE_STAKED:
+		return delegator.StakedBalance
+	default:
+		return delegator.DelegatedBalance.Add(delegator.StakedBalance)
+	}
+}
+
+// DelegatorToPayoutCandidate resolves the fee with precedence override > fee tier > fee schedule > fee.
+// Tier fees are relative to the payout fee, fee schedule shifts them by the same difference.
+func DelegatorToPayoutCandidate(delegator common.Delegator, configuration *configuration.RuntimeConfiguration, cycle int64) PayoutCandidate {
 	pkh, _ := delegator.Address.MarshalText()
-	delegatorOverrides := configuration.Delegators.Overrides
-	payoutFeeRate := configuration.PayoutConfiguration.Fee
+	payoutFeeRate := configuration.PayoutConfiguration.GetFee(cycle)
 	payoutRecipient := delegator.Address
 
-	if delegatorOverride, ok := delegatorOverrides[string(pkh)]; ok {
+	delegatorOverride, hasOverride := configuration.Delegators.Overrides[string(pkh)]
+	// tier is chosen from the capped balance
+	if hasOverride && delegatorOverride.MaximumBalance != nil && delegatorOverride.MaximumBalance.IsLess(delegator.DelegatedBalance) {
+		delegator.DelegatedBalance = *delegatorOverride.MaximumBalance
+	}
+	if tierFeeRate, ok := configuration.Delegators.FeeTiers.GetFee(getFeeTierBalance(delegator, configuration.Delegators.FeeTiersBalance)); ok {
+		scheduleShift := payoutFeeRate - configuration.PayoutConfiguration.Fee
+		payoutFeeRate = math.Min(math.Max(tierFeeRate+scheduleShift, 0), 1)
+	}
+	if hasOverride {
 		if !delegatorOverride.Recipient.Equal(tezos.InvalidAddress) {
 			payoutRecipient = delegatorOverride.Recipient
 		}
-		if delegatorOverride.Fee != nil {
-			payoutFeeRate = *delegatorOverride.Fee
-		}
-		if delegatorOverride.MaximumBalance != nil && delegatorOverride.MaximumBalance.IsLess(delegator.DelegatedBalance) {
-			delegator.DelegatedBalance = *delegatorOverride.MaximumBalance
-		}
+		payoutFeeRate = delegatorOverride.GetFee(cycle, payoutFeeRate)
+	}
+	// redirect requested by the delegator is newer than manual override
+	if redirect, ok := configuration.Delegators.Redirects[string(pkh)]; ok {
+		payoutRecipient = redirect
 	}
 
 	return PayoutCandidate{
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/tez-capital/tezpay/common"
 	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants/enums"
 	"github.com/trilitech/tzgo/tezos"
 )
 
@@ -36,20 +37,151 @@ func TestDelegatorToPayoutCandidate(t *testing.T) {
 	}
 
 	delegator := delegators[0]
-	candidate := DelegatorToPayoutCandidate(delegator, &config)
+	candidate := DelegatorToPayoutCandidate(delegator, &config, 800)
 	assert.True(candidate.GetDelegatedBalance().Equal(tezos.MinZ(delegator.DelegatedBalance, maximumBalance)))
 
 	delegator = delegators[1]
-	candidate = DelegatorToPayoutCandidate(delegator, &config)
+	candidate = DelegatorToPayoutCandidate(delegator, &config, 800)
 	assert.True(candidate.GetDelegatedBalance().Equal(tezos.MinZ(delegator.DelegatedBalance, maximumBalance)))
 
 	config.Delegators.Overrides = map[string]configuration.RuntimeDelegatorOverride{}
 
 	delegator = delegators[0]
-	candidate = DelegatorToPayoutCandidate(delegator, &config)
+	candidate = DelegatorToPayoutCandidate(delegator, &config, 800)
 	assert.True(candidate.GetDelegatedBalance().Equal(delegator.DelegatedBalance))
 
 	delegator = delegators[1]
-	candidate = DelegatorToPayoutCandidate(delegator, &config)
+	candidate = DelegatorToPayoutCandidate(delegator, &config, 800)
 	assert.True(candidate.GetDelegatedBalance().Equal(delegator.DelegatedBalance))
 }
+
+func TestDelegatorToPayoutCandidateFeeSchedule(t *testing.T) {
+	assert := assert.New(t)
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	config.PayoutConfiguration.Fee = .05
+	config.PayoutConfiguration.FeeSchedule = configuration.RuntimeFeeSchedule{
+		{FromCycle: 801, Fee: .07},
+	}
+
+	overrideFee := .1
+	config.Delegators.Overrides = map[string]configuration.RuntimeDelegatorOverride{
+		"tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM": {
+			Fee: &overrideFee,
+			FeeSchedule: configuration.RuntimeFeeSchedule{
+				{FromCycle: 900, Fee: .2},
+			},
+		},
+		"tz1hZvgjekGo7DmQjWh7XnY5eLQD8wNYPczE": {
+			FeeSchedule: configuration.RuntimeFeeSchedule{
+				{FromCycle: 850, Fee: 0},
+			},
+		},
+	}
+
+	overridden := common.Delegator{Address: tezos.MustParseAddress("tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM")}
+	scheduled := common.Delegator{Address: tezos.MustParseAddress("tz1hZvgjekGo7DmQjWh7XnY5eLQD8wNYPczE")}
+	regular := common.Delegator{Address: tezos.MustParseAddress("tz1bDXD6nNSrebqmAnnKKwnX1QdePSMCj4MX")}
+
+	assert.Equal(.05, DelegatorToPayoutCandidate(regular, &config, 800).FeeRate)
+	assert.Equal(.07, DelegatorToPayoutCandidate(regular, &config, 801).FeeRate)
+
+	assert.Equal(.1, DelegatorToPayoutCandidate(overridden, &config, 800).FeeRate)
+	assert.Equal(.1, DelegatorToPayoutCandidate(overridden, &config, 899).FeeRate)
+	assert.Equal(.2, DelegatorToPayoutCandidate(overridden, &config, 900).FeeRate)
+
+	assert.Equal(.05, DelegatorToPayoutCandidate(scheduled, &config, 800).FeeRate)
+	assert.Equal(.07, DelegatorToPayoutCandidate(scheduled, &config, 849).FeeRate)
+	assert.Equal(.0, DelegatorToPayoutCandidate(scheduled, &config, 850).FeeRate)
+}
+
+func TestDelegatorToPayoutCandidateFeeTiers(t *testing.T) {
+	assert := assert.New(t)
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	config.PayoutConfiguration.Fee = .1
+	thousand := tezos.NewZ(1_000_000_000)
+	tenThousand := tezos.NewZ(10_000_000_000)
+	config.Delegators.FeeTiers = configuration.RuntimeFeeTiers{
+		{MinimumBalance: tezos.Zero, MaximumBalance: &thousand, Fee: .08},
+		{MinimumBalance: thousand, MaximumBalance: &tenThousand, Fee: .06},
+		{MinimumBalance: tenThousand, Fee: .04},
+	}
+	overrideFee := .01
+	config.Delegators.Overrides = map[string]configuration.RuntimeDelegatorOverride{
+		"tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM": {
+			Fee: &overrideFee,
+		},
+	}
+
+	delegator := common.Delegator{
+		Address:          tezos.MustParseAddress("tz1hZvgjekGo7DmQjWh7XnY5eLQD8wNYPczE"),
+		DelegatedBalance: tezos.NewZ(600_000_000),
+		StakedBalance:    tezos.NewZ(600_000_000),
+	}
+	assert.Equal(.06, DelegatorToPayoutCandidate(delegator, &config, 800).FeeRate)
+
+	config.Delegators.FeeTiersBalance = enums.FEE_TIER_BALANCE_DELEGATED
+	assert.Equal(.08, DelegatorToPayoutCandidate(delegator, &config, 800).FeeRate)
+
+	delegator.DelegatedBalance = tenThousand
+	assert.Equal(.04, DelegatorToPayoutCandidate(delegator, &config, 800).FeeRate)
+
+	delegator.Address = tezos.MustParseAddress("tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM")
+	assert.Equal(.01, DelegatorToPayoutCandidate(delegator, &config, 800).FeeRate)
+}
+
+func TestDelegatorToPayoutCandidateFeeTiersWithScheduleAndCap(t *testing.T) {
+	assert := assert.New(t)
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	config.PayoutConfiguration.Fee = .1
+	config.PayoutConfiguration.FeeSchedule = configuration.RuntimeFeeSchedule{{FromCycle: 900, Fee: .12}}
+	thousand := tezos.NewZ(1_000_000_000)
+	config.Delegators.FeeTiers = configuration.RuntimeFeeTiers{
+		{MinimumBalance: tezos.Zero, MaximumBalance: &thousand, Fee: .08},
+		{MinimumBalance: thousand, Fee: .04},
+	}
+	config.Delegators.FeeTiersBalance = enums.FEE_TIER_BALANCE_DELEGATED
+
+	delegator := common.Delegator{
+		Address:          tezos.MustParseAddress("tz1hZvgjekGo7DmQjWh7XnY5eLQD8wNYPczE"),
+		DelegatedBalance: tezos.NewZ(2_000_000_000),
+	}
+	// schedule raises the fee by 2%, tiers are shifted by the same difference
+	assert.Equal(.04, DelegatorToPayoutCandidate(delegator, &config, 800).FeeRate)
+	assert.InDelta(.06, DelegatorToPayoutCandidate(delegator, &config, 900).FeeRate, 1e-9)
+
+	// tier is chosen from the capped balance
+	maximumBalance := tezos.NewZ(500_000_000)
+	config.Delegators.Overrides = map[string]configuration.RuntimeDelegatorOverride{
+		delegator.Address.String(): {MaximumBalance: &maximumBalance},
+	}
+	candidate := DelegatorToPayoutCandidate(delegator, &config, 800)
+	assert.Equal(.08, candidate.FeeRate)
+	assert.Equal(maximumBalance, candidate.DelegatedBalance)
+}
+
+func TestDelegatorToPayoutCandidateRedirect(t *testing.T) {
+	assert := assert.New(t)
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	delegator := common.Delegator{
+		Address:          tezos.MustParseAddress("tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM"),
+		DelegatedBalance: tezos.NewZ(100000000),
+	}
+	overrideRecipient := tezos.MustParseAddress("tz1hZvgjekGo7DmQjWh7XnY5eLQD8wNYPczE")
+	redirectRecipient := tezos.MustParseAddress("tz1X7U9XxVz6NDxL4DSZhijME61PW45bYUJE")
+	config.Delegators.Overrides = map[string]configuration.RuntimeDelegatorOverride{
+		delegator.Address.String(): {Recipient: overrideRecipient},
+	}
+	assert.Equal(overrideRecipient, DelegatorToPayoutCandidate(delegator, &config, 800).Recipient)
+
+	// approved redirect of the registry takes precedence over manual override
+	config.Delegators.Redirects = map[string]tezos.Address{
+		delegator.Address.String(): redirectRecipient,
+	}
+	candidate := DelegatorToPayoutCandidate(delegator, &config, 800)
+	assert.Equal(redirectRecipient, candidate.Recipient)
+	assert.Equal(delegator.Address, candidate.Source)
+}
@@ -53,9 +53,10 @@ func ValidateTxKind(candidate *PresimPayoutCandidate, _ *configuration.RuntimeCo
 	}
 }
 
-func ValidateMinumumAmount(candidate *PayoutCandidateWithBondAmountAndFee, configuration *configuration.RuntimeConfiguration, _ *configuration.RuntimeDelegatorOverride) {
+func ValidateMinumumAmount(candidate *PayoutCandidateWithBondAmountAndFee, configuration *configuration.RuntimeConfiguration, overrides *configuration.RuntimeDelegatorOverride) {
 	treshhold := configuration.PayoutConfiguration.MinimumAmount
-	if treshhold.IsNeg() || candidate.TxKind != enums.PAYOUT_TX_KIND_TEZ { // if payout is not tezos we respect anything above 0
+	// deferred payouts are carried forward and checked once accumulated
+	if treshhold.IsNeg() || candidate.TxKind != enums.PAYOUT_TX_KIND_TEZ || (overrides != nil && overrides.DefersPayouts()) { // if payout is not tezos we respect anything above 0
 		treshhold = tezos.Zero
 	}
 	diff := candidate.BondsAmount.Sub(treshhold)
@@ -27,7 +27,7 @@ func (engine *mockGenerateCollector) IsRevealed(address tezos.Address) (bool, er
 }
 
 func (engine *mockGenerateCollector) GetCycleStakingData(baker tezos.Address, cycle int64) (*common.BakersCycleData, error) {
-	rawCycleData := `{"OwnDelegatedBalance":"275708698","ExternalDelegatedBalance":"49100747788","BlockDelegatedRewards":"1197688","IdealBlockDelegatedRewards":"1197688","AttestationsDelegatedRewards":"1920302","IdealAttestationsDelegatedRewards":"1920302","DalDelegatedRewards":"427318","IdealDalDelegatedRewards":"427318","BlockDelegatedFees":"17100","DelegatorsCount":57,"OwnStakedBalance":"16421212933","ExternalStakedBalance":"29383795329","BlockStakingRewardsEdge":"192434","AttestationStakingRewardsEdge":"308534","BlockStakingFees":"0","StakersCount":8,"FrozenDepositLimit":"7300000000","Delegators":[{"Address":"tz1dKeXpumUQD5aCgk3jb7i7psWiRkzqJQdE","DelegatedBalance":"18996459719","StakedBalance":"0","Emptied":false},{"Address":"tz1WZRZ6ciwRvkx5YVe5uVXKxrnzDQQevFCL","DelegatedBalance":"16995702660","StakedBalance":"0","Emptied":false},{"Address":"tz1WpPaE47vtUT56MZGgKVi4VQCgEF95mvng","DelegatedBalance":"6704943203","StakedBalance":"0","Emptied":false},{"Address":"tz2X42QzdRqoqeKCqZaGpoXD4VmzmwbuMJMb","DelegatedBalance":"2067404437","StakedBalance":"0","Emptied":false},{"Address":"tz1PABDPXbg9rqz62umCaQ8YhCUajmoYK2Cb","DelegatedBalance":"767347858","StakedBalance":"0","Emptied":false},{"Address":"tz1dhJPohjESC3zzRnaJH7krf5wZPwLm4oH7","DelegatedBalance":"664759189","StakedBalance":"0","Emptied":false},{"Address":"tz1gnuBF9TbBcgHPV2mUE96tBrW7PxqRmx1h","DelegatedBalance":"598755901","StakedBalance":"0","Emptied":false},{"Address":"tz1UGkfyrT9yBt6U5PV7Qeui3pt3a8jffoWv","DelegatedBalance":"507845869","StakedBalance":"0","Emptied":false},{"Address":"tz1XS2hdV3ygveRn1gxRJZgJ4yxFK1YnRrLn","DelegatedBalance":"405103709","StakedBalance":"0","Emptied":false},{"Address":"tz1P9A1noGEeoAQ6WNYM87BbJ3iaioorrws2","DelegatedBalance":"313906924","StakedBalance":"0","Emptied":false},{"Address":"tz1eYiA2SFVBKPK2UW24puEzbTiLHxMDjVtj","DelegatedBalance":"170719784","StakedBalance":"0","Emptied":false},{"Address":"tz1X7U9XxVz6NDxL4DSZhijME61PW45bYUJE","DelegatedBalance":"165652388","StakedBalance":"0","Emptied":false},{"Address":"tz1WWvSczbkko8p14qknj6d1KiK4T3ckQ33X","DelegatedBalance":"161298995","StakedBalance":"0","Emptied":false},{"Address":"tz1gKDahpLhd85yuJ6TEFAwrcnmcEG9gTkSi","DelegatedBalance":"94164895","StakedBalance":"0","Emptied":false},{"Address":"tz2BZibvVaRzZq5kUWz1rcrJychdbHmoNZJE","DelegatedBalance":"75318303","StakedBalance":"0","Emptied":false},{"Address":"tz1cXDqHit4wsNv6dFHggKxDUBdpTkAaizyx","DelegatedBalance":"74317010","StakedBalance":"0","Emptied":false},{"Address":"tz2NvuB26dtjUg11Gb49uocbYrJf26zN2QqW","DelegatedBalance":"70644509","StakedBalance":"0","Emptied":false},{"Address":"tz1XyRgFTiNNZU7WzG3LDBi1bXWYPupBNBL2","DelegatedBalance":"50000001","StakedBalance":"0","Emptied":false},{"Address":"tz1dLZQyaopB2MDMdQC5nRt3Yx6RArVRubRh","DelegatedBalance":"34441415","StakedBalance":"0","Emptied":false},{"Address":"tz1gMRKQWdhY5ZPUehpHofLswyL8Vexvfpbm","DelegatedBalance":"32415471","StakedBalance":"0","Emptied":false},{"Address":"tz2Lhty58UJvywseQH9vpGasHjuHtiitKZ56","DelegatedBalance":"20256873","StakedBalance":"0","Emptied":false},{"Address":"tz1hVZBTeTs9GZtpShYYuF92f7F2g4gWSQo9","DelegatedBalance":"18888009","StakedBalance":"0","Emptied":false},{"Address":"tz1Nry5YPddf4VFDyunXLR8b8AtpvGJLMLZA","DelegatedBalance":"17427981","StakedBalance":"0","Emptied":false},{"Address":"tz1boUGNrp8Z1WEAkqTHAPr5DennP7tQjF71","DelegatedBalance":"14207578","StakedBalance":"0","Emptied":false},{"Address":"tz1Sf9KWCfjN3nsPHB4cek2MXBuXq5qjBZrs","DelegatedBalance":"12750656","StakedBalance":"0","Emptied":false},{"Address":"tz1frE7ArsC1spvQGizY5gBbJBtjnzGCgQ3y","DelegatedBalance":"11966885","StakedBalance":"0","Emptied":false},{"Address":"tz1hbseHXGm2XB7ivo1r3mbpH29c4GZE1aV2","DelegatedBalance":"11307149","StakedBalance":"0","Emptied":false},{"Address":"tz1f8LD3RZ9SMZUgsHQsQAX85EApD2cTXKES","DelegatedBalance":"10889299","StakedBalance":"0","Emptied":false},{"Address":"tz1dTmMLY7tan1HKfW5e3KxJcfZput6UuTH6","DelegatedBalance":"7355283","StakedBalance":"0","Emptied":false},{"Address":"tz1SRqikWtR2KNpugCApreA9QeZuqh8swQMa","DelegatedBalance":"5221495","StakedBalance":"0","Emptied":false},{"Address":"tz1iKqZc4CfrPwjcD7kx8MZtFtfL6YysKnPv","DelegatedBalance":"2939210","StakedBalance":"0","Emptied":false},{"Address":"tz2DWwmauKy4Dkak6X8ePY4Z91EsM36miYxB","DelegatedBalance":"1984760","StakedBalance":"0","Emptied":false},{"Address":"tz1aCb6QKL7K4JxRTUTYjAER8qZH4zKAbigq","DelegatedBalance":"1866216","StakedBalance":"0","Emptied":false},{"Address":"tz1f4zxfTtjTKkrzzXNtQU73zGBcasW3xEBS","DelegatedBalance":"1641573","StakedBalance":"0","Emptied":false},{"Address":"tz1TG2bxjvmRMQakt8KVQ5S6CyLzMuSHx2qF","DelegatedBalance":"1502458","StakedBalance":"0","Emptied":false},{"Address":"tz1YibTr9kUchgG6wJjMwRar2NUfawxH48FT","DelegatedBalance":"1459627","StakedBalance":"0","Emptied":false},{"Address":"tz1YtK9qUJffq2SqjhaJEGbufVHEBWDLPrSV","DelegatedBalance":"1425001","StakedBalance":"0","Emptied":false},{"Address":"tz2DTjmLqMXSwhkpCFnV977vyhtYWbsP6nwo","DelegatedBalance":"1406177","StakedBalance":"0","Emptied":false},{"Address":"tz1eaCp9cc4FtTs3Wm6UgZZnEtZ9b1Peqewi","DelegatedBalance":"1322354","StakedBalance":"0","Emptied":false},{"Address":"tz1MZ7ypArEoYocyyfscGaneNKM7bADhnQC4","DelegatedBalance":"990125","StakedBalance":"0","Emptied":false},{"Address":"tz1Qzov1LwEvSfHonRHr2EwQGto7ExrH43or","DelegatedBalance":"960009","StakedBalance":"0","Emptied":false},{"Address":"tz28e4LURT9Kv6TXysL6szWYkKKqRmA1wDGq","DelegatedBalance":"370571","StakedBalance":"0","Emptied":false},{"Address":"KT1AmQTRDjTwfJDASJRJZdd7uJwDqs5W2mjA","DelegatedBalance":"289285","StakedBalance":"0","Emptied":false},{"Address":"tz1UC8X57hKRmTbgJWsoos9njMy2SW3bKAAh","DelegatedBalance":"275000","StakedBalance":"0","Emptied":false},{"Address":"tz1XX6ykvpfPGAkzeDaFPvvPqmRQoTwCvP4S","DelegatedBalance":"200001","StakedBalance":"0","Emptied":false},{"Address":"tz1P91PPAfukVdpgj153WqoLymrWDYCoyLRA","DelegatedBalance":"172996","StakedBalance":"0","Emptied":false},{"Address":"KT1Kmai449TQT76GZXbihwNFHTUy432y1Z6Y","DelegatedBalance":"164629","StakedBalance":"0","Emptied":false},{"Address":"tz2JvpjaCGsynphe78Xbyu2kHUU2Ym8rxi8v","DelegatedBalance":"162848","StakedBalance":"0","Emptied":false},{"Address":"tz1YDHyB7aBCvj5TCYiRQkjeVHfgtEYUDAd2","DelegatedBalance":"63473","StakedBalance":"0","Emptied":false},{"Address":"tz1VWKeeSqxdLsTfAyCSrwFVZnfgw4ZwaHWV","DelegatedBalance":"40787","StakedBalance":"0","Emptied":false},{"Address":"tz1Xfkbwwa9ewNvYnesSXEYWGgS5gXVSQQVj","DelegatedBalance":"27475","StakedBalance":"0","Emptied":false},{"Address":"tz1Mv79qqYx2QX1NXYawrSrya13T7QoxCba9","DelegatedBalance":"8937","StakedBalance":"0","Emptied":false},{"Address":"KT19XE62UbrJ2gWW4ZWq2UxTQLhrnjBHLvBm","DelegatedBalance":"826","StakedBalance":"0","Emptied":false},{"Address":"tz2H4arakxXuzfo8NBVqTNgL5NBZ9b497Pfv","DelegatedBalance":"1","StakedBalance":"0","Emptied":false},{"Address":"tz1WxZvegEFTFSA8mqHWsBq97S15kS34JXqu","DelegatedBalance":"1","StakedBalance":"0","Emptied":false},{"Address":"KT1FtGbyLR1KV9oQGEYgBUpKPkEC8BcQn4cD","DelegatedBalance":"0","StakedBalance":"0","Emptied":false},{"Address":"KT1B5KPckWy2Mw99ii3wKuE4TQWKKQtSNXFE","DelegatedBalance":"0","StakedBalance":"0","Emptied":false}]}`
+	rawCycleData := `{"own_delegated_balance":"275708698","external_delegated_balance":"49100747788","block_delegated_rewards":"1197688","ideal_block_delegated_rewards":"1197688","attestations_delegated_rewards":"1920302","ideal_attestations_delegated_rewards":"1920302","dal_delegated_rewards":"427318","ideal_dal_delegated_rewards":"427318","block_delegated_fees":"17100","delegators_count":57,"own_staked_balance":"16421212933","external_staked_balance":"29383795329","block_staking_rewards_edge":"192434","attestation_staking_rewards_edge":"308534","block_staking_fees":"0","stakers_count":8,"frozen_deposit_limit":"7300000000","delegators":[{"address":"tz1dKeXpumUQD5aCgk3jb7i7psWiRkzqJQdE","delegated_balance":"18996459719","staked_balance":"0","emptied":false},{"address":"tz1WZRZ6ciwRvkx5YVe5uVXKxrnzDQQevFCL","delegated_balance":"16995702660","staked_balance":"0","emptied":false},{"address":"tz1WpPaE47vtUT56MZGgKVi4VQCgEF95mvng","delegated_balance":"6704943203","staked_balance":"0","emptied":false},{"address":"tz2X42QzdRqoqeKCqZaGpoXD4VmzmwbuMJMb","delegated_balance":"2067404437","staked_balance":"0","emptied":false},{"address":"tz1PABDPXbg9rqz62umCaQ8YhCUajmoYK2Cb","delegated_balance":"767347858","staked_balance":"0","emptied":false},{"address":"tz1dhJPohjESC3zzRnaJH7krf5wZPwLm4oH7","delegated_balance":"664759189","staked_balance":"0","emptied":false},{"address":"tz1gnuBF9TbBcgHPV2mUE96tBrW7PxqRmx1h","delegated_balance":"598755901","staked_balance":"0","emptied":false},{"address":"tz1UGkfyrT9yBt6U5PV7Qeui3pt3a8jffoWv","delegated_balance":"507845869","staked_balance":"0","emptied":false},{"address":"tz1XS2hdV3ygveRn1gxRJZgJ4yxFK1YnRrLn","delegated_balance":"405103709","staked_balance":"0","emptied":false},{"address":"tz1P9A1noGEeoAQ6WNYM87BbJ3iaioorrws2","delegated_balance":"313906924","staked_balance":"0","emptied":false},{"address":"tz1eYiA2SFVBKPK2UW24puEzbTiLHxMDjVtj","delegated_balance":"170719784","staked_balance":"0","emptied":false},{"address":"tz1X7U9XxVz6NDxL4DSZhijME61PW45bYUJE","delegated_balance":"165652388","staked_balance":"0","emptied":false},{"address":"tz1WWvSczbkko8p14qknj6d1KiK4T3ckQ33X","delegated_balance":"161298995","staked_balance":"0","emptied":false},{"address":"tz1gKDahpLhd85yuJ6TEFAwrcnmcEG9gTkSi","delegated_balance":"94164895","staked_balance":"0","emptied":false},{"address":"tz2BZibvVaRzZq5kUWz1rcrJychdbHmoNZJE","delegated_balance":"75318303","staked_balance":"0","emptied":false},{"address":"tz1cXDqHit4wsNv6dFHggKxDUBdpTkAaizyx","delegated_balance":"74317010","staked_balance":"0","emptied":false},{"address":"tz2NvuB26dtjUg11Gb49uocbYrJf26zN2QqW","delegated_balance":"70644509","staked_balance":"0","emptied":false},{"address":"tz1XyRgFTiNNZU7WzG3LDBi1bXWYPupBNBL2","delegated_balance":"50000001","staked_balance":"0","emptied":false},{"address":"tz1dLZQyaopB2MDMdQC5nRt3Yx6RArVRubRh","delegated_balance":"34441415","staked_balance":"0","emptied":false},{"address":"tz1gMRKQWdhY5ZPUehpHofLswyL8Vexvfpbm","delegated_balance":"32415471","staked_balance":"0","emptied":false},{"address":"tz2Lhty58UJvywseQH9vpGasHjuHtiitKZ56","delegated_balance":"20256873","staked_balance":"0","emptied":false},{"address":"tz1hVZBTeTs9GZtpShYYuF92f7F2g4gWSQo9","delegated_balance":"18888009","staked_balance":"0","emptied":false},{"address":"tz1Nry5YPddf4VFDyunXLR8b8AtpvGJLMLZA","delegated_balance":"17427981","staked_balance":"0","emptied":false},{"address":"tz1boUGNrp8Z1WEAkqTHAPr5DennP7tQjF71","delegated_balance":"14207578","staked_balance":"0","emptied":false},{"address":"tz1Sf9KWCfjN3nsPHB4cek2MXBuXq5qjBZrs","delegated_balance":"12750656","staked_balance":"0","emptied":false},{"address":"tz1frE7ArsC1spvQGizY5gBbJBtjnzGCgQ3y","delegated_balance":"11966885","staked_balance":"0","emptied":false},{"address":"tz1hbseHXGm2XB7ivo1r3mbpH29c4GZE1aV2","delegated_balance":"11307149","staked_balance":"0","emptied":false},{"address":"tz1f8LD3RZ9SMZUgsHQsQAX85EApD2cTXKES","delegated_balance":"10889299","staked_balance":"0","emptied":false},{"address":"tz1dTmMLY7tan1HKfW5e3KxJcfZput6UuTH6","delegated_balance":"7355283","staked_balance":"0","emptied":false},{"address":"tz1SRqikWtR2KNpugCApreA9QeZuqh8swQMa","delegated_balance":"5221495","staked_balance":"0","emptied":false},{"address":"tz1iKqZc4CfrPwjcD7kx8MZtFtfL6YysKnPv","delegated_balance":"2939210","staked_balance":"0","emptied":false},{"address":"tz2DWwmauKy4Dkak6X8ePY4Z91EsM36miYxB","delegated_balance":"1984760","staked_balance":"0","emptied":false},{"address":"tz1aCb6QKL7K4JxRTUTYjAER8qZH4zKAbigq","delegated_balance":"1866216","staked_balance":"0","emptied":false},{"address":"tz1f4zxfTtjTKkrzzXNtQU73zGBcasW3xEBS","delegated_balance":"1641573","staked_balance":"0","emptied":false},{"address":"tz1TG2bxjvmRMQakt8KVQ5S6CyLzMuSHx2qF","delegated_balance":"1502458","staked_balance":"0","emptied":false},{"address":"tz1YibTr9kUchgG6wJjMwRar2NUfawxH48FT","delegated_balance":"1459627","staked_balance":"0","emptied":false},{"address":"tz1YtK9qUJffq2SqjhaJEGbufVHEBWDLPrSV","delegated_balance":"1425001","staked_balance":"0","emptied":false},{"address":"tz2DTjmLqMXSwhkpCFnV977vyhtYWbsP6nwo","delegated_balance":"1406177","staked_balance":"0","emptied":false},{"address":"tz1eaCp9cc4FtTs3Wm6UgZZnEtZ9b1Peqewi","delegated_balance":"1322354","staked_balance":"0","emptied":false},{"address":"tz1MZ7ypArEoYocyyfscGaneNKM7bADhnQC4","delegated_balance":"990125","staked_balance":"0","emptied":false},{"address":"tz1Qzov1LwEvSfHonRHr2EwQGto7ExrH43or","delegated_balance":"960009","staked_balance":"0","emptied":false},{"address":"tz28e4LURT9Kv6TXysL6szWYkKKqRmA1wDGq","delegated_balance":"370571","staked_balance":"0","emptied":false},{"address":"KT1AmQTRDjTwfJDASJRJZdd7uJwDqs5W2mjA","delegated_balance":"289285","staked_balance":"0","emptied":false},{"address":"tz1UC8X57hKRmTbgJWsoos9njMy2SW3bKAAh","delegated_balance":"275000","staked_balance":"0","emptied":false},{"address":"tz1XX6ykvpfPGAkzeDaFPvvPqmRQoTwCvP4S","delegated_balance":"200001","staked_balance":"0","emptied":false},{"address":"tz1P91PPAfukVdpgj153WqoLymrWDYCoyLRA","delegated_balance":"172996","staked_balance":"0","emptied":false},{"address":"KT1Kmai449TQT76GZXbihwNFHTUy432y1Z6Y","delegated_balance":"164629","staked_balance":"0","emptied":false},{"address":"tz2JvpjaCGsynphe78Xbyu2kHUU2Ym8rxi8v","delegated_balance":"162848","staked_balance":"0","emptied":false},{"address":"tz1YDHyB7aBCvj5TCYiRQkjeVHfgtEYUDAd2","delegated_balance":"63473","staked_balance":"0","emptied":false},{"address":"tz1VWKeeSqxdLsTfAyCSrwFVZnfgw4ZwaHWV","delegated_balance":"40787","staked_balance":"0","emptied":false},{"address":"tz1Xfkbwwa9ewNvYnesSXEYWGgS5gXVSQQVj","delegated_balance":"27475","staked_balance":"0","emptied":false},{"address":"tz1Mv79qqYx2QX1NXYawrSrya13T7QoxCba9","delegated_balance":"8937","staked_balance":"0","emptied":false},{"address":"KT19XE62UbrJ2gWW4ZWq2UxTQLhrnjBHLvBm","delegated_balance":"826","staked_balance":"0","emptied":false},{"address":"tz2H4arakxXuzfo8NBVqTNgL5NBZ9b497Pfv","delegated_balance":"1","staked_balance":"0","emptied":false},{"address":"tz1WxZvegEFTFSA8mqHWsBq97S15kS34JXqu","delegated_balance":"1","staked_balance":"0","emptied":false},{"address":"KT1FtGbyLR1KV9oQGEYgBUpKPkEC8BcQn4cD","delegated_balance":"0","staked_balance":"0","emptied":false},{"address":"KT1B5KPckWy2Mw99ii3wKuE4TQWKKQtSNXFE","delegated_balance":"0","staked_balance":"0","emptied":false}]}`
 	var cycleData common.BakersCycleData
 	err := json.Unmarshal([]byte(rawCycleData), &cycleData)
 	if err != nil {
@@ -20,6 +20,7 @@ func PreparePayouts(blueprints []*common.CyclePayoutBlueprint, config *configura
 	ctx, err = WrapContext[*prepare.PayoutPrepareContext, *common.PreparePayoutsOptions](ctx).ExecuteStages(options,
 		prepare.PreparePayouts,
 		prepare.AccumulatePayouts,
+		prepare.DeferPayouts,
 		prepare.CheckSufficientBalance,
 		prepare.CollectTransactionFees,
 		prepare.ValidatePreparedPayouts,
@@ -34,6 +35,7 @@ func PreparePayouts(blueprints []*common.CyclePayoutBlueprint, config *configura
 		ValidPayouts:                         ctx.StageData.AccumulatedPayouts,
 		InvalidPayouts:                       ctx.StageData.InvalidRecipes,
 		ReportsOfPastSuccessfulPayouts:       ctx.StageData.ReportsOfPastSuccesfulPayouts,
+		HeldPayouts:                          ctx.StageData.HeldPayouts,
 		BatchMetadataDeserializationGasLimit: ctx.StageData.BatchMetadataDeserializationGasLimit,
 	}, nil
 }
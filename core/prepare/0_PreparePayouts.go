@@ -4,12 +4,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/samber/lo"
 	"github.com/tez-capital/tezpay/common"
 	"github.com/tez-capital/tezpay/constants"
 	"github.com/tez-capital/tezpay/constants/enums"
 	"github.com/tez-capital/tezpay/extension"
+	"github.com/tez-capital/tezpay/ledger"
+	"github.com/tez-capital/tezpay/state"
 	"github.com/tez-capital/tezpay/utils"
 	"github.com/trilitech/tzgo/codec"
 	"github.com/trilitech/tzgo/tezos"
@@ -59,6 +62,43 @@ func estimateBatchSerializationGasLimit(ctx *PayoutPrepareContext) error {
 	return nil
 }
 
+// loadOwedRecipes returns unpaid recipes of the owed ledger, recipes of blueprint cycles are skipped as they are generated again
+func loadOwedRecipes(ctx *PayoutPrepareContext) ([]common.PayoutRecipe, []common.PayoutReport, []common.PayoutReport, error) {
+	owedLedger, err := ledger.Load(state.Global.GetOwedLedgerFilePath())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	blueprintCycles := lo.Map(ctx.PayoutBlueprints, func(blueprint *common.CyclePayoutBlueprint, _ int) int64 {
+		return blueprint.Cycle
+	})
+	owed := lo.GroupBy(lo.Filter(owedLedger.GetOwed(ctx.configuration.BakerPKH), func(recipe common.PayoutRecipe, _ int) bool {
+		return !slices.Contains(blueprintCycles, recipe.Cycle)
+	}), func(recipe common.PayoutRecipe) int64 {
+		return recipe.Cycle
+	})
+	cycles := lo.Keys(owed)
+	slices.Sort(cycles)
+
+	recipes := make([]common.PayoutRecipe, 0)
+	reportsOfPastSuccesfulPayouts := make([]common.PayoutReport, 0)
+	heldPayouts := make([]common.PayoutReport, 0)
+	for _, cycle := range cycles {
+		reports, err := ctx.GetReporter().GetExistingReports(cycle)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, nil, nil, errors.Join(constants.ErrPayoutsFromFileLoadFailed, fmt.Errorf("cycle: %d", cycle), err)
+		}
+		reportResidues := utils.FilterReportsByBaker(reports, ctx.configuration.BakerPKH)
+		unpaid, cycleReportsOfPastSuccesfulPayouts, cycleHeldPayouts := utils.FilterRecipesByReports(owed[cycle], reportResidues, ctx.GetCollector())
+		recipes = append(recipes, unpaid...)
+		reportsOfPastSuccesfulPayouts = append(reportsOfPastSuccesfulPayouts, cycleReportsOfPastSuccesfulPayouts...)
+		heldPayouts = append(heldPayouts, cycleHeldPayouts...)
+	}
+	if len(recipes) > 0 {
+		ctx.logger.Info("carrying forward owed payouts", "count", len(recipes), "cycles", cycles)
+	}
+	return recipes, reportsOfPastSuccesfulPayouts, heldPayouts, nil
+}
+
 type AfterPayoutsPreapered struct {
 	Recipes                       []common.PayoutRecipe `json:"recipes"`
 	Payouts                       []common.PayoutRecipe `json:"payouts"`
@@ -90,6 +130,7 @@ func PreparePayouts(ctx *PayoutPrepareContext, options *common.PreparePayoutsOpt
 
 	payouts := make([]common.PayoutRecipe, 0, count)
 	reportsOfPastSuccesfulPayouts := make([]common.PayoutReport, 0, count)
+	heldPayouts := make([]common.PayoutReport, 0)
 	for _, blueprint := range ctx.PayoutBlueprints {
 		reports, err := ctx.GetReporter().GetExistingReports(blueprint.Cycle)
 		if err != nil && !os.IsNotExist(err) {
@@ -97,10 +138,24 @@ func PreparePayouts(ctx *PayoutPrepareContext, options *common.PreparePayoutsOpt
 		}
 		reportResidues := utils.FilterReportsByBaker(reports, ctx.configuration.BakerPKH)
 		// we match already paid even against invalid set of payouts in case they were paid under different conditions
-		bluePrintPayouts, blueprintReportsOfPastSuccesfulPayouts := utils.FilterRecipesByReports(blueprint.Payouts, reportResidues, ctx.GetCollector())
+		bluePrintPayouts, blueprintReportsOfPastSuccesfulPayouts, blueprintHeldPayouts := utils.FilterRecipesByReports(blueprint.Payouts, reportResidues, ctx.GetCollector())
 
 		payouts = append(payouts, bluePrintPayouts...)
 		reportsOfPastSuccesfulPayouts = append(reportsOfPastSuccesfulPayouts, blueprintReportsOfPastSuccesfulPayouts...)
+		heldPayouts = append(heldPayouts, blueprintHeldPayouts...)
+	}
+
+	owedRecipes, owedReportsOfPastSuccesfulPayouts, owedHeldPayouts, err := loadOwedRecipes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	payouts = append(payouts, owedRecipes...)
+	reportsOfPastSuccesfulPayouts = append(reportsOfPastSuccesfulPayouts, owedReportsOfPastSuccesfulPayouts...)
+	heldPayouts = append(heldPayouts, owedHeldPayouts...)
+	ctx.StageData.OwedRecipes = owedRecipes
+	ctx.StageData.HeldPayouts = heldPayouts
+	if len(heldPayouts) > 0 {
+		logger.Warn("payouts recorded before execution but never settled are held until reconciled (see 'tezpay reconcile')", "count", len(heldPayouts))
 	}
 
 	hookData := &AfterPayoutsPreapered{
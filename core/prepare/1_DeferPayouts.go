@@ -0,0 +1,92 @@
+package prepare
+
+import (
+	"slices"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/ledger"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// isPayoutDue checks accumulated payout against payout preferences of the delegator
+func isPayoutDue(payout *common.AccumulatedPayoutRecipe, override *configuration.RuntimeDelegatorOverride, config *configuration.RuntimeConfiguration, lastCycle int64) bool {
+	oldestCycle := lo.Min(lo.Map(payout.Recipes, func(recipe *common.PayoutRecipe, _ int) int64 {
+		return recipe.Cycle
+	}))
+	if lastCycle-oldestCycle+1 < override.PayoutInterval {
+		return false
+	}
+
+	treshhold := config.PayoutConfiguration.MinimumAmount
+	if override.MinimumPayoutAmount != nil {
+		treshhold = *override.MinimumPayoutAmount
+	}
+	if treshhold.IsNeg() || payout.TxKind != enums.PAYOUT_TX_KIND_TEZ {
+		treshhold = tezos.Zero
+	}
+	return treshhold.IsLess(payout.GetAmount())
+}
+
+// DeferPayouts holds back payouts of delegators with payout preferences until they are due,
+// recipes of such delegators are kept in owed ledger until they are reported as paid
+func DeferPayouts(ctx *PayoutPrepareContext, options *common.PreparePayoutsOptions) (*PayoutPrepareContext, error) {
+	config := ctx.GetConfiguration()
+	logger := ctx.logger.With("phase", "defer_payouts")
+
+	blueprintCycles := lo.Map(ctx.PayoutBlueprints, func(blueprint *common.CyclePayoutBlueprint, _ int) int64 {
+		return blueprint.Cycle
+	})
+	lastCycle := lo.Max(blueprintCycles)
+
+	owed := slices.Clone(ctx.StageData.OwedRecipes)
+	payouts := make([]*common.AccumulatedPayoutRecipe, 0, len(ctx.StageData.AccumulatedPayouts))
+	for _, payout := range ctx.StageData.AccumulatedPayouts {
+		override, ok := config.Delegators.Overrides[payout.Delegator.String()]
+		if !payout.IsValid || !payout.Kind.IsDelegatorPayout() || !ok || !override.DefersPayouts() {
+			payouts = append(payouts, payout)
+			continue
+		}
+
+		for _, recipe := range payout.Recipes {
+			owed = append(owed, *recipe)
+		}
+		if isPayoutDue(payout, &override, config, lastCycle) {
+			payouts = append(payouts, payout)
+			continue
+		}
+
+		logger.Debug("deferring payout", "delegator", payout.Delegator.String(), "amount", payout.GetAmount().Int64())
+		for _, recipe := range payout.Recipes {
+			// carried forward recipes are reported once paid
+			if !slices.Contains(blueprintCycles, recipe.Cycle) {
+				continue
+			}
+			deferred := *recipe
+			deferred.IsValid = false
+			deferred.Note = string(enums.INVALID_PAYOUT_DEFERRED)
+			ctx.StageData.InvalidRecipes = append(ctx.StageData.InvalidRecipes, deferred)
+		}
+	}
+	ctx.StageData.AccumulatedPayouts = payouts
+
+	if !options.UpdateOwedLedger {
+		return ctx, nil
+	}
+	owedLedgerFile := state.Global.GetOwedLedgerFilePath()
+	owedLedger, err := ledger.Load(owedLedgerFile)
+	if err != nil {
+		return ctx, err
+	}
+	// recipes of blueprint cycles were not carried forward, they stay in the ledger until reported as paid
+	owedLedger.SetOwed(config.BakerPKH, append(owed, lo.Filter(owedLedger.GetOwed(config.BakerPKH), func(recipe common.PayoutRecipe, _ int) bool {
+		return slices.Contains(blueprintCycles, recipe.Cycle)
+	})...))
+	if err := owedLedger.Save(owedLedgerFile); err != nil {
+		return ctx, err
+	}
+	return ctx, nil
+}
@@ -0,0 +1,87 @@
+package prepare
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/ledger"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func getAccumulatedRecipe(baker, delegator tezos.Address, amount int64, cycles ...int64) *common.AccumulatedPayoutRecipe {
+	recipes := lo.Map(cycles, func(cycle int64, _ int) *common.PayoutRecipe {
+		return &common.PayoutRecipe{
+			Baker:     baker,
+			Delegator: delegator,
+			Recipient: delegator,
+			Cycle:     cycle,
+			Amount:    tezos.NewZ(amount),
+			Kind:      enums.PAYOUT_KIND_DELEGATOR_REWARD,
+			TxKind:    enums.PAYOUT_TX_KIND_TEZ,
+			IsValid:   true,
+		}
+	})
+	payout := recipes[0].AsAccumulated()
+	payout.Recipes = recipes
+	return payout
+}
+
+func TestDeferPayouts(t *testing.T) {
+	assert := assert.New(t)
+	assert.Nil(state.Init(t.TempDir(), state.StateInitOptions{}))
+
+	regular, due, notDue, bellowMinimum := mock.GetRandomAddress(), mock.GetRandomAddress(), mock.GetRandomAddress(), mock.GetRandomAddress()
+	minimumPayoutAmount := tezos.NewZ(5000000)
+	config := configuration.GetDefaultRuntimeConfiguration()
+	config.BakerPKH = mock.GetRandomAddress()
+	config.Delegators.Overrides = map[string]configuration.RuntimeDelegatorOverride{
+		due.String():           {PayoutInterval: 3},
+		notDue.String():        {PayoutInterval: 3},
+		bellowMinimum.String(): {MinimumPayoutAmount: &minimumPayoutAmount},
+	}
+
+	dueRecipe := getAccumulatedRecipe(config.BakerPKH, due, 1000000, 8, 9, 10)
+	ctx := &PayoutPrepareContext{
+		StageData: &StageData{
+			AccumulatedPayouts: []*common.AccumulatedPayoutRecipe{
+				getAccumulatedRecipe(config.BakerPKH, regular, 1000000, 10),
+				dueRecipe,
+				getAccumulatedRecipe(config.BakerPKH, notDue, 1000000, 9, 10),
+				getAccumulatedRecipe(config.BakerPKH, bellowMinimum, 2000000, 9, 10),
+			},
+			// carried forward from the ledger
+			OwedRecipes: lo.Map(dueRecipe.Recipes[:2], func(recipe *common.PayoutRecipe, _ int) common.PayoutRecipe { return *recipe }),
+		},
+		PayoutBlueprints: []*common.CyclePayoutBlueprint{{Cycle: 10}},
+		configuration:    &config,
+		logger:           slog.Default(),
+	}
+
+	result, err := DeferPayouts(ctx, &common.PreparePayoutsOptions{UpdateOwedLedger: true})
+	assert.Nil(err)
+	assert.Equal([]tezos.Address{regular, due}, lo.Map(result.StageData.AccumulatedPayouts, func(payout *common.AccumulatedPayoutRecipe, _ int) tezos.Address {
+		return payout.Delegator
+	}))
+
+	// only recipes of blueprint cycles are reported as deferred
+	assert.Len(result.StageData.InvalidRecipes, 2)
+	for _, recipe := range result.StageData.InvalidRecipes {
+		assert.Equal(int64(10), recipe.Cycle)
+		assert.False(recipe.IsValid)
+		assert.Equal(string(enums.INVALID_PAYOUT_DEFERRED), recipe.Note)
+	}
+
+	owedLedger, err := ledger.Load(state.Global.GetOwedLedgerFilePath())
+	assert.Nil(err)
+	owed := owedLedger.GetOwed(config.BakerPKH)
+	assert.Len(owed, 7)
+	assert.False(lo.ContainsBy(owed, func(recipe common.PayoutRecipe) bool { return recipe.Delegator.Equal(regular) }))
+	assert.True(lo.EveryBy(owed, func(recipe common.PayoutRecipe) bool { return recipe.IsValid }))
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/tez-capital/tezpay/constants"
 	"github.com/tez-capital/tezpay/constants/enums"
 	"github.com/tez-capital/tezpay/extension"
+	"github.com/tez-capital/tezpay/metrics"
 	"github.com/tez-capital/tezpay/utils"
 	"github.com/trilitech/tzgo/tezos"
 )
@@ -35,16 +36,48 @@ func checkBalanceWithCollector(data *CheckBalanceHookData, ctx *PayoutPrepareCon
 	if data.SkipTezCheck { // skip tez check for cases when pervious hook already checked it
 		return nil
 	}
-	payableBalance, err := ctx.GetCollector().GetBalance(ctx.PayoutKey.Address())
+	configuration := ctx.GetConfiguration()
+	executorAddress := ctx.PayoutKey.Address()
+	payoutAddress := configuration.PayoutConfiguration.GetPayoutAddress(executorAddress)
+	payableBalance, err := ctx.GetCollector().GetBalance(payoutAddress)
 	if err != nil {
 		return err
 	}
-
-	configuration := ctx.GetConfiguration()
+	metrics.PayoutWalletBalance.WithLabelValues(payoutAddress.String()).Set(float64(payableBalance.Int64()))
 
 	totalPayouts := len(data.Payouts)
 	// add all bonds, fees and donations destinations
 	totalPayouts = totalPayouts + len(configuration.IncomeRecipients.Bonds) + len(configuration.IncomeRecipients.Fees) + utils.Max(len(configuration.IncomeRecipients.Donations), 1)
+	feeBuffer := tezos.NewZ(constants.PAYOUT_FEE_BUFFER).Mul64(int64(totalPayouts))
+
+	if !payoutAddress.Equal(executorAddress) {
+		// multisig holds the funds, fees of the multisig calls are paid by the executor wallet
+		requiredBalance := lo.Reduce(data.Payouts, func(agg tezos.Z, recipe *common.AccumulatedPayoutRecipe, _ int) tezos.Z {
+			if recipe.TxKind == enums.PAYOUT_TX_KIND_TEZ {
+				return agg.Add(recipe.GetAmount())
+			}
+			return agg
+		}, tezos.Zero)
+		if payableBalance.Sub(requiredBalance).IsNeg() {
+			data.IsSufficient = false
+			data.Message = fmt.Sprintf("required: %s, available: %s", common.FormatTezAmount(requiredBalance.Int64()), common.FormatTezAmount(payableBalance.Int64()))
+			return nil
+		}
+
+		executorBalance, err := ctx.GetCollector().GetBalance(executorAddress)
+		if err != nil {
+			return err
+		}
+		requiredFees := lo.Reduce(data.Payouts, func(agg tezos.Z, recipe *common.AccumulatedPayoutRecipe, _ int) tezos.Z {
+			return agg.Add64(recipe.GetTxFee())
+		}, feeBuffer)
+		diff := executorBalance.Sub(requiredFees)
+		if diff.IsNeg() || diff.IsZero() {
+			data.IsSufficient = false
+			data.Message = fmt.Sprintf("executor wallet %s - required for fees: %s, available: %s", executorAddress.String(), common.FormatTezAmount(requiredFees.Int64()), common.FormatTezAmount(executorBalance.Int64()))
+		}
+		return nil
+	}
 
 	requiredbalance := lo.Reduce(data.Payouts, func(agg tezos.Z, recipe *common.AccumulatedPayoutRecipe, _ int) tezos.Z {
 		if recipe.TxKind == enums.PAYOUT_TX_KIND_TEZ {
@@ -54,7 +87,7 @@ func checkBalanceWithCollector(data *CheckBalanceHookData, ctx *PayoutPrepareCon
 	}, tezos.Zero)
 
 	// add bonds,fees and donations to required balance
-	requiredbalance = requiredbalance.Add(tezos.NewZ(constants.PAYOUT_FEE_BUFFER).Mul64(int64(totalPayouts)))
+	requiredbalance = requiredbalance.Add(feeBuffer)
 
 	diff := payableBalance.Sub(requiredbalance)
 	if diff.IsNeg() || diff.IsZero() {
@@ -0,0 +1,64 @@
+package prepare
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type balanceCollector struct {
+	mock.EmptyCollector
+	balances map[string]tezos.Z
+}
+
+func (engine *balanceCollector) GetBalance(addr tezos.Address) (tezos.Z, error) {
+	return engine.balances[addr.String()], nil
+}
+
+func TestCheckBalanceWithCollectorMultisig(t *testing.T) {
+	assert := assert.New(t)
+
+	executorKey, err := tezos.GenerateKey(tezos.KeyTypeEd25519)
+	assert.Nil(err)
+	executor := executorKey.Address()
+	contract := tezos.MustParseAddress("KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn")
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	config.BakerPKH = mock.GetRandomAddress()
+	config.PayoutConfiguration.Multisig = &configuration.RuntimeMultisigConfiguration{Contract: contract}
+
+	collector := &balanceCollector{balances: map[string]tezos.Z{
+		contract.String(): tezos.NewZ(2_000_000),
+		executor.String(): tezos.NewZ(1_000_000),
+	}}
+	ctx := &PayoutPrepareContext{
+		PreparePayoutsEngineContext: *common.NewPreparePayoutsEngineContext(collector, nil, nil, nil),
+		configuration:               &config,
+		PayoutKey:                   executorKey.Public(),
+		logger:                      slog.Default(),
+	}
+	data := &CheckBalanceHookData{
+		IsSufficient: true,
+		Payouts:      []*common.AccumulatedPayoutRecipe{getAccumulatedRecipe(config.BakerPKH, mock.GetRandomAddress(), 1_500_000, 10)},
+	}
+
+	// amounts are paid by the multisig, fees by the executor
+	assert.Nil(checkBalanceWithCollector(data, ctx))
+	assert.True(data.IsSufficient)
+
+	collector.balances[executor.String()] = tezos.NewZ(100)
+	assert.Nil(checkBalanceWithCollector(data, ctx))
+	assert.False(data.IsSufficient)
+	assert.Contains(data.Message, executor.String())
+
+	data.IsSufficient = true
+	collector.balances[executor.String()] = tezos.NewZ(1_000_000)
+	collector.balances[contract.String()] = tezos.NewZ(1_000_000)
+	assert.Nil(checkBalanceWithCollector(data, ctx))
+	assert.False(data.IsSufficient)
+}
@@ -54,9 +54,9 @@ func CollectTransactionFees(ctx *PayoutPrepareContext, options *common.PreparePa
 		txFee := result.OpLimits.GetOperationFeesWithoutAllocation()
 		allocationFee := result.OpLimits.GetAllocationFee()
 
-		// only tez delegator rewards are subject to fee collection
-		recipe.AddTxFee64(txFee, recipe.TxKind == enums.PAYOUT_TX_KIND_TEZ && recipe.Kind == enums.PAYOUT_KIND_DELEGATOR_REWARD && !isBakerPayingTxFee)
-		recipe.AddTxFee64(allocationFee, recipe.TxKind == enums.PAYOUT_TX_KIND_TEZ && recipe.Kind == enums.PAYOUT_KIND_DELEGATOR_REWARD && !isBakerPayingAllocationTxFee)
+		// only tez delegator and staking rewards are subject to fee collection
+		recipe.AddTxFee64(txFee, recipe.TxKind == enums.PAYOUT_TX_KIND_TEZ && recipe.Kind.IsDelegatorPayout() && !isBakerPayingTxFee)
+		recipe.AddTxFee64(allocationFee, recipe.TxKind == enums.PAYOUT_TX_KIND_TEZ && recipe.Kind.IsDelegatorPayout() && !isBakerPayingAllocationTxFee)
 		if recipe.GetAmount().IsNeg() || recipe.GetAmount().IsZero() {
 			recipe = backup // restore as we wont charge fees if we are invalid
 			recipe.IsValid = false
@@ -3,7 +3,6 @@ package prepare
 import (
 	"github.com/samber/lo"
 	"github.com/tez-capital/tezpay/common"
-	"github.com/tez-capital/tezpay/constants/enums"
 	"github.com/tez-capital/tezpay/utils"
 )
 
@@ -19,7 +18,7 @@ func ValidatePreparedPayouts(ctx *PayoutPrepareContext, options *common.PrepareP
 			return recipe
 		}
 
-		if recipe.Kind != enums.PAYOUT_KIND_DELEGATOR_REWARD { // only delegator rewards are subject to validation
+		if !recipe.Kind.IsDelegatorPayout() { // only delegator and staking rewards are subject to validation
 			return recipe
 		}
 
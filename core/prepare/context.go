@@ -13,6 +13,10 @@ type StageData struct {
 	AccumulatedPayouts            []*common.AccumulatedPayoutRecipe
 	InvalidRecipes                []common.PayoutRecipe
 	ReportsOfPastSuccesfulPayouts []common.PayoutReport
+	// unpaid recipes carried forward from owed ledger
+	OwedRecipes []common.PayoutRecipe
+	// payouts with unknown outcome held until reconciled
+	HeldPayouts []common.PayoutReport
 	// protocol, signature etc.
 	BatchMetadataDeserializationGasLimit int64
 }
@@ -55,8 +55,11 @@ func (validationContext *PayoutRecipeValidationContext) Validate(validators ...P
 
 // validation
 
-func ValidateMinumumAmount(candidate *common.AccumulatedPayoutRecipe, configuration *configuration.RuntimeConfiguration, _ *configuration.RuntimeDelegatorOverride) {
+func ValidateMinumumAmount(candidate *common.AccumulatedPayoutRecipe, configuration *configuration.RuntimeConfiguration, overrides *configuration.RuntimeDelegatorOverride) {
 	treshhold := configuration.PayoutConfiguration.MinimumAmount
+	if overrides != nil && overrides.MinimumPayoutAmount != nil {
+		treshhold = *overrides.MinimumPayoutAmount
+	}
 	if treshhold.IsNeg() || candidate.TxKind != enums.PAYOUT_TX_KIND_TEZ { // if payout is not tezos we respect anything above 0
 		treshhold = tezos.Zero
 	}
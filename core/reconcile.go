@@ -0,0 +1,128 @@
+package core
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func reconcileByOpHash(report common.PayoutReport, collector common.CollectorEngine) (common.ReconciledPayout, error) {
+	result := common.ReconciledPayout{Original: report, Reconciled: report}
+	status, err := collector.WasOperationApplied(report.OpHash)
+	if err != nil {
+		return result, err
+	}
+	switch status {
+	case common.OPERATION_STATUS_APPLIED:
+		result.Status = common.RECONCILE_STATUS_PAID
+	case common.OPERATION_STATUS_FAILED:
+		result.Status = common.RECONCILE_STATUS_FAILED
+	case common.OPERATION_STATUS_NOT_EXISTS:
+		result.Status = common.RECONCILE_STATUS_NOT_LANDED
+	default:
+		result.Status = common.RECONCILE_STATUS_UNRESOLVED
+		result.Reason = "operation status unknown"
+	}
+	return result, nil
+}
+
+func reconcileByRecipient(report common.PayoutReport, source tezos.Address, collector common.CollectorEngine) (common.ReconciledPayout, error) {
+	result := common.ReconciledPayout{Original: report, Reconciled: report}
+	if report.TxKind != enums.PAYOUT_TX_KIND_TEZ {
+		// fa transfers target the token contract, we can not match them by recipient
+		result.Status = common.RECONCILE_STATUS_UNRESOLVED
+		result.Reason = "missing op hash for non-tez transfer"
+		return result, nil
+	}
+
+	// record is written just before dispatch, allow small clock drift
+	since := report.Timestamp.Add(-time.Minute)
+	until := report.Timestamp.Add(time.Minute * constants.RECONCILE_LOOKUP_WINDOW_MINUTES)
+	transactions, err := collector.GetTransactions(source, report.Recipient, since, until)
+	if err != nil {
+		return result, err
+	}
+
+	// accumulated payouts are sent as a single transaction, amount may only be higher
+	var failed *common.TransactionInfo
+	for _, transaction := range transactions {
+		if transaction.Amount.IsLess(report.Amount) {
+			continue
+		}
+		if transaction.Status == common.OPERATION_STATUS_APPLIED {
+			result.Status = common.RECONCILE_STATUS_PAID
+			result.Reconciled.OpHash = transaction.OpHash
+			return result, nil
+		}
+		failed = &transaction
+	}
+	if failed != nil {
+		result.Status = common.RECONCILE_STATUS_FAILED
+		result.Reconciled.OpHash = failed.OpHash
+		return result, nil
+	}
+	result.Status = common.RECONCILE_STATUS_NOT_LANDED
+	return result, nil
+}
+
+// ReconcilePayouts settles reports left in recorded before execution state.
+// Reports which did not land (or failed) are marked as failed so they become payable again.
+func ReconcilePayouts(reports []common.PayoutReport, source tezos.Address, collector common.CollectorEngine, options *common.ReconcilePayoutsOptions) (*common.ReconcilePayoutsResult, error) {
+	if collector == nil {
+		return nil, constants.ErrMissingCollectorEngine
+	}
+	if options == nil {
+		options = &common.ReconcilePayoutsOptions{}
+	}
+	if options.Now.IsZero() {
+		options.Now = time.Now()
+	}
+
+	result := &common.ReconcilePayoutsResult{
+		Reports:    make([]common.PayoutReport, 0, len(reports)),
+		Reconciled: make([]common.ReconciledPayout, 0),
+	}
+	for _, report := range reports {
+		if !report.IsInFlight() {
+			result.Reports = append(result.Reports, report)
+			continue
+		}
+
+		var reconciled common.ReconciledPayout
+		var err error
+		if !report.OpHash.Equal(tezos.ZeroOpHash) {
+			reconciled, err = reconcileByOpHash(report, collector)
+		} else {
+			reconciled, err = reconcileByRecipient(report, source, collector)
+		}
+		if err != nil {
+			return nil, errors.Join(constants.ErrReconcileFailed, err)
+		}
+
+		lookupDeadline := report.Timestamp.Add(time.Minute * constants.RECONCILE_LOOKUP_WINDOW_MINUTES)
+		if reconciled.Status == common.RECONCILE_STATUS_NOT_LANDED && options.Now.Before(lookupDeadline) {
+			reconciled.Status = common.RECONCILE_STATUS_UNRESOLVED
+			reconciled.Reason = "operation may still land, try again later"
+		}
+
+		switch reconciled.Status {
+		case common.RECONCILE_STATUS_PAID:
+			reconciled.Reconciled.IsSuccess = true
+			reconciled.Reconciled.Note = ""
+		case common.RECONCILE_STATUS_FAILED:
+			reconciled.Reconciled.Note = constants.ErrPayoutOperationFailed.Error()
+		case common.RECONCILE_STATUS_NOT_LANDED:
+			reconciled.Reconciled.Note = constants.ErrPayoutNotLanded.Error()
+		}
+		slog.Debug("reconciled payout", "recipient", report.Recipient.String(), "kind", report.Kind, "status", reconciled.Status, "op_hash", reconciled.Reconciled.OpHash.String())
+
+		result.Reports = append(result.Reports, reconciled.Reconciled)
+		result.Reconciled = append(result.Reconciled, reconciled)
+	}
+	return result, nil
+}
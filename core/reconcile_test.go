@@ -0,0 +1,94 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type mockReconcileCollector struct {
+	mock.EmptyCollector
+	transactions map[string][]common.TransactionInfo
+}
+
+func (engine *mockReconcileCollector) GetTransactions(source tezos.Address, target tezos.Address, since time.Time, until time.Time) ([]common.TransactionInfo, error) {
+	result := make([]common.TransactionInfo, 0)
+	for _, transaction := range engine.transactions[target.String()] {
+		if transaction.Timestamp.Before(since) || transaction.Timestamp.After(until) {
+			continue
+		}
+		result = append(result, transaction)
+	}
+	return result, nil
+}
+
+func TestReconcilePayouts(t *testing.T) {
+	assert := assert.New(t)
+
+	recordedAt := time.Now().Add(-2 * time.Hour)
+	inFlight := func(recipient tezos.Address, amount int64) common.PayoutReport {
+		return common.PayoutReport{
+			Cycle:     10,
+			Kind:      enums.PAYOUT_KIND_DELEGATOR_REWARD,
+			TxKind:    enums.PAYOUT_TX_KIND_TEZ,
+			Delegator: recipient,
+			Recipient: recipient,
+			Amount:    tezos.NewZ(amount),
+			Timestamp: recordedAt,
+			Note:      constants.ErrPayoutRecordedBeforeExecution.Error(),
+		}
+	}
+
+	paid, failed, missing, settled := mock.GetRandomAddress(), mock.GetRandomAddress(), mock.GetRandomAddress(), mock.GetRandomAddress()
+	appliedHash := tezos.MustParseOpHash("oneDGhZacw99EEFaYDTtWfz5QEhUW3PPVFsHa7GShnLPuDn7gSd")
+	failedHash := tezos.MustParseOpHash("onyUK7ZnQHzeNYbWSLL4zVATBtvLLk5GpPDv3VfoQPLtsBCjPX1")
+	collector := &mockReconcileCollector{
+		transactions: map[string][]common.TransactionInfo{
+			// accumulated payout carries higher amount
+			paid.String():   {{OpHash: appliedHash, Amount: tezos.NewZ(150), Status: common.OPERATION_STATUS_APPLIED, Timestamp: recordedAt.Add(time.Minute)}},
+			failed.String(): {{OpHash: failedHash, Amount: tezos.NewZ(200), Status: common.OPERATION_STATUS_FAILED, Timestamp: recordedAt.Add(time.Minute)}},
+			// too late to be the recorded payout
+			missing.String(): {{OpHash: appliedHash, Amount: tezos.NewZ(300), Status: common.OPERATION_STATUS_APPLIED, Timestamp: recordedAt.Add(3 * time.Hour)}},
+		},
+	}
+
+	reports := []common.PayoutReport{
+		inFlight(paid, 100),
+		inFlight(failed, 200),
+		inFlight(missing, 300),
+		{Cycle: 10, Recipient: settled, Amount: tezos.NewZ(400), IsSuccess: true},
+	}
+	assert.True(reports[0].IsInFlight())
+	assert.False(reports[3].IsInFlight())
+
+	result, err := ReconcilePayouts(reports, mock.GetRandomAddress(), collector, &common.ReconcilePayoutsOptions{})
+	assert.Nil(err)
+	assert.Len(result.Reports, 4)
+	assert.Len(result.Reconciled, 3)
+
+	assert.Equal(common.RECONCILE_STATUS_PAID, result.Reconciled[0].Status)
+	assert.True(result.Reports[0].IsSuccess)
+	assert.Equal(appliedHash, result.Reports[0].OpHash)
+	assert.Empty(result.Reports[0].Note)
+
+	assert.Equal(common.RECONCILE_STATUS_FAILED, result.Reconciled[1].Status)
+	assert.False(result.Reports[1].IsSuccess)
+	assert.Equal(failedHash, result.Reports[1].OpHash)
+	assert.False(result.Reports[1].IsInFlight())
+
+	assert.Equal(common.RECONCILE_STATUS_NOT_LANDED, result.Reconciled[2].Status)
+	assert.Equal(constants.ErrPayoutNotLanded.Error(), result.Reports[2].Note)
+	assert.Equal(reports[3], result.Reports[3])
+
+	// within lookup window missing operation may still land
+	result, err = ReconcilePayouts(reports[2:3], mock.GetRandomAddress(), collector, &common.ReconcilePayoutsOptions{Now: recordedAt.Add(time.Minute)})
+	assert.Nil(err)
+	assert.Equal(common.RECONCILE_STATUS_UNRESOLVED, result.Reconciled[0].Status)
+	assert.True(result.Reports[0].IsInFlight())
+}
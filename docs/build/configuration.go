@@ -36,6 +36,8 @@ func genrateSample() *tezpay_configuration.ConfigurationV0 {
 	donate := 0.025
 	donateFees := 0.05
 	donateBonds := 0.03
+	stakingFee := 0.05
+	crossCheckTolerance := 0.001
 	gasLimitBuffer := int64(200)
 	ktGasLimitBuffer := int64(400)
 	deserializationGasBuffer := int64(5)
@@ -43,8 +45,15 @@ func genrateSample() *tezpay_configuration.ConfigurationV0 {
 	ktFeeBuffer := int64(50)
 	bellowMinimumBalanceRewardDestination := enums.REWARD_DESTINATION_EVERYONE
 	maximumBalance := float64(1000.0)
+	minimumPayoutAmount := float64(5.0)
 	minimumDelayBlocks := int64(10)
 	maximumDelayBlocks := int64(250)
+	maxBatchesInFlight := 3
+	feeBumpingAfterBlocks := int64(5)
+	feeBumpingFeeIncrease := float64(0.2)
+	feeBumpingMaxBumps := 3
+	tierThousand := float64(1000)
+	tierTenThousand := float64(10000)
 
 	return &tezpay_configuration.ConfigurationV0{
 		Version:  0,
@@ -63,28 +72,56 @@ func genrateSample() *tezpay_configuration.ConfigurationV0 {
 				"tz1hZvgjekGo7DmQjWh7XnY5eLQD8wNYPczE": {
 					MaximumBalance: &maximumBalance,
 				},
+				"tz1X7U9XxVz6NDxL4DSZhijME61PW45bYUJE": {
+					PayoutInterval:      5,
+					MinimumPayoutAmount: &minimumPayoutAmount,
+				},
 			},
 			FeeOverrides: map[string][]tezos.Address{
-				"1":  {tezos.ZeroAddress, tezos.BurnAddress},
-				".5": {tezos.InvalidAddress},
+				"1":       {tezos.ZeroAddress, tezos.BurnAddress},
+				".5":      {tezos.InvalidAddress},
+				".25@801": {tezos.InvalidAddress},
+			},
+			FeeTiers: []tezpay_configuration.FeeTierV0{
+				{MaximumBalance: &tierThousand, Fee: .08},
+				{MinimumBalance: tierThousand, MaximumBalance: &tierTenThousand, Fee: .06},
+				{MinimumBalance: tierTenThousand, Fee: .04},
+			},
+			FeeTiersBalance: enums.FEE_TIER_BALANCE_TOTAL,
+			Loyalty: []tezpay_configuration.LoyaltyRuleV0{
+				{AfterCycles: 50, Discount: .01},
 			},
 			Ignore:    []tezos.Address{tezos.ZeroAddress, tezos.BurnAddress},
 			Prefilter: []tezos.Address{tezos.MustParseAddress("tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM"), tezos.MustParseAddress("tz1hZvgjekGo7DmQjWh7XnY5eLQD8wNYPczE")},
 		},
 		Network: tezpay_configuration.TezosNetworkConfigurationV0{
-			RpcUrl:                 "https://rpc.tzkt.io/mainnet",
-			RpcPool:                constants.DEFAULT_RPC_POOL,
-			TzktUrl:                constants.DEFAULT_TZKT_URL,
+			RpcUrl:    "https://rpc.tzkt.io/mainnet",
+			RpcPool:   constants.DEFAULT_RPC_POOL,
+			TzktUrl:   constants.DEFAULT_TZKT_URL,
+			Collector: enums.COLLECTOR_KIND_DEFAULT,
+			CrossCheck: &tezpay_configuration.CollectorCrossCheckV0{
+				Collector: enums.COLLECTOR_KIND_RPC,
+				Tolerance: &crossCheckTolerance,
+				Action:    enums.CROSS_CHECK_ACTION_ABORT,
+			},
 			Explorer:               "https://tzstats.com/",
 			DoNotPaySmartContracts: true,
 		},
 		Overdelegation: tezpay_configuration.OverdelegationConfigurationV0{
 			IsProtectionEnabled: true,
 		},
+		Reporting: tezpay_configuration.ReportingConfigurationV0{
+			Kind:     enums.REPORTER_KIND_SQLITE,
+			Database: "reports/reports.db",
+		},
 		PayoutConfiguration: tezpay_configuration.PayoutConfigurationV0{
-			WalletMode:                 enums.WALLET_MODE_LOCAL_PRIVATE_KEY,
-			PayoutMode:                 enums.PAYOUT_MODE_IDEAL,
-			Fee:                        .075,
+			WalletMode: enums.WALLET_MODE_LOCAL_PRIVATE_KEY,
+			PayoutMode: enums.PAYOUT_MODE_IDEAL,
+			Fee:        .075,
+			FeeSchedule: []tezpay_configuration.FeeScheduleEntryV0{
+				{FromCycle: 801, Fee: .08},
+			},
+			StakingFee:                 &stakingFee,
 			IsPayingTxFee:              true,
 			IsPayingAllocationTxFee:    true,
 			MinimumAmount:              10.5,
@@ -95,6 +132,16 @@ func genrateSample() *tezpay_configuration.ConfigurationV0 {
 			KtTxFeeBuffer:              &ktFeeBuffer,
 			MinimumDelayBlocks:         &minimumDelayBlocks,
 			MaximumDelayBlocks:         &maximumDelayBlocks,
+			MaxBatchesInFlight:         &maxBatchesInFlight,
+			FeeBumping: &tezpay_configuration.FeeBumpingConfigurationV0{
+				AfterBlocks: &feeBumpingAfterBlocks,
+				FeeIncrease: &feeBumpingFeeIncrease,
+				MaxBumps:    &feeBumpingMaxBumps,
+			},
+			Multisig: &tezpay_configuration.MultisigConfigurationV0{
+				Contract: tezos.MustParseAddress("KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"),
+				Signers:  []string{"keyfile:multisig-signer.key", "remote:tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM@https://signer.example.com"},
+			},
 		},
 		NotificationConfigurations: []json.RawMessage{
 			json.RawMessage(`{
@@ -183,6 +230,28 @@ func genrateSample() *tezpay_configuration.ConfigurationV0 {
 					Mode: enums.EXTENSION_HOOK_MODE_READ_WRITE,
 				}},
 				Configuration: &feeExtensionConfiguration,
+				Permissions: map[enums.EExtensionHook]common.ExtensionPermissions{
+					enums.EXTENSION_HOOK_AFTER_CANDIDATES_GENERATED: {
+						MutableFields: []string{"fee_rate", "note"},
+					},
+				},
+			},
+			common.ExtensionDefinition{
+				Name: "remote-extension",
+				Url:  "wss://extensions.example.com/tezpay",
+				Kind: enums.EXTENSION_WS_RPC,
+				Hooks: []common.ExtensionHook{{
+					Id:   enums.EXTENSION_HOOK_AFTER_PAYOUTS_PREPARED,
+					Mode: enums.EXTENSION_HOOK_MODE_READ_ONLY,
+				}},
+				Headers: map[string]string{"Authorization": "Bearer <token>"},
+				Tls: &common.ExtensionTlsOptions{
+					CaFile: "/path/to/ca.pem",
+				},
+				Reconnect: &common.ExtensionReconnectOptions{
+					Attempts: 3,
+					Delay:    5,
+				},
 			},
 		},
 		DisableAnalytics: true,
@@ -6,9 +6,12 @@ import (
 	"os"
 
 	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
 	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/core/execute"
 	"github.com/tez-capital/tezpay/core/generate"
 	"github.com/tez-capital/tezpay/core/prepare"
+	"github.com/tez-capital/tezpay/utils"
 	"github.com/trilitech/tzgo/tezos"
 )
 
@@ -33,6 +36,26 @@ func GenerateHookSampleData() {
 		Fee: tezos.NewZ(1000000000),
 	}
 
+	acd := generate.AfterCycleDataCollectedHookData{
+		Cycle: 580,
+		CycleData: &common.BakersCycleData{
+			OwnDelegatedBalance:          tezos.NewZ(1000000000),
+			ExternalDelegatedBalance:     tezos.NewZ(1000000000),
+			BlockDelegatedRewards:        tezos.NewZ(1000000),
+			AttestationsDelegatedRewards: tezos.NewZ(1000000),
+			BlockDelegatedFees:           tezos.NewZ(1000),
+			DelegatorsCount:              1,
+			OwnStakedBalance:             tezos.NewZ(1000000000),
+			ExternalStakedBalance:        tezos.NewZ(1000000000),
+			StakersCount:                 1,
+			Delegators: []common.Delegator{{
+				Address:          tezos.ZeroAddress,
+				DelegatedBalance: tezos.NewZ(1000000000),
+				StakedBalance:    tezos.NewZ(1000000000),
+			}},
+		},
+	}
+
 	acg := generate.AfterCandidateGeneratedHookData{
 		Cycle:      580,
 		Candidates: []generate.PayoutCandidate{payoutCandidate.PayoutCandidate},
@@ -78,9 +101,28 @@ func GenerateHookSampleData() {
 		ReportsOfPastSuccesfulPayouts: common.NewSuccessBatchResult([]*common.AccumulatedPayoutRecipe{recipe.AsAccumulated()}, tezos.ZeroOpHash).ToIndividualReports(),
 	}
 
+	successfulBatch := common.NewSuccessBatchResult([]*common.AccumulatedPayoutRecipe{recipe.AsAccumulated()}, tezos.ZeroOpHash)
+	failedBatch := common.NewFailedBatchResultWithOpHash([]*common.AccumulatedPayoutRecipe{recipe.AsAccumulated()}, tezos.ZeroOpHash, constants.ErrOperationConfirmationFailed)
+	aba := execute.NewAfterBatchAppliedHookData("1/2", successfulBatch, false)
+	ape := execute.AfterPayoutsExecutedHookData{
+		Batches: []execute.AfterBatchAppliedHookData{
+			aba,
+			execute.NewAfterBatchAppliedHookData("2/2", failedBatch, false),
+		},
+		InvalidPayouts: []common.PayoutReport{recipe.ToPayoutReport()},
+		Summary:        *utils.GeneratePayoutSummary([]*common.CyclePayoutBlueprint{{Cycle: 1}}, successfulBatch.ToIndividualReports()),
+	}
+
 	result := "\n"
 	result += "NOTE: *all bellow examples are just sample data to showcase fields used in data passed to hooks.*\n\n"
 
+	result += fmt.Sprintf("## %s\n\n", enums.EXTENSION_HOOK_AFTER_CYCLE_DATA_COLLECTED)
+	result += "This hook is capable of mutating data. Resulting cycle data may not contain negative balances or duplicated delegators.\n"
+	result += "```json\n"
+	acdSerialized, _ := json.MarshalIndent(acd, "", "  ")
+	result += string(acdSerialized)
+	result += "\n```\n\n"
+
 	result += fmt.Sprintf("## %s\n\n", enums.EXTENSION_HOOK_AFTER_CANDIDATES_GENERATED)
 	result += "This hook is capable of mutating data.\n"
 	result += "```json\n"
@@ -123,6 +165,20 @@ func GenerateHookSampleData() {
 	result += string(appSerialized)
 	result += "\n```\n\n"
 
+	result += fmt.Sprintf("## %s\n\n", enums.EXTENSION_HOOK_AFTER_BATCH_APPLIED)
+	result += "This hook is NOT capable of mutating data. It is executed for each batch as soon as its result is final, batches sent again after failure have 'recovery <n>' batch id.\n"
+	result += "```json\n"
+	abaSerialized, _ := json.MarshalIndent(aba, "", "  ")
+	result += string(abaSerialized)
+	result += "\n```\n\n"
+
+	result += fmt.Sprintf("## %s\n\n", enums.EXTENSION_HOOK_AFTER_PAYOUTS_EXECUTED)
+	result += "This hook is NOT capable of mutating data. It is executed once all batches are executed and reports are written.\n"
+	result += "```json\n"
+	apeSerialized, _ := json.MarshalIndent(ape, "", "  ")
+	result += string(apeSerialized)
+	result += "\n```\n\n"
+
 	// write to docs/extensions/Hooks.md
 	os.WriteFile("docs/extensions/Hooks.md", []byte(result), 0644)
 }
@@ -0,0 +1,158 @@
+package collector_engines
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+const CROSS_CHECK_MAX_REPORTED_DELEGATORS = 5
+
+// CrossCheckCollector uses the primary collector for everything and compares cycle data with the secondary one
+type CrossCheckCollector struct {
+	common.CollectorEngine
+	secondary   common.CollectorEngine
+	tolerance   float64
+	action      enums.ECrossCheckAction
+	adminNotify func(string)
+}
+
+func NewCrossCheckCollector(primary common.CollectorEngine, secondary common.CollectorEngine, crossCheck *configuration.RuntimeCollectorCrossCheck, adminNotify func(string)) *CrossCheckCollector {
+	if adminNotify == nil {
+		adminNotify = func(string) {}
+	}
+	return &CrossCheckCollector{
+		CollectorEngine: primary,
+		secondary:       secondary,
+		tolerance:       crossCheck.Tolerance,
+		action:          crossCheck.Action,
+		adminNotify:     adminNotify,
+	}
+}
+
+func (engine *CrossCheckCollector) GetId() string {
+	return fmt.Sprintf("CrossCheckCollector(%s, %s)", engine.CollectorEngine.GetId(), engine.secondary.GetId())
+}
+
+func (engine *CrossCheckCollector) mismatch(msg string) error {
+	engine.adminNotify(msg)
+	if engine.action == enums.CROSS_CHECK_ACTION_NOTIFY {
+		slog.Warn(msg)
+		return nil
+	}
+	return errors.Join(constants.ErrCycleDataMismatch, errors.New(msg))
+}
+
+func (engine *CrossCheckCollector) GetCycleStakingData(baker tezos.Address, cycle int64) (*common.BakersCycleData, error) {
+	data, err := engine.CollectorEngine.GetCycleStakingData(baker, cycle)
+	if err != nil {
+		return nil, err
+	}
+
+	secondaryData, err := engine.secondary.GetCycleStakingData(baker, cycle)
+	if err != nil {
+		msg := fmt.Sprintf("failed to cross check cycle %d data of %s with %s - %s", cycle, baker.String(), engine.secondary.GetId(), err.Error())
+		if err := engine.mismatch(msg); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+
+	differences := compareCycleData(data, secondaryData, engine.tolerance)
+	if len(differences) == 0 {
+		slog.Debug("cycle data cross checked", "cycle", cycle, "baker", baker.String(), "collector", engine.secondary.GetId())
+		return data, nil
+	}
+	msg := fmt.Sprintf("cycle %d data of %s differ between %s and %s:\n%s", cycle, baker.String(),
+		engine.CollectorEngine.GetId(), engine.secondary.GetId(), strings.Join(differences, "\n"))
+	if err := engine.mismatch(msg); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func isWithinTolerance(a tezos.Z, b tezos.Z, tolerance float64) bool {
+	if a.Equal(b) {
+		return true
+	}
+	reference := math.Max(math.Abs(float64(a.Int64())), math.Abs(float64(b.Int64())))
+	return math.Abs(float64(a.Int64()-b.Int64()))/reference <= tolerance
+}
+
+func formatAddresses(addresses []string) string {
+	if len(addresses) > CROSS_CHECK_MAX_REPORTED_DELEGATORS {
+		return fmt.Sprintf("%s and %d more", strings.Join(addresses[:CROSS_CHECK_MAX_REPORTED_DELEGATORS], ", "), len(addresses)-CROSS_CHECK_MAX_REPORTED_DELEGATORS)
+	}
+	return strings.Join(addresses, ", ")
+}
+
+// compareCycleData returns human readable differences exceeding the relative tolerance,
+// delegators without any balance are not considered part of the delegator set
+func compareCycleData(a *common.BakersCycleData, b *common.BakersCycleData, tolerance float64) []string {
+	differences := make([]string, 0)
+	compare := func(name string, x tezos.Z, y tezos.Z) {
+		if !isWithinTolerance(x, y, tolerance) {
+			differences = append(differences, fmt.Sprintf("%s: %s != %s", name, x.String(), y.String()))
+		}
+	}
+
+	compare("own delegated balance", a.OwnDelegatedBalance, b.OwnDelegatedBalance)
+	compare("external delegated balance", a.ExternalDelegatedBalance, b.ExternalDelegatedBalance)
+	compare("own staked balance", a.OwnStakedBalance, b.OwnStakedBalance)
+	compare("external staked balance", a.ExternalStakedBalance, b.ExternalStakedBalance)
+	compare("actual delegated rewards", a.GetTotalDelegatedRewards(enums.PAYOUT_MODE_ACTUAL), b.GetTotalDelegatedRewards(enums.PAYOUT_MODE_ACTUAL))
+	compare("ideal delegated rewards", a.GetTotalDelegatedRewards(enums.PAYOUT_MODE_IDEAL), b.GetTotalDelegatedRewards(enums.PAYOUT_MODE_IDEAL))
+	compare("staking rewards edge", a.GetStakingRewardsEdge(), b.GetStakingRewardsEdge())
+	compare("staking rewards shared", a.GetStakingRewardsShared(), b.GetStakingRewardsShared())
+
+	delegators := make(map[string]common.Delegator, len(b.Delegators))
+	for _, delegator := range b.Delegators {
+		if delegator.DelegatedBalance.IsZero() && delegator.StakedBalance.IsZero() {
+			continue
+		}
+		delegators[delegator.Address.String()] = delegator
+	}
+
+	missing, mismatched := make([]string, 0), make([]string, 0)
+	for _, delegator := range a.Delegators {
+		if delegator.DelegatedBalance.IsZero() && delegator.StakedBalance.IsZero() {
+			continue
+		}
+		address := delegator.Address.String()
+		other, ok := delegators[address]
+		if !ok {
+			missing = append(missing, address)
+			continue
+		}
+		delete(delegators, address)
+		if !isWithinTolerance(delegator.DelegatedBalance, other.DelegatedBalance, tolerance) ||
+			!isWithinTolerance(delegator.StakedBalance, other.StakedBalance, tolerance) {
+			mismatched = append(mismatched, address)
+		}
+	}
+	extra := make([]string, 0, len(delegators))
+	for _, delegator := range b.Delegators {
+		if _, ok := delegators[delegator.Address.String()]; ok {
+			extra = append(extra, delegator.Address.String())
+		}
+	}
+
+	if len(missing) > 0 {
+		differences = append(differences, fmt.Sprintf("delegators missing in second source: %s", formatAddresses(missing)))
+	}
+	if len(extra) > 0 {
+		differences = append(differences, fmt.Sprintf("delegators missing in first source: %s", formatAddresses(extra)))
+	}
+	if len(mismatched) > 0 {
+		differences = append(differences, fmt.Sprintf("delegators with different balances: %s", formatAddresses(mismatched)))
+	}
+	return differences
+}
@@ -0,0 +1,92 @@
+package collector_engines
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func getCrossCheckCycleData(delegators ...common.Delegator) *common.BakersCycleData {
+	return &common.BakersCycleData{
+		OwnDelegatedBalance:          tezos.NewZ(10_000_000),
+		ExternalDelegatedBalance:     tezos.NewZ(5_000_000),
+		BlockDelegatedRewards:        tezos.NewZ(100_000),
+		AttestationsDelegatedRewards: tezos.NewZ(200_000),
+		Delegators:                   delegators,
+	}
+}
+
+func TestCompareCycleData(t *testing.T) {
+	assert := assert.New(t)
+
+	a, b, c := mock.GetRandomAddress(), mock.GetRandomAddress(), mock.GetRandomAddress()
+	first := getCrossCheckCycleData(
+		common.Delegator{Address: a, DelegatedBalance: tezos.NewZ(1_000_000)},
+		common.Delegator{Address: b, DelegatedBalance: tezos.NewZ(2_000_000)},
+		common.Delegator{Address: c, Emptied: true},
+	)
+
+	// emptied delegators are not part of the delegator set
+	second := getCrossCheckCycleData(
+		common.Delegator{Address: b, DelegatedBalance: tezos.NewZ(2_000_000)},
+		common.Delegator{Address: a, DelegatedBalance: tezos.NewZ(1_000_500)},
+	)
+	assert.Empty(compareCycleData(first, second, 0.001))
+	assert.Len(compareCycleData(first, second, 0), 1)
+
+	second = getCrossCheckCycleData(
+		common.Delegator{Address: a, DelegatedBalance: tezos.NewZ(1_000_000)},
+		common.Delegator{Address: c, DelegatedBalance: tezos.NewZ(2_000_000)},
+	)
+	second.BlockDelegatedRewards = tezos.NewZ(50_000)
+	differences := compareCycleData(first, second, 0.001)
+	assert.Equal([]string{
+		"actual delegated rewards: 300000 != 250000",
+		"delegators missing in second source: " + b.String(),
+		"delegators missing in first source: " + c.String(),
+	}, differences)
+}
+
+type staticCycleDataCollector struct {
+	common.CollectorEngine
+	data *common.BakersCycleData
+}
+
+func (engine *staticCycleDataCollector) GetId() string {
+	return "staticCycleDataCollector"
+}
+
+func (engine *staticCycleDataCollector) GetCycleStakingData(baker tezos.Address, cycle int64) (*common.BakersCycleData, error) {
+	return engine.data, nil
+}
+
+func TestCrossCheckCollector(t *testing.T) {
+	assert := assert.New(t)
+
+	primary := &staticCycleDataCollector{data: getCrossCheckCycleData()}
+	secondaryData := getCrossCheckCycleData()
+	secondaryData.ExternalDelegatedBalance = tezos.NewZ(6_000_000)
+	secondary := &staticCycleDataCollector{data: secondaryData}
+
+	notified := 0
+	crossCheck := &configuration.RuntimeCollectorCrossCheck{Tolerance: 0.001, Action: enums.CROSS_CHECK_ACTION_ABORT}
+	collector := NewCrossCheckCollector(primary, secondary, crossCheck, func(string) { notified++ })
+
+	_, err := collector.GetCycleStakingData(mock.GetRandomAddress(), 800)
+	assert.True(errors.Is(err, constants.ErrCycleDataMismatch))
+	assert.Equal(1, notified)
+
+	crossCheck.Action = enums.CROSS_CHECK_ACTION_NOTIFY
+	collector = NewCrossCheckCollector(primary, secondary, crossCheck, func(string) { notified++ })
+	data, err := collector.GetCycleStakingData(mock.GetRandomAddress(), 800)
+	assert.Nil(err)
+	assert.Equal(primary.data, data)
+	assert.Equal(2, notified)
+}
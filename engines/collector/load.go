@@ -0,0 +1,48 @@
+package collector_engines
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+)
+
+func loadCollector(config *configuration.RuntimeConfiguration) (common.CollectorEngine, error) {
+	switch config.Network.Collector {
+	case enums.COLLECTOR_KIND_DEFAULT, "":
+		collector, err := InitDefaultRpcAndTzktColletor(config)
+		if err != nil {
+			return nil, errors.Join(constants.ErrCollectorLoadFailed, err)
+		}
+		return collector, nil
+	case enums.COLLECTOR_KIND_RPC:
+		collector, err := InitRpcCollector(config)
+		if err != nil {
+			return nil, errors.Join(constants.ErrCollectorLoadFailed, err)
+		}
+		return collector, nil
+	default:
+		return nil, errors.Join(constants.ErrCollectorLoadFailed, fmt.Errorf("unsupported collector kind: %s", config.Network.Collector))
+	}
+}
+
+// Load initializes the configured collector, wrapped in a cross check collector if a second source is configured
+func Load(config *configuration.RuntimeConfiguration, adminNotify func(string)) (common.CollectorEngine, error) {
+	collector, err := loadCollector(config)
+	if err != nil || config.Network.CrossCheck == nil {
+		return collector, err
+	}
+
+	secondaryConfig := *config
+	secondaryConfig.Network.Collector = config.Network.CrossCheck.Collector
+	secondaryConfig.Network.TzktUrl = config.Network.CrossCheck.TzktUrl
+	secondaryConfig.Network.CrossCheck = nil
+	secondary, err := loadCollector(&secondaryConfig)
+	if err != nil {
+		return nil, err
+	}
+	return NewCrossCheckCollector(collector, secondary, config.Network.CrossCheck, adminNotify), nil
+}
@@ -0,0 +1,515 @@
+package collector_engines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/engines/tzkt"
+	"github.com/tez-capital/tezpay/utils"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/rpc"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// RpcCollector collects all data from rpc nodes only, cycle data requires archive node.
+// Transaction and delegation history is not available from rpc, tzkt is used for it if configured.
+type RpcCollector struct {
+	rpcs    []*rpc.Client
+	history *tzkt.Client
+
+	mtx             sync.Mutex
+	operationHashes map[int64][]tezos.OpHash
+}
+
+var (
+	defaultCtx context.Context = context.Background()
+)
+
+func initRpcCollector(config *configuration.RuntimeConfiguration, httpClient *http.Client) (*RpcCollector, error) {
+	rpc_clients, err := utils.InitializeRpcClients(context.Background(), config.Network.RpcPool, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RpcCollector{
+		rpcs:            rpc_clients,
+		operationHashes: make(map[int64][]tezos.OpHash),
+	}
+	return result, result.RefreshParams()
+}
+
+func InitRpcCollector(config *configuration.RuntimeConfiguration) (*RpcCollector, error) {
+	httpClient := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+	collector, err := initRpcCollector(config, httpClient)
+	if err != nil || config.Network.TzktUrl == "" {
+		return collector, err
+	}
+	collector.history, err = tzkt.InitClient(config.Network.TzktUrl, &tzkt.TzktClientOptions{
+		HttpClient: httpClient,
+	})
+	return collector, err
+}
+
+func (engine *RpcCollector) GetId() string {
+	return "RpcCollector"
+}
+
+func (engine *RpcCollector) RefreshParams() error {
+	failures := 0
+	for _, rpc := range engine.rpcs {
+		err := rpc.Init(context.Background())
+		if err != nil {
+			slog.Debug("failed to refresh rpc params", "error", err.Error(), "rpc_url", rpc.BaseURL.String())
+			failures++
+		}
+	}
+	if failures == len(engine.rpcs) {
+		return fmt.Errorf("failed to refresh rpc params for all clients, all %d failed", failures)
+	}
+	return nil
+}
+
+func (engine *RpcCollector) GetCurrentProtocol() (tezos.ProtocolHash, error) {
+	params, err := utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (*tezos.Params, error) {
+		return client.GetParams(context.Background(), rpc.Head)
+	})
+	if err != nil {
+		return tezos.ZeroProtocolHash, err
+	}
+	return params.Protocol, nil
+}
+
+func (engine *RpcCollector) IsRevealed(addr tezos.Address) (bool, error) {
+	state, err := utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (*rpc.ContractInfo, error) {
+		return client.GetContractExt(defaultCtx, addr, rpc.Head)
+	})
+	if err != nil {
+		return false, err
+	}
+	return state.IsRevealed(), nil
+}
+
+func (engine *RpcCollector) GetManagerKey(addr tezos.Address) (tezos.Key, error) {
+	return utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (tezos.Key, error) {
+		return client.GetManagerKey(defaultCtx, addr, rpc.Head)
+	})
+}
+
+func (engine *RpcCollector) GetCurrentCycleNumber() (int64, error) {
+	head, err := utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (*rpc.BlockMetadata, error) {
+		return client.GetBlockMetadata(defaultCtx, rpc.Head)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return head.LevelInfo.Cycle, nil
+}
+
+func (engine *RpcCollector) GetLastCompletedCycle() (int64, error) {
+	cycle, err := engine.GetCurrentCycleNumber()
+	return cycle - 1, err
+}
+
+func (engine *RpcCollector) GetChainId() (tezos.ChainIdHash, error) {
+	chainId, err := utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (tezos.ChainIdHash, error) {
+		return client.GetChainId(defaultCtx)
+	})
+	return chainId, err
+}
+
+func (engine *RpcCollector) GetBranch(offset int64) (hash tezos.BlockHash, err error) {
+	hash, err = utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (tezos.BlockHash, error) {
+		return client.GetBlockHash(context.Background(), rpc.NewBlockOffset(rpc.Head, offset))
+	})
+	return
+}
+
+func (engine *RpcCollector) Simulate(o *codec.Op, publicKey tezos.Key) (rcpt *rpc.Receipt, err error) {
+	params, err := utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (*tezos.Params, error) {
+		return client.GetParams(context.Background(), rpc.Head)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	o = o.WithParams(params)
+	for i := 0; i < 5; i++ {
+		_, err = utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (bool, error) {
+			err := client.Complete(context.Background(), o, publicKey)
+			if err != nil {
+				return false, err
+			}
+
+			rcpt, err = client.Simulate(context.Background(), o, nil)
+			if err != nil && rcpt == nil { // we do not retry on receipt errors
+				slog.Debug("Internal simulate error - likely networking, retrying", "error", err.Error())
+				// sleep 5s * i
+				time.Sleep(time.Duration(i*5) * time.Second)
+				return false, err
+			}
+			return true, nil
+		})
+		if err == nil {
+			break
+		}
+	}
+	return rcpt, err
+}
+
+func (engine *RpcCollector) GetBalance(addr tezos.Address) (tezos.Z, error) {
+	return utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (tezos.Z, error) {
+		return client.GetContractBalance(context.Background(), addr, rpc.Head)
+	})
+}
+
+func (engine *RpcCollector) CreateCycleMonitor(options common.CycleMonitorOptions) (common.CycleMonitor, error) {
+	ctx := context.Background()
+	monitor, err := utils.AttemptWithRpcClients(ctx, engine.rpcs, func(client *rpc.Client) (common.CycleMonitor, error) {
+		return common.NewCycleMonitor(ctx, client, options)
+	})
+	if err != nil {
+		return nil, err
+	}
+	utils.CallbackOnInterrupt(ctx, monitor.Cancel)
+	slog.Info("tracking cycles... (cancel with Ctrl-C/SIGINT)\n\n")
+	return monitor, nil
+}
+
+func (engine *RpcCollector) SendAnalytics(bakerId string, version string) {
+	go func() {
+		body := fmt.Sprintf(`{"bakerId": "%s", "version": "%s"}`, bakerId, version)
+		resp, err := http.Post("https://analytics.tez.capital/pay", "application/json", strings.NewReader(body))
+		if err != nil {
+			return
+		}
+		defer resp.Body.Close()
+	}()
+}
+
+const (
+	// baking rights of the baker are checked up to this round when looking for baked blocks
+	RPC_COLLECTOR_MAX_BAKING_ROUND = 5
+)
+
+func getFromRpc[T any](engine *RpcCollector, path string) (T, error) {
+	return utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (T, error) {
+		var result T
+		err := client.Get(defaultCtx, path, &result)
+		return result, err
+	})
+}
+
+type cycleLevels struct {
+	First int64 `json:"first"`
+	Last  int64 `json:"last"`
+}
+
+func (engine *RpcCollector) getCycleLevels(cycle int64) (cycleLevels, error) {
+	currentCycle, err := engine.GetCurrentCycleNumber()
+	if err != nil {
+		return cycleLevels{}, err
+	}
+	return getFromRpc[cycleLevels](engine, fmt.Sprintf("chains/main/blocks/head/helpers/levels_in_current_cycle?offset=%d", cycle-currentCycle))
+}
+
+func (engine *RpcCollector) getBalances(addr tezos.Address, block rpc.BlockID) (delegated tezos.Z, staked tezos.Z, err error) {
+	fullBalance, err := getFromRpc[tezos.Z](engine, fmt.Sprintf("chains/main/blocks/%s/context/contracts/%s/full_balance", block, addr))
+	if err != nil {
+		return tezos.Zero, tezos.Zero, err
+	}
+	staked, err = getFromRpc[tezos.Z](engine, fmt.Sprintf("chains/main/blocks/%s/context/contracts/%s/staked_balance", block, addr))
+	if err != nil {
+		return tezos.Zero, tezos.Zero, err
+	}
+	return fullBalance.Sub(staked), staked, nil
+}
+
+// isEmptied checks whether implicit account has no balance left at head, such accounts are deallocated
+func (engine *RpcCollector) isEmptied(addr tezos.Address) (bool, error) {
+	if addr.IsContract() {
+		return false, nil
+	}
+	balance, err := getFromRpc[tezos.Z](engine, fmt.Sprintf("chains/main/blocks/head/context/contracts/%s/full_balance", addr))
+	if err != nil {
+		return false, err
+	}
+	return balance.IsZero(), nil
+}
+
+// collectBalances fills balances of the baker and its delegators as of the block the cycle stake snapshot was taken from
+func (engine *RpcCollector) collectBalances(baker tezos.Address, block rpc.BlockID, cycleData *common.BakersCycleData) error {
+	delegate, err := utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (*rpc.Delegate, error) {
+		return client.GetDelegate(defaultCtx, baker, block)
+	})
+	if err != nil {
+		return err
+	}
+	delegators := delegate.Delegators
+	if len(delegators) == 0 {
+		delegators = delegate.DelegatedContracts
+	}
+
+	cycleData.OwnDelegatedBalance, cycleData.OwnStakedBalance, err = engine.getBalances(baker, block)
+	if err != nil {
+		return err
+	}
+
+	cycleData.Delegators = make([]common.Delegator, 0, len(delegators))
+	for _, addr := range delegators {
+		if addr.Equal(baker) {
+			continue
+		}
+		delegated, staked, err := engine.getBalances(addr, block)
+		if err != nil {
+			return err
+		}
+		emptied, err := engine.isEmptied(addr)
+		if err != nil {
+			return err
+		}
+		cycleData.Delegators = append(cycleData.Delegators, common.Delegator{
+			Address:          addr,
+			DelegatedBalance: delegated,
+			StakedBalance:    staked,
+			Emptied:          emptied,
+		})
+		cycleData.ExternalDelegatedBalance = cycleData.ExternalDelegatedBalance.Add(delegated)
+		cycleData.ExternalStakedBalance = cycleData.ExternalStakedBalance.Add(staked)
+		if !staked.IsZero() {
+			cycleData.StakersCount++
+		}
+	}
+	cycleData.DelegatorsCount = int32(len(cycleData.Delegators))
+	return nil
+}
+
+// collectRewards goes through blocks the baker could bake in the cycle and the last block of the cycle
+// where attestation rewards are distributed, blocks produced by others in place of the baker are counted as missed
+func (engine *RpcCollector) collectRewards(baker tezos.Address, cycle int64, levels cycleLevels) (*bakerCycleRewards, error) {
+	rights, err := getFromRpc[[]rpc.BakingRight](engine, fmt.Sprintf("chains/main/blocks/%d/helpers/baking_rights?cycle=%d&delegate=%s&max_round=%d", levels.First, cycle, baker, RPC_COLLECTOR_MAX_BAKING_ROUND))
+	if err != nil {
+		return nil, err
+	}
+	rightRounds := make(map[int64]int, len(rights))
+	for _, right := range rights {
+		if round, ok := rightRounds[right.Level]; !ok || right.Round < round {
+			rightRounds[right.Level] = right.Round
+		}
+	}
+	blockLevels := lo.Uniq(append(lo.Map(rights, func(right rpc.BakingRight, _ int) int64 {
+		return right.Level
+	}), levels.Last))
+
+	rewards := newBakerCycleRewards(baker)
+	for _, level := range blockLevels {
+		metadata, err := utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (*rpc.BlockMetadata, error) {
+			return client.GetBlockMetadata(defaultCtx, rpc.BlockLevel(level))
+		})
+		if err != nil {
+			return nil, err
+		}
+		rewards.AddBalanceUpdates(metadata.BalanceUpdates)
+
+		round, hasRight := rightRounds[level]
+		if !hasRight || metadata.Baker.Equal(baker) || metadata.Proposer.Equal(baker) {
+			continue
+		}
+		missed := round == 0
+		if !missed {
+			header, err := utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (*rpc.BlockHeader, error) {
+				return client.GetBlockHeader(defaultCtx, rpc.BlockLevel(level))
+			})
+			if err != nil {
+				return nil, err
+			}
+			missed = header.PayloadRound > round
+		}
+		if missed {
+			rewards.AddMissedBlock(metadata.BalanceUpdates)
+		}
+	}
+	return rewards, nil
+}
+
+func (engine *RpcCollector) GetCycleStakingData(baker tezos.Address, cycle int64) (*common.BakersCycleData, error) {
+	params, err := utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (*tezos.Params, error) {
+		return client.GetParams(defaultCtx, rpc.Head)
+	})
+	if err != nil {
+		return nil, errors.Join(constants.ErrCycleDataFetchFailed, err)
+	}
+	levels, err := engine.getCycleLevels(cycle)
+	if err != nil {
+		return nil, errors.Join(constants.ErrCycleDataFetchFailed, err)
+	}
+	// rights for the cycle are based on stake at the end of cycle - consensus_rights_delay - 1
+	snapshotLevels, err := engine.getCycleLevels(cycle - params.ConsensusRightsDelay - 1)
+	if err != nil {
+		return nil, errors.Join(constants.ErrCycleDataFetchFailed, err)
+	}
+
+	slog.Debug("collecting cycle data from rpc", "baker", baker.String(), "cycle", cycle, "snapshot_level", snapshotLevels.Last)
+	cycleData := &common.BakersCycleData{}
+	if err := engine.collectBalances(baker, rpc.BlockLevel(snapshotLevels.Last), cycleData); err != nil {
+		return nil, errors.Join(constants.ErrCycleDataFetchFailed, err)
+	}
+	rewards, err := engine.collectRewards(baker, cycle, levels)
+	if err != nil {
+		return nil, errors.Join(constants.ErrCycleDataFetchFailed, err)
+	}
+	rewards.ApplyTo(cycleData)
+	return cycleData, nil
+}
+
+func (engine *RpcCollector) getFirstBlockCycleAfterTimestamp(timestamp time.Time) (int64, error) {
+	getHeader := func(block rpc.BlockID) (*rpc.BlockHeader, error) {
+		return utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (*rpc.BlockHeader, error) {
+			return client.GetBlockHeader(defaultCtx, block)
+		})
+	}
+
+	head, err := getHeader(rpc.Head)
+	if err != nil {
+		return 0, errors.Join(constants.ErrCycleDataFetchFailed, err)
+	}
+	if !head.Timestamp.After(timestamp) {
+		return 0, errors.Join(constants.ErrCycleDataFetchFailed, fmt.Errorf("no cycles found"))
+	}
+
+	low, high := int64(1), head.Level
+	for low < high {
+		middle := low + (high-low)/2
+		header, err := getHeader(rpc.BlockLevel(middle))
+		if err != nil {
+			return 0, errors.Join(constants.ErrCycleDataFetchFailed, err)
+		}
+		if header.Timestamp.After(timestamp) {
+			high = middle
+		} else {
+			low = middle + 1
+		}
+	}
+
+	metadata, err := utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (*rpc.BlockMetadata, error) {
+		return client.GetBlockMetadata(defaultCtx, rpc.BlockLevel(low))
+	})
+	if err != nil {
+		return 0, errors.Join(constants.ErrCycleDataFetchFailed, err)
+	}
+	return metadata.LevelInfo.Cycle, nil
+}
+
+func (engine *RpcCollector) GetCyclesInDateRange(startDate time.Time, endDate time.Time) ([]int64, error) {
+	firstCycle, err := engine.getFirstBlockCycleAfterTimestamp(startDate)
+	if err != nil {
+		return nil, err
+	}
+	firstCycleAfterTheRange, err := engine.getFirstBlockCycleAfterTimestamp(endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	cycles := make([]int64, 0, 20)
+	for cycle := firstCycle; cycle < firstCycleAfterTheRange; cycle++ {
+		cycles = append(cycles, cycle)
+	}
+	return cycles, nil
+}
+
+func (engine *RpcCollector) getManagerOperationHashes(level int64) ([]tezos.OpHash, error) {
+	engine.mtx.Lock()
+	hashes, ok := engine.operationHashes[level]
+	engine.mtx.Unlock()
+	if ok {
+		return hashes, nil
+	}
+
+	hashes, err := utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) ([]tezos.OpHash, error) {
+		return client.GetBlockOperationListHashes(defaultCtx, rpc.BlockLevel(level), 3)
+	})
+	if err != nil {
+		return nil, err
+	}
+	engine.mtx.Lock()
+	defer engine.mtx.Unlock()
+	engine.operationHashes[level] = hashes
+	return hashes, nil
+}
+
+// pruneOperationHashes drops cached hashes of levels below the lowest level operations can be looked up in
+func (engine *RpcCollector) pruneOperationHashes(lowestLevel int64) {
+	engine.mtx.Lock()
+	defer engine.mtx.Unlock()
+	for level := range engine.operationHashes {
+		if level < lowestLevel {
+			delete(engine.operationHashes, level)
+		}
+	}
+}
+
+// WasOperationApplied looks for the operation within max operations ttl blocks,
+// older operations can not be found without an indexer and are reported as unknown
+func (engine *RpcCollector) WasOperationApplied(opHash tezos.OpHash) (common.OperationStatus, error) {
+	head, err := utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (*rpc.BlockMetadata, error) {
+		return client.GetBlockMetadata(defaultCtx, rpc.Head)
+	})
+	if err != nil {
+		return common.OPERATION_STATUS_UNKNOWN, errors.Join(constants.ErrOperationStatusCheckFailed, err)
+	}
+
+	lowestLevel := head.LevelInfo.Level - int64(head.MaxOperationsTTL) + 1
+	engine.pruneOperationHashes(lowestLevel)
+	for level := head.LevelInfo.Level; level > 0 && level >= lowestLevel; level-- {
+		hashes, err := engine.getManagerOperationHashes(level)
+		if err != nil {
+			return common.OPERATION_STATUS_UNKNOWN, errors.Join(constants.ErrOperationStatusCheckFailed, err)
+		}
+		index := lo.IndexOf(hashes, opHash)
+		if index < 0 {
+			continue
+		}
+
+		op, err := utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (*rpc.Operation, error) {
+			return client.GetBlockOperation(defaultCtx, rpc.BlockLevel(level), 3, index)
+		})
+		if err != nil {
+			return common.OPERATION_STATUS_UNKNOWN, errors.Join(constants.ErrOperationStatusCheckFailed, err)
+		}
+		receipt := rpc.Receipt{Op: op}
+		if receipt.IsSuccess() {
+			return common.OPERATION_STATUS_APPLIED, nil
+		}
+		return common.OPERATION_STATUS_FAILED, nil
+	}
+	return common.OPERATION_STATUS_UNKNOWN, nil
+}
+
+// GetTransactions falls back to tzkt, scanning blocks through rpc is not feasible
+func (engine *RpcCollector) GetTransactions(source tezos.Address, target tezos.Address, since time.Time, until time.Time) ([]common.TransactionInfo, error) {
+	if engine.history == nil {
+		return nil, errors.Join(constants.ErrTransactionsFetchFailed, constants.ErrNotSupportedByCollector, errors.New("tzkt_url is not configured"))
+	}
+	return engine.history.GetTransactions(defaultCtx, source, target, since, until)
+}
+
+// GetDelegationStartCycles falls back to tzkt, rpc does not keep delegation history
+func (engine *RpcCollector) GetDelegationStartCycles(baker tezos.Address) (map[string]int64, error) {
+	if engine.history == nil {
+		return nil, errors.Join(constants.ErrDelegationsFetchFailed, constants.ErrNotSupportedByCollector, errors.New("tzkt_url is not configured"))
+	}
+	return engine.history.GetDelegationStartCycles(defaultCtx, baker)
+}
@@ -0,0 +1,155 @@
+package collector_engines
+
+import (
+	"strings"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/trilitech/tzgo/rpc"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+const (
+	BALANCE_UPDATE_BAKING_REWARDS      = "baking rewards"
+	BALANCE_UPDATE_BAKING_BONUSES      = "baking bonuses"
+	BALANCE_UPDATE_ATTESTING_REWARDS   = "attesting rewards"
+	BALANCE_UPDATE_DAL_REWARDS         = "dal attesting rewards"
+	BALANCE_UPDATE_BLOCK_FEES          = "block fees"
+	BALANCE_UPDATE_LOST_REWARDS_PREFIX = "lost "
+)
+
+type rewardsSplit struct {
+	Delegated     tezos.Z
+	OwnStaked     tezos.Z
+	StakingEdge   tezos.Z
+	StakingShared tezos.Z
+	Lost          tezos.Z
+}
+
+func (split *rewardsSplit) total() tezos.Z {
+	return split.Delegated.Add(split.OwnStaked).Add(split.StakingEdge).Add(split.StakingShared)
+}
+
+// bakerCycleRewards accumulates rewards of a single baker from block balance updates
+type bakerCycleRewards struct {
+	baker tezos.Address
+
+	Block       rewardsSplit
+	Attestation rewardsSplit
+	Dal         rewardsSplit
+	Fees        tezos.Z
+}
+
+func newBakerCycleRewards(baker tezos.Address) *bakerCycleRewards {
+	return &bakerCycleRewards{baker: baker}
+}
+
+func (rewards *bakerCycleRewards) getSplit(category string) *rewardsSplit {
+	switch strings.TrimPrefix(category, BALANCE_UPDATE_LOST_REWARDS_PREFIX) {
+	case BALANCE_UPDATE_BAKING_REWARDS, BALANCE_UPDATE_BAKING_BONUSES:
+		return &rewards.Block
+	case BALANCE_UPDATE_ATTESTING_REWARDS:
+		return &rewards.Attestation
+	case BALANCE_UPDATE_DAL_REWARDS:
+		return &rewards.Dal
+	default:
+		return nil
+	}
+}
+
+// AddBalanceUpdates attributes each credit to the category of the preceding debit (minted rewards or accumulated fees)
+func (rewards *bakerCycleRewards) AddBalanceUpdates(updates rpc.BalanceUpdates) {
+	source := ""
+	for _, update := range updates {
+		if update.Change < 0 {
+			source = update.Category
+			continue
+		}
+
+		if update.Kind == "burned" && strings.HasPrefix(update.Category, BALANCE_UPDATE_LOST_REWARDS_PREFIX) {
+			if split := rewards.getSplit(update.Category); split != nil && update.Delegate.Equal(rewards.baker) {
+				split.Lost = split.Lost.Add64(update.Change)
+			}
+			continue
+		}
+
+		if source == BALANCE_UPDATE_BLOCK_FEES {
+			if update.Kind == "contract" && update.Contract.Equal(rewards.baker) {
+				rewards.Fees = rewards.Fees.Add64(update.Change)
+			}
+			continue
+		}
+
+		split := rewards.getSplit(source)
+		if split == nil {
+			continue
+		}
+		staker := update.Staker
+		switch {
+		case update.Kind == "contract" && update.Contract.Equal(rewards.baker):
+			split.Delegated = split.Delegated.Add64(update.Change)
+		case update.Kind != "freezer":
+			continue
+		case staker.BakerOwnStake.Equal(rewards.baker) || staker.Baker.Equal(rewards.baker):
+			split.OwnStaked = split.OwnStaked.Add64(update.Change)
+		case staker.BakerEdge.Equal(rewards.baker):
+			split.StakingEdge = split.StakingEdge.Add64(update.Change)
+		case staker.Delegate.Equal(rewards.baker) && !staker.Contract.IsValid():
+			split.StakingShared = split.StakingShared.Add64(update.Change)
+		}
+	}
+}
+
+// AddMissedBlock counts rewards paid out for a block the baker had rights for but did not produce as lost block rewards
+func (rewards *bakerCycleRewards) AddMissedBlock(updates rpc.BalanceUpdates) {
+	source := ""
+	for _, update := range updates {
+		if update.Change < 0 {
+			source = update.Category
+			continue
+		}
+		if update.Kind == "burned" {
+			continue
+		}
+		if source == BALANCE_UPDATE_BAKING_REWARDS || source == BALANCE_UPDATE_BAKING_BONUSES {
+			rewards.Block.Lost = rewards.Block.Lost.Add64(update.Change)
+		}
+	}
+}
+
+// getDelegatedShare returns portion of rewards which belongs to the delegated balance
+// based on the split of attestation rewards (or block rewards if there were none)
+func (rewards *bakerCycleRewards) getDelegatedShare() (delegated tezos.Z, total tezos.Z) {
+	for _, split := range []rewardsSplit{rewards.Attestation, rewards.Dal, rewards.Block} {
+		if total := split.total(); !total.IsZero() {
+			return split.Delegated, total
+		}
+	}
+	return tezos.Zero, tezos.Zero
+}
+
+func (rewards *bakerCycleRewards) getIdealDelegatedRewards(split *rewardsSplit) tezos.Z {
+	delegated, total := rewards.getDelegatedShare()
+	if total.IsZero() {
+		return split.Delegated
+	}
+	return split.Delegated.Add(split.Lost.Mul(delegated).Div(total))
+}
+
+// ApplyTo fills rewards part of the cycle data, ideal rewards include delegated share of lost and missed rewards
+func (rewards *bakerCycleRewards) ApplyTo(cycleData *common.BakersCycleData) {
+	cycleData.BlockDelegatedRewards = rewards.Block.Delegated
+	cycleData.IdealBlockDelegatedRewards = rewards.getIdealDelegatedRewards(&rewards.Block)
+	cycleData.AttestationsDelegatedRewards = rewards.Attestation.Delegated
+	cycleData.IdealAttestationsDelegatedRewards = rewards.getIdealDelegatedRewards(&rewards.Attestation)
+	cycleData.DalDelegatedRewards = rewards.Dal.Delegated
+	cycleData.IdealDalDelegatedRewards = rewards.getIdealDelegatedRewards(&rewards.Dal)
+	cycleData.BlockDelegatedFees = rewards.Fees
+
+	cycleData.BlockStakingRewardsEdge = rewards.Block.StakingEdge
+	cycleData.AttestationStakingRewardsEdge = rewards.Attestation.StakingEdge
+	cycleData.DalStakingRewardsEdge = rewards.Dal.StakingEdge
+	cycleData.BlockStakingRewardsShared = rewards.Block.StakingShared
+	cycleData.AttestationStakingRewardsShared = rewards.Attestation.StakingShared
+	cycleData.DalStakingRewardsShared = rewards.Dal.StakingShared
+	cycleData.BlockStakingFees = tezos.Zero // block fees are distributed as liquid balance only
+}
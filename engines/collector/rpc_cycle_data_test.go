@@ -0,0 +1,60 @@
+package collector_engines
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/trilitech/tzgo/rpc"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+const balanceUpdatesJson = `[
+	{"kind": "accumulator", "category": "block fees", "change": "-1500", "origin": "block"},
+	{"kind": "contract", "contract": "tz1Ke2h7sDdakHJQh8WX4Z372du1KChsksyU", "change": "1500", "origin": "block"},
+	{"kind": "minted", "category": "baking rewards", "change": "-1000", "origin": "block"},
+	{"kind": "contract", "contract": "tz1Ke2h7sDdakHJQh8WX4Z372du1KChsksyU", "change": "1000", "origin": "block"},
+	{"kind": "minted", "category": "baking rewards", "change": "-500", "origin": "block"},
+	{"kind": "freezer", "category": "deposits", "staker": {"baker_own_stake": "tz1Ke2h7sDdakHJQh8WX4Z372du1KChsksyU"}, "change": "500", "origin": "block"},
+	{"kind": "minted", "category": "attesting rewards", "change": "-3000", "origin": "block"},
+	{"kind": "contract", "contract": "tz1Ke2h7sDdakHJQh8WX4Z372du1KChsksyU", "change": "3000", "origin": "block"},
+	{"kind": "minted", "category": "attesting rewards", "change": "-1000", "origin": "block"},
+	{"kind": "freezer", "category": "deposits", "staker": {"baker_own_stake": "tz1Ke2h7sDdakHJQh8WX4Z372du1KChsksyU"}, "change": "1000", "origin": "block"},
+	{"kind": "minted", "category": "attesting rewards", "change": "-100", "origin": "block"},
+	{"kind": "freezer", "category": "deposits", "staker": {"baker_edge": "tz1Ke2h7sDdakHJQh8WX4Z372du1KChsksyU"}, "change": "100", "origin": "block"},
+	{"kind": "minted", "category": "attesting rewards", "change": "-900", "origin": "block"},
+	{"kind": "freezer", "category": "deposits", "staker": {"delegate": "tz1Ke2h7sDdakHJQh8WX4Z372du1KChsksyU"}, "change": "900", "origin": "block"},
+	{"kind": "minted", "category": "attesting rewards", "change": "-2000", "origin": "block"},
+	{"kind": "burned", "category": "lost attesting rewards", "delegate": "tz1Ke2h7sDdakHJQh8WX4Z372du1KChsksyU", "participation": true, "revelation": false, "change": "2000", "origin": "block"},
+	{"kind": "minted", "category": "attesting rewards", "change": "-7000", "origin": "block"},
+	{"kind": "contract", "contract": "tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM", "change": "7000", "origin": "block"}
+]`
+
+func TestBakerCycleRewards(t *testing.T) {
+	assert := assert.New(t)
+
+	var updates rpc.BalanceUpdates
+	assert.Nil(json.Unmarshal([]byte(balanceUpdatesJson), &updates))
+
+	rewards := newBakerCycleRewards(tezos.MustParseAddress("tz1Ke2h7sDdakHJQh8WX4Z372du1KChsksyU"))
+	rewards.AddBalanceUpdates(updates)
+
+	assert.Equal(int64(1500), rewards.Fees.Int64())
+	assert.Equal(int64(1000), rewards.Block.Delegated.Int64())
+	assert.Equal(int64(500), rewards.Block.OwnStaked.Int64())
+	assert.Equal(int64(3000), rewards.Attestation.Delegated.Int64())
+	assert.Equal(int64(1000), rewards.Attestation.OwnStaked.Int64())
+	assert.Equal(int64(100), rewards.Attestation.StakingEdge.Int64())
+	assert.Equal(int64(900), rewards.Attestation.StakingShared.Int64())
+	assert.Equal(int64(2000), rewards.Attestation.Lost.Int64())
+
+	cycleData := &common.BakersCycleData{}
+	rewards.ApplyTo(cycleData)
+	assert.Equal(int64(1000), cycleData.IdealBlockDelegatedRewards.Int64())
+	// 3000 of 5000 attestation rewards belong to delegated balance
+	assert.Equal(int64(4200), cycleData.IdealAttestationsDelegatedRewards.Int64())
+	assert.Equal(int64(100), cycleData.GetStakingRewardsEdge().Int64())
+	assert.Equal(int64(900), cycleData.GetStakingRewardsShared().Int64())
+	assert.Equal(int64(5500), cycleData.GetTotalDelegatedRewards("").Int64())
+}
@@ -0,0 +1,134 @@
+package collector_engines
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/rpc"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+const (
+	testRpcBaker = "tz1Ke2h7sDdakHJQh8WX4Z372du1KChsksyU"
+	testRpcOther = "tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM"
+)
+
+func newTestRpcCollector(t *testing.T, responses map[string]string) *RpcCollector {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/")
+		if path == "chains/main/is_bootstrapped" {
+			fmt.Fprint(w, `{"bootstrapped": true, "sync_state": "synced"}`)
+			return
+		}
+		response, ok := responses[path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, response)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := rpc.NewClient(server.URL, server.Client())
+	assert.Nil(t, err)
+	return &RpcCollector{
+		rpcs:            []*rpc.Client{client},
+		operationHashes: make(map[int64][]tezos.OpHash),
+	}
+}
+
+func blockMetadataJson(baker string, rewardsRecipient string) string {
+	return fmt.Sprintf(`{"baker": "%s", "proposer": "%s", "balance_updates": [
+		{"kind": "minted", "category": "baking rewards", "change": "-1000", "origin": "block"},
+		{"kind": "contract", "contract": "%s", "change": "1000", "origin": "block"}
+	]}`, baker, baker, rewardsRecipient)
+}
+
+func TestRpcCollectorCollectRewards(t *testing.T) {
+	assert := assert.New(t)
+
+	collector := newTestRpcCollector(t, map[string]string{
+		"chains/main/blocks/100/helpers/baking_rights": fmt.Sprintf(`[
+			{"delegate": "%[1]s", "level": 101, "round": 0},
+			{"delegate": "%[1]s", "level": 102, "round": 0},
+			{"delegate": "%[1]s", "level": 103, "round": 1},
+			{"delegate": "%[1]s", "level": 104, "round": 1}
+		]`, testRpcBaker),
+		// baked by the baker
+		"chains/main/blocks/101/metadata": blockMetadataJson(testRpcBaker, testRpcBaker),
+		// round 0 missed
+		"chains/main/blocks/102/metadata": blockMetadataJson(testRpcOther, testRpcOther),
+		// baked by other baker at round 0, not missed
+		"chains/main/blocks/103/metadata": blockMetadataJson(testRpcOther, testRpcOther),
+		"chains/main/blocks/103/header":   `{"level": 103, "payload_round": 0}`,
+		// baked by other baker at round 2, missed
+		"chains/main/blocks/104/metadata": blockMetadataJson(testRpcOther, testRpcOther),
+		"chains/main/blocks/104/header":   `{"level": 104, "payload_round": 2}`,
+		"chains/main/blocks/200/metadata": `{"baker": "` + testRpcOther + `", "proposer": "` + testRpcOther + `", "balance_updates": []}`,
+	})
+
+	rewards, err := collector.collectRewards(tezos.MustParseAddress(testRpcBaker), 10, cycleLevels{First: 100, Last: 200})
+	assert.Nil(err)
+	assert.Equal(int64(1000), rewards.Block.Delegated.Int64())
+	assert.Equal(int64(2000), rewards.Block.Lost.Int64())
+
+	cycleData := &common.BakersCycleData{}
+	rewards.ApplyTo(cycleData)
+	assert.Equal(int64(1000), cycleData.BlockDelegatedRewards.Int64())
+	assert.Equal(int64(3000), cycleData.IdealBlockDelegatedRewards.Int64())
+}
+
+func TestRpcCollectorCollectBalances(t *testing.T) {
+	assert := assert.New(t)
+
+	collector := newTestRpcCollector(t, map[string]string{
+		"chains/main/blocks/50/context/delegates/" + testRpcBaker:                     fmt.Sprintf(`{"delegated_contracts": ["%s", "%s"]}`, testRpcBaker, testRpcOther),
+		"chains/main/blocks/50/context/contracts/" + testRpcBaker + "/full_balance":   `"5000"`,
+		"chains/main/blocks/50/context/contracts/" + testRpcBaker + "/staked_balance": `"1000"`,
+		"chains/main/blocks/50/context/contracts/" + testRpcOther + "/full_balance":   `"3000"`,
+		"chains/main/blocks/50/context/contracts/" + testRpcOther + "/staked_balance": `"0"`,
+		"chains/main/blocks/head/context/contracts/" + testRpcOther + "/full_balance": `"0"`,
+	})
+
+	cycleData := &common.BakersCycleData{}
+	assert.Nil(collector.collectBalances(tezos.MustParseAddress(testRpcBaker), rpc.BlockLevel(50), cycleData))
+	assert.Equal(int64(4000), cycleData.OwnDelegatedBalance.Int64())
+	assert.Len(cycleData.Delegators, 1)
+	assert.Equal(int64(3000), cycleData.Delegators[0].DelegatedBalance.Int64())
+	assert.True(cycleData.Delegators[0].Emptied)
+}
+
+func TestRpcCollectorPruneOperationHashes(t *testing.T) {
+	assert := assert.New(t)
+
+	var opHash tezos.OpHash
+	opHash[0] = 1
+	collector := newTestRpcCollector(t, map[string]string{
+		"chains/main/blocks/10/operation_hashes/3": fmt.Sprintf(`["%s"]`, opHash),
+	})
+	hashes, err := collector.getManagerOperationHashes(10)
+	assert.Nil(err)
+	assert.Equal([]tezos.OpHash{opHash}, hashes)
+	collector.operationHashes[5] = nil
+
+	collector.pruneOperationHashes(8)
+	assert.NotContains(collector.operationHashes, int64(5))
+	assert.Contains(collector.operationHashes, int64(10))
+}
+
+func TestRpcCollectorHistoryWithoutTzkt(t *testing.T) {
+	assert := assert.New(t)
+
+	collector := newTestRpcCollector(t, map[string]string{})
+	_, err := collector.GetTransactions(tezos.MustParseAddress(testRpcBaker), tezos.MustParseAddress(testRpcOther), time.Now(), time.Now())
+	assert.ErrorIs(err, constants.ErrNotSupportedByCollector)
+	_, err = collector.GetDelegationStartCycles(tezos.MustParseAddress(testRpcBaker))
+	assert.ErrorIs(err, constants.ErrNotSupportedByCollector)
+}
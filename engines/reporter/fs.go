@@ -30,11 +30,9 @@ func NewFileSystemReporter(config *configuration.RuntimeConfiguration, options *
 }
 
 func (engine *FsReporter) getReportsDirectory() (string, error) {
-	var directory string
+	directory := path.Join(state.Global.GetReportsDirectory(), engine.configuration.Reporting.Subdirectory)
 	if engine.options.DryRun {
-		directory = path.Join(state.Global.GetReportsDirectory(), "dry")
-	} else {
-		directory = state.Global.GetReportsDirectory()
+		directory = path.Join(directory, "dry")
 	}
 	return directory, os.MkdirAll(directory, 0700)
 }
@@ -0,0 +1,137 @@
+package reporter_engines
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"strconv"
+
+	"github.com/gocarina/gocsv"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+)
+
+type ImportReportsResult struct {
+	Cycles         int `json:"cycles"`
+	Payouts        int `json:"payouts"`
+	InvalidPayouts int `json:"invalid_payouts"`
+	Summaries      int `json:"summaries"`
+}
+
+func readCsvReports(file string) ([]common.PayoutReport, error) {
+	reports := make([]common.PayoutReport, 0)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return reports, nil
+		}
+		return reports, err
+	}
+	err = gocsv.UnmarshalBytes(data, &reports)
+	return reports, err
+}
+
+func compactJson(data []byte) (string, error) {
+	var buffer bytes.Buffer
+	if err := json.Compact(&buffer, data); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// ImportFileSystemReports loads reports tree created by FsReporter (reports/<cycle>/...)
+// into the database. Everything is imported in single transaction, so either all cycles
+// are imported or none.
+func (engine *SqliteReporter) ImportFileSystemReports(directory string) (*ImportReportsResult, error) {
+	if engine.options.IsReadOnly {
+		return nil, errors.New("reporter is in read-only mode")
+	}
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, errors.Join(constants.ErrReportsImportFailed, err)
+	}
+
+	tx, err := engine.db.Begin()
+	if err != nil {
+		return nil, errors.Join(constants.ErrReportsImportFailed, err)
+	}
+	fail := func(cycle int64, err error) (*ImportReportsResult, error) {
+		return nil, errors.Join(constants.ErrReportsImportFailed, fmt.Errorf("cycle: %d", cycle), err, tx.Rollback())
+	}
+
+	result := &ImportReportsResult{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		cycle, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if err != nil {
+			slog.Debug("skipping non cycle directory", "directory", entry.Name())
+			continue
+		}
+		cycleDirectory := path.Join(directory, entry.Name())
+
+		payouts, err := readCsvReports(path.Join(cycleDirectory, constants.PAYOUT_REPORT_FILE_NAME))
+		if err != nil {
+			return fail(cycle, err)
+		}
+		if len(payouts) > 0 {
+			if err := replaceCycleReports(tx, sqlitePayoutsTable, cycle, payouts); err != nil {
+				return fail(cycle, err)
+			}
+		}
+
+		invalid, err := readCsvReports(path.Join(cycleDirectory, constants.INVALID_REPORT_FILE_NAME))
+		if err != nil {
+			return fail(cycle, err)
+		}
+		if len(invalid) > 0 {
+			if err := replaceCycleReports(tx, sqliteInvalidPayoutsTable, cycle, invalid); err != nil {
+				return fail(cycle, err)
+			}
+		}
+
+		hasSummary := false
+		summaryData, err := os.ReadFile(path.Join(cycleDirectory, constants.REPORT_SUMMARY_FILE_NAME))
+		switch {
+		case os.IsNotExist(err):
+		case err != nil:
+			return fail(cycle, err)
+		default:
+			var summary common.CyclePayoutSummary
+			if err := json.Unmarshal(summaryData, &summary); err != nil {
+				return fail(cycle, err)
+			}
+			// store original bytes, so we do not lose fields unknown to this version
+			compacted, err := compactJson(summaryData)
+			if err != nil {
+				return fail(cycle, err)
+			}
+			if _, err := tx.Exec("INSERT INTO cycle_summaries (cycle, data) VALUES (?, ?) ON CONFLICT (cycle) DO UPDATE SET data = excluded.data", cycle, compacted); err != nil {
+				return fail(cycle, err)
+			}
+			hasSummary = true
+		}
+
+		if len(payouts) == 0 && len(invalid) == 0 && !hasSummary {
+			continue
+		}
+		result.Cycles++
+		result.Payouts += len(payouts)
+		result.InvalidPayouts += len(invalid)
+		if hasSummary {
+			result.Summaries++
+		}
+		slog.Debug("cycle reports imported", "cycle", cycle, "payouts", len(payouts), "invalid", len(invalid), "summary", hasSummary)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Join(constants.ErrReportsImportFailed, err)
+	}
+	return result, nil
+}
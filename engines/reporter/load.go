@@ -0,0 +1,26 @@
+package reporter_engines
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+)
+
+func Load(config *configuration.RuntimeConfiguration, options *common.ReporterEngineOptions) (common.ReporterEngine, error) {
+	switch config.Reporting.Kind {
+	case enums.REPORTER_KIND_FILESYSTEM, "":
+		return NewFileSystemReporter(config, options), nil
+	case enums.REPORTER_KIND_SQLITE:
+		reporter, err := NewSqliteReporter(config, options)
+		if err != nil {
+			return nil, errors.Join(constants.ErrReporterLoadFailed, err)
+		}
+		return reporter, nil
+	default:
+		return nil, errors.Join(constants.ErrReporterLoadFailed, fmt.Errorf("unsupported reporter kind: %s", config.Reporting.Kind))
+	}
+}
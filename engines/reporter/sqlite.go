@@ -0,0 +1,244 @@
+package reporter_engines
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/tez-capital/tezpay/utils"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	sqlitePayoutsTable        = "payouts"
+	sqliteInvalidPayoutsTable = "invalid_payouts"
+)
+
+var sqliteSchema = []string{
+	`CREATE TABLE IF NOT EXISTS payouts (
+		cycle INTEGER NOT NULL,
+		position INTEGER NOT NULL,
+		id TEXT NOT NULL,
+		baker TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		delegator TEXT NOT NULL,
+		recipient TEXT NOT NULL,
+		op_hash TEXT NOT NULL,
+		success INTEGER NOT NULL,
+		data TEXT NOT NULL,
+		PRIMARY KEY (cycle, position)
+	)`,
+	`CREATE INDEX IF NOT EXISTS payouts_delegator ON payouts (delegator)`,
+	`CREATE INDEX IF NOT EXISTS payouts_op_hash ON payouts (op_hash)`,
+	`CREATE TABLE IF NOT EXISTS invalid_payouts (
+		cycle INTEGER NOT NULL,
+		position INTEGER NOT NULL,
+		id TEXT NOT NULL,
+		baker TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		delegator TEXT NOT NULL,
+		recipient TEXT NOT NULL,
+		op_hash TEXT NOT NULL,
+		success INTEGER NOT NULL,
+		data TEXT NOT NULL,
+		PRIMARY KEY (cycle, position)
+	)`,
+	`CREATE TABLE IF NOT EXISTS cycle_summaries (
+		cycle INTEGER PRIMARY KEY,
+		data TEXT NOT NULL
+	)`,
+}
+
+type SqliteReporter struct {
+	configuration *configuration.RuntimeConfiguration
+	options       *common.ReporterEngineOptions
+	db            *sql.DB
+}
+
+func getSqliteDatabasePath(config *configuration.RuntimeConfiguration, options *common.ReporterEngineOptions) string {
+	reportsDirectory := path.Join(state.Global.GetReportsDirectory(), config.Reporting.Subdirectory)
+	if options.DryRun {
+		return path.Join(reportsDirectory, "dry", constants.REPORTS_DATABASE_FILE)
+	}
+	if config.Reporting.Database != "" {
+		if path.IsAbs(config.Reporting.Database) {
+			return config.Reporting.Database
+		}
+		return path.Join(state.Global.GetWorkingDirectory(), config.Reporting.Database)
+	}
+	return path.Join(reportsDirectory, constants.REPORTS_DATABASE_FILE)
+}
+
+func NewSqliteReporter(config *configuration.RuntimeConfiguration, options *common.ReporterEngineOptions) (*SqliteReporter, error) {
+	databasePath := getSqliteDatabasePath(config, options)
+	if err := os.MkdirAll(path.Dir(databasePath), 0700); err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite", databasePath)
+	if err != nil {
+		return nil, err
+	}
+	// sqlite allows single writer, we serialize access to avoid busy errors
+	db.SetMaxOpenConns(1)
+
+	for _, statement := range sqliteSchema {
+		if _, err := db.Exec(statement); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+
+	return &SqliteReporter{
+		configuration: config,
+		options:       options,
+		db:            db,
+	}, nil
+}
+
+func (engine *SqliteReporter) Close() error {
+	return engine.db.Close()
+}
+
+func (engine *SqliteReporter) getReports(table string, cycle int64) ([]common.PayoutReport, error) {
+	rows, err := engine.db.Query("SELECT data FROM "+table+" WHERE cycle = ? ORDER BY position", cycle)
+	if err != nil {
+		return []common.PayoutReport{}, err
+	}
+	defer rows.Close()
+
+	reports := make([]common.PayoutReport, 0)
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return []common.PayoutReport{}, err
+		}
+		var report common.PayoutReport
+		if err := json.Unmarshal([]byte(data), &report); err != nil {
+			return []common.PayoutReport{}, err
+		}
+		reports = append(reports, report)
+	}
+	if err := rows.Err(); err != nil {
+		return []common.PayoutReport{}, err
+	}
+	if len(reports) == 0 {
+		// keep parity with fs reporter, callers check os.IsNotExist
+		return reports, os.ErrNotExist
+	}
+	return reports, nil
+}
+
+func replaceCycleReports(tx *sql.Tx, table string, cycle int64, reports []common.PayoutReport) error {
+	if _, err := tx.Exec("DELETE FROM "+table+" WHERE cycle = ?", cycle); err != nil {
+		return err
+	}
+	statement, err := tx.Prepare("INSERT INTO " + table + " (cycle, position, id, baker, kind, delegator, recipient, op_hash, success, data) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer statement.Close()
+
+	for position, report := range reports {
+		data, err := json.Marshal(report)
+		if err != nil {
+			return err
+		}
+		_, err = statement.Exec(cycle, position, report.Id, report.Baker.String(), string(report.Kind), report.Delegator.String(), report.Recipient.String(), report.OpHash.String(), report.IsSuccess, string(data))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (engine *SqliteReporter) writeReports(table string, reports []common.PayoutReport) error {
+	cyclesToBeWritten := lo.Uniq(lo.Map(reports, func(pr common.PayoutReport, _ int) int64 {
+		return pr.Cycle
+	}))
+
+	tx, err := engine.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, cycle := range cyclesToBeWritten {
+		if err := replaceCycleReports(tx, table, cycle, utils.FilterPayoutsByCycle(reports, cycle)); err != nil {
+			return errors.Join(err, tx.Rollback())
+		}
+	}
+	return tx.Commit()
+}
+
+func (engine *SqliteReporter) GetExistingReports(cycle int64) ([]common.PayoutReport, error) {
+	return engine.getReports(sqlitePayoutsTable, cycle)
+}
+
+func (engine *SqliteReporter) GetExistingInvalidReports(cycle int64) ([]common.PayoutReport, error) {
+	return engine.getReports(sqliteInvalidPayoutsTable, cycle)
+}
+
+func (engine *SqliteReporter) ReportPayouts(payouts []common.PayoutReport) error {
+	if len(payouts) == 0 {
+		return nil
+	}
+	if engine.options.IsReadOnly {
+		return errors.New("reporter is in read-only mode")
+	}
+
+	sort.Slice(payouts, func(i, j int) bool {
+		return !payouts[i].Amount.IsLess(payouts[j].Amount)
+	})
+	return engine.writeReports(sqlitePayoutsTable, payouts)
+}
+
+func (engine *SqliteReporter) ReportInvalidPayouts(payouts []common.PayoutReport) error {
+	invalid := utils.OnlyFailedOrInvalidPayouts(payouts)
+	if len(invalid) == 0 {
+		return nil
+	}
+	for _, inv := range invalid {
+		if len(inv.Accumulated) > 0 {
+			panic("invalid payout report contains accumulated reports")
+		}
+	}
+
+	if engine.options.IsReadOnly {
+		return errors.New("reporter is in read-only mode")
+	}
+	return engine.writeReports(sqliteInvalidPayoutsTable, invalid)
+}
+
+func (engine *SqliteReporter) ReportCycleSummary(cycle int64, summary common.CyclePayoutSummary) error {
+	if engine.options.IsReadOnly {
+		return errors.New("reporter is in read-only mode")
+	}
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	_, err = engine.db.Exec("INSERT INTO cycle_summaries (cycle, data) VALUES (?, ?) ON CONFLICT (cycle) DO UPDATE SET data = excluded.data", cycle, string(data))
+	return err
+}
+
+func (engine *SqliteReporter) GetExistingCycleSummary(cycle int64) (*common.CyclePayoutSummary, error) {
+	var data string
+	err := engine.db.QueryRow("SELECT data FROM cycle_summaries WHERE cycle = ?", cycle).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, os.ErrNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	var summary common.CyclePayoutSummary
+	err = json.Unmarshal([]byte(data), &summary)
+	return &summary, err
+}
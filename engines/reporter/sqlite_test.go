@@ -0,0 +1,137 @@
+package reporter_engines
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+var (
+	testBaker     = tezos.MustParseAddress("tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM")
+	testDelegator = tezos.MustParseAddress("tz1hZvgjekGo7DmQjWh7XnY5eLQD8wNYPczE")
+)
+
+func testReports(cycle int64, success bool) []common.PayoutReport {
+	return []common.PayoutReport{
+		{
+			Id:               "a",
+			Baker:            testBaker,
+			Timestamp:        time.Unix(1700000000, 0).UTC(),
+			Cycle:            cycle,
+			Kind:             enums.PAYOUT_KIND_DELEGATOR_REWARD,
+			TxKind:           enums.PAYOUT_TX_KIND_TEZ,
+			Delegator:        testDelegator,
+			Recipient:        testDelegator,
+			DelegatedBalance: tezos.NewZ(1000000000),
+			Amount:           tezos.NewZ(1000),
+			FeeRate:          .05,
+			Fee:              tezos.NewZ(50),
+			TxFee:            400,
+			IsSuccess:        success,
+			Note:             "note",
+		},
+		{
+			Id:        "b",
+			Baker:     testBaker,
+			Timestamp: time.Unix(1700000000, 0).UTC(),
+			Cycle:     cycle,
+			Kind:      enums.PAYOUT_KIND_DONATION,
+			TxKind:    enums.PAYOUT_TX_KIND_TEZ,
+			Recipient: testBaker,
+			Amount:    tezos.NewZ(2000),
+			IsSuccess: success,
+		},
+	}
+}
+
+func newTestSqliteReporter(t *testing.T) *SqliteReporter {
+	workingDirectory := t.TempDir()
+	assert.Nil(t, state.Init(workingDirectory, state.StateInitOptions{}))
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	config.Reporting.Kind = enums.REPORTER_KIND_SQLITE
+	reporter, err := NewSqliteReporter(&config, &common.ReporterEngineOptions{})
+	assert.Nil(t, err)
+	t.Cleanup(func() { reporter.Close() })
+	return reporter
+}
+
+func TestSqliteReporterRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+	reporter := newTestSqliteReporter(t)
+
+	_, err := reporter.GetExistingReports(100)
+	assert.True(os.IsNotExist(err))
+	_, err = reporter.GetExistingCycleSummary(100)
+	assert.True(os.IsNotExist(err))
+
+	assert.Nil(reporter.ReportPayouts(append(testReports(100, true), testReports(101, true)...)))
+	reports, err := reporter.GetExistingReports(100)
+	assert.Nil(err)
+	assert.Len(reports, 2)
+	// sorted by amount as fs reporter does
+	assert.Equal("b", reports[0].Id)
+	assert.Equal(testReports(100, true)[0], reports[1])
+
+	// rewrite replaces the cycle
+	assert.Nil(reporter.ReportPayouts(testReports(100, true)[:1]))
+	reports, err = reporter.GetExistingReports(100)
+	assert.Nil(err)
+	assert.Len(reports, 1)
+	reports, err = reporter.GetExistingReports(101)
+	assert.Nil(err)
+	assert.Len(reports, 2)
+
+	assert.Nil(reporter.ReportInvalidPayouts(testReports(102, false)))
+	invalid, err := reporter.GetExistingInvalidReports(102)
+	assert.Nil(err)
+	assert.Len(invalid, 2)
+
+	summary := common.CyclePayoutSummary{Delegators: 10, PaidDelegators: 9, EarnedTotal: tezos.NewZ(12345), Timestamp: time.Unix(1700000000, 0).UTC()}
+	assert.Nil(reporter.ReportCycleSummary(100, summary))
+	summary.Delegators = 11
+	assert.Nil(reporter.ReportCycleSummary(100, summary))
+	stored, err := reporter.GetExistingCycleSummary(100)
+	assert.Nil(err)
+	assert.Equal(summary, *stored)
+}
+
+func TestSqliteReporterImportFileSystemReports(t *testing.T) {
+	assert := assert.New(t)
+	reporter := newTestSqliteReporter(t)
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	fsReporter := NewFileSystemReporter(&config, &common.ReporterEngineOptions{})
+	assert.Nil(fsReporter.ReportPayouts(append(testReports(200, true), testReports(201, true)...)))
+	assert.Nil(fsReporter.ReportInvalidPayouts(testReports(201, false)))
+	summary := common.CyclePayoutSummary{Delegators: 3, DistributedRewards: tezos.NewZ(3000), Timestamp: time.Unix(1700000000, 0).UTC()}
+	assert.Nil(fsReporter.ReportCycleSummary(200, summary))
+	// non cycle directories are skipped
+	assert.Nil(os.MkdirAll(state.Global.GetReportsDirectory()+"/dry/5", 0700))
+
+	result, err := reporter.ImportFileSystemReports(state.Global.GetReportsDirectory())
+	assert.Nil(err)
+	assert.Equal(&ImportReportsResult{Cycles: 2, Payouts: 4, InvalidPayouts: 2, Summaries: 1}, result)
+
+	for _, cycle := range []int64{200, 201} {
+		expected, err := fsReporter.GetExistingReports(cycle)
+		assert.Nil(err)
+		imported, err := reporter.GetExistingReports(cycle)
+		assert.Nil(err)
+		assert.Equal(expected, imported)
+	}
+	invalid, err := reporter.GetExistingInvalidReports(201)
+	assert.Nil(err)
+	assert.Len(invalid, 2)
+
+	importedSummary, err := reporter.GetExistingCycleSummary(200)
+	assert.Nil(err)
+	assert.Equal(summary, *importedSummary)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/trilitech/tzgo/codec"
 	"github.com/trilitech/tzgo/signer"
 	"github.com/trilitech/tzgo/tezos"
+	"golang.org/x/crypto/blake2b"
 )
 
 type InMemorySigner struct {
@@ -42,6 +43,11 @@ func (inMemSigner *InMemorySigner) Sign(op *codec.Op) error {
 	return nil
 }
 
+func (inMemSigner *InMemorySigner) SignBytes(data []byte) (tezos.Signature, error) {
+	digest := blake2b.Sum256(data)
+	return inMemSigner.Key.Sign(digest[:])
+}
+
 func (inMemSigner *InMemorySigner) GetSigner() signer.Signer {
 	return signer.NewFromKey(inMemSigner.Key)
 }
@@ -0,0 +1,228 @@
+package signer_engines
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/utils"
+	"github.com/trilitech/tzgo/tezos"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	KEYSTORE_VERSION = 1
+	KEYSTORE_KDF     = "scrypt"
+	KEYSTORE_CIPHER  = "aes-256-gcm"
+
+	KEYSTORE_PASSPHRASE_ENV     = "TEZPAY_KEYSTORE_PASSPHRASE"
+	KEYSTORE_PASSPHRASE_FD_ENV  = "TEZPAY_KEYSTORE_PASSPHRASE_FD"
+	KEYSTORE_NEW_PASSPHRASE_ENV = "TEZPAY_KEYSTORE_NEW_PASSPHRASE"
+
+	keystoreScryptN      = 1 << 15
+	keystoreScryptR      = 8
+	keystoreScryptP      = 1
+	keystoreScryptKeyLen = 32
+	keystoreSaltLen      = 32
+)
+
+type KeystoreKdfParams struct {
+	N    int            `json:"n"`
+	R    int            `json:"r"`
+	P    int            `json:"p"`
+	Salt tezos.HexBytes `json:"salt"`
+}
+
+// Keystore is passphrase protected private key stored in place of plaintext payout_wallet_private.key
+type Keystore struct {
+	Version    int               `json:"version"`
+	Pkh        string            `json:"pkh"`
+	Kdf        string            `json:"kdf"`
+	KdfParams  KeystoreKdfParams `json:"kdf_params"`
+	Cipher     string            `json:"cipher"`
+	Nonce      tezos.HexBytes    `json:"nonce"`
+	Ciphertext tezos.HexBytes    `json:"ciphertext"`
+}
+
+// PassphrasePrompt asks user for passphrase, it is injected by cli
+type PassphrasePrompt func(msg string) (string, error)
+
+var passphrasePrompt PassphrasePrompt
+
+func SetPassphrasePrompt(prompt PassphrasePrompt) {
+	passphrasePrompt = prompt
+}
+
+func IsKeystore(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+}
+
+func ParseKeystore(data []byte) (*Keystore, error) {
+	keystore := &Keystore{}
+	if err := json.Unmarshal(data, keystore); err != nil {
+		return nil, errors.Join(constants.ErrKeystoreInvalid, err)
+	}
+	if keystore.Version != KEYSTORE_VERSION || keystore.Kdf != KEYSTORE_KDF || keystore.Cipher != KEYSTORE_CIPHER {
+		return nil, errors.Join(constants.ErrKeystoreInvalid, fmt.Errorf("unsupported keystore (version %d, kdf '%s', cipher '%s')", keystore.Version, keystore.Kdf, keystore.Cipher))
+	}
+	return keystore, nil
+}
+
+func newKeystoreCipher(passphrase string, params KeystoreKdfParams) (cipher.AEAD, error) {
+	derivedKey, err := scrypt.Key([]byte(passphrase), params.Salt, params.N, params.R, params.P, keystoreScryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func EncryptPrivateKey(key tezos.PrivateKey, passphrase string) (*Keystore, error) {
+	if passphrase == "" {
+		return nil, constants.ErrKeystorePassphraseInvalid
+	}
+	params := KeystoreKdfParams{
+		N:    keystoreScryptN,
+		R:    keystoreScryptR,
+		P:    keystoreScryptP,
+		Salt: make([]byte, keystoreSaltLen),
+	}
+	if _, err := rand.Read(params.Salt); err != nil {
+		return nil, err
+	}
+	aead, err := newKeystoreCipher(passphrase, params)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	pkh := key.Address().String()
+	return &Keystore{
+		Version:   KEYSTORE_VERSION,
+		Pkh:       pkh,
+		Kdf:       KEYSTORE_KDF,
+		KdfParams: params,
+		Cipher:    KEYSTORE_CIPHER,
+		Nonce:     nonce,
+		// pkh is authenticated so it can not be swapped without noticing
+		Ciphertext: aead.Seal(nil, nonce, []byte(key.String()), []byte(pkh)),
+	}, nil
+}
+
+func (keystore *Keystore) Decrypt(passphrase string) (tezos.PrivateKey, error) {
+	aead, err := newKeystoreCipher(passphrase, keystore.KdfParams)
+	if err != nil {
+		return tezos.PrivateKey{}, errors.Join(constants.ErrKeystoreInvalid, err)
+	}
+	if len(keystore.Nonce) != aead.NonceSize() {
+		return tezos.PrivateKey{}, errors.Join(constants.ErrKeystoreInvalid, errors.New("invalid nonce"))
+	}
+	plaintext, err := aead.Open(nil, keystore.Nonce, keystore.Ciphertext, []byte(keystore.Pkh))
+	if err != nil {
+		return tezos.PrivateKey{}, constants.ErrKeystoreDecryptFailed
+	}
+	key, err := tezos.ParsePrivateKey(string(plaintext))
+	if err != nil {
+		return tezos.PrivateKey{}, errors.Join(constants.ErrKeystoreInvalid, err)
+	}
+	return key, nil
+}
+
+func (keystore *Keystore) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(keystore, "", "\t")
+}
+
+var (
+	// file descriptor can be read only once, passphrase is kept for other signers loaded from keystore
+	fdPassphrases    = map[string]string{}
+	fdPassphrasesMtx sync.Mutex
+)
+
+func readPassphraseFromFd(rawFd string) (string, error) {
+	fdPassphrasesMtx.Lock()
+	defer fdPassphrasesMtx.Unlock()
+	if passphrase, ok := fdPassphrases[rawFd]; ok {
+		return passphrase, nil
+	}
+
+	fd, err := strconv.Atoi(rawFd)
+	if err != nil || fd < 0 {
+		return "", errors.Join(constants.ErrKeystorePassphraseInvalid, fmt.Errorf("invalid file descriptor '%s'", rawFd))
+	}
+	file := os.NewFile(uintptr(fd), "passphrase")
+	if file == nil {
+		return "", errors.Join(constants.ErrKeystorePassphraseInvalid, fmt.Errorf("invalid file descriptor '%s'", rawFd))
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return "", err
+	}
+	passphrase := strings.TrimRight(string(data), "\r\n")
+	fdPassphrases[rawFd] = passphrase
+	return passphrase, nil
+}
+
+// GetKeystorePassphrase looks up passphrase in env, then in file descriptor and falls back to interactive prompt
+func GetKeystorePassphrase() (string, error) {
+	if passphrase := os.Getenv(KEYSTORE_PASSPHRASE_ENV); passphrase != "" {
+		return passphrase, nil
+	}
+	if rawFd := os.Getenv(KEYSTORE_PASSPHRASE_FD_ENV); rawFd != "" {
+		return readPassphraseFromFd(rawFd)
+	}
+	if passphrasePrompt != nil && utils.IsTty() {
+		return passphrasePrompt("Enter keystore passphrase:")
+	}
+	return "", constants.ErrKeystorePassphraseMissing
+}
+
+// GetNewKeystorePassphrase is used when (re)encrypting the key, interactive input has to be repeated
+func GetNewKeystorePassphrase() (string, error) {
+	if passphrase := os.Getenv(KEYSTORE_NEW_PASSPHRASE_ENV); passphrase != "" {
+		return passphrase, nil
+	}
+	if passphrasePrompt == nil || !utils.IsTty() {
+		return "", errors.Join(constants.ErrKeystorePassphraseMissing, fmt.Errorf("set %s", KEYSTORE_NEW_PASSPHRASE_ENV))
+	}
+	passphrase, err := passphrasePrompt("Enter new keystore passphrase:")
+	if err != nil {
+		return "", err
+	}
+	repeated, err := passphrasePrompt("Repeat new keystore passphrase:")
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" || passphrase != repeated {
+		return "", errors.Join(constants.ErrKeystorePassphraseInvalid, errors.New("passphrases do not match"))
+	}
+	return passphrase, nil
+}
+
+func DecryptKeystoreFile(data []byte) (tezos.PrivateKey, error) {
+	keystore, err := ParseKeystore(data)
+	if err != nil {
+		return tezos.PrivateKey{}, err
+	}
+	passphrase, err := GetKeystorePassphrase()
+	if err != nil {
+		return tezos.PrivateKey{}, err
+	}
+	return keystore.Decrypt(passphrase)
+}
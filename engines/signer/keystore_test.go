@@ -0,0 +1,56 @@
+package signer_engines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func TestKeystore(t *testing.T) {
+	assert := assert.New(t)
+
+	key, err := tezos.GenerateKey(tezos.KeyTypeEd25519)
+	assert.Nil(err)
+
+	keystore, err := EncryptPrivateKey(key, "secret")
+	assert.Nil(err)
+	assert.Equal(key.Address().String(), keystore.Pkh)
+	data, err := keystore.ToJSON()
+	assert.Nil(err)
+	assert.True(IsKeystore(data))
+	assert.NotContains(string(data), key.String())
+
+	parsed, err := ParseKeystore(data)
+	assert.Nil(err)
+	decrypted, err := parsed.Decrypt("secret")
+	assert.Nil(err)
+	assert.Equal(key.String(), decrypted.String())
+
+	_, err = parsed.Decrypt("wrong")
+	assert.ErrorIs(err, constants.ErrKeystoreDecryptFailed)
+
+	// pkh is bound to ciphertext
+	parsed.Pkh = tezos.BurnAddress.String()
+	_, err = parsed.Decrypt("secret")
+	assert.ErrorIs(err, constants.ErrKeystoreDecryptFailed)
+
+	_, err = EncryptPrivateKey(key, "")
+	assert.ErrorIs(err, constants.ErrKeystorePassphraseInvalid)
+	assert.False(IsKeystore([]byte(key.String())))
+}
+
+func TestGetKeystorePassphrase(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv(KEYSTORE_PASSPHRASE_ENV, "from-env")
+	passphrase, err := GetKeystorePassphrase()
+	assert.Nil(err)
+	assert.Equal("from-env", passphrase)
+
+	t.Setenv(KEYSTORE_PASSPHRASE_ENV, "")
+	t.Setenv(KEYSTORE_PASSPHRASE_FD_ENV, "")
+	_, err = GetKeystorePassphrase()
+	assert.ErrorIs(err, constants.ErrKeystorePassphraseMissing)
+}
@@ -0,0 +1,35 @@
+//go:build unix
+
+package signer_engines
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetKeystorePassphraseFromFd(t *testing.T) {
+	assert := assert.New(t)
+
+	reader, writer, err := os.Pipe()
+	assert.Nil(err)
+	_, err = writer.WriteString("from-fd\n")
+	assert.Nil(err)
+	assert.Nil(writer.Close())
+	// passphrase reader closes the fd, so it gets its own copy
+	fd, err := syscall.Dup(int(reader.Fd()))
+	assert.Nil(err)
+	assert.Nil(reader.Close())
+
+	t.Setenv(KEYSTORE_PASSPHRASE_ENV, "")
+	t.Setenv(KEYSTORE_PASSPHRASE_FD_ENV, fmt.Sprint(fd))
+	// second signer load has to get the same passphrase even though fd was already consumed
+	for range 2 {
+		passphrase, err := GetKeystorePassphrase()
+		assert.Nil(err)
+		assert.Equal("from-fd", passphrase)
+	}
+}
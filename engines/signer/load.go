@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path"
 	"strings"
 
 	"github.com/hjson/hjson-go/v4"
@@ -14,51 +15,89 @@ import (
 	"github.com/tez-capital/tezpay/state"
 )
 
-func Load(kind string) (common.SignerEngine, error) {
-	switch kind {
-	case string(enums.WALLET_MODE_LOCAL_PRIVATE_KEY2):
-		fallthrough
-	case string(enums.WALLET_MODE_LOCAL_PRIVATE_KEY):
-		slog.Debug("creating InMemorySigner")
-		privateKeyFile := state.Global.GetPrivateKeyFilePath()
-		slog.Debug("loading private key from file", "path", privateKeyFile)
-		keyBytes, err := os.ReadFile(privateKeyFile)
-		if err != nil {
-			return nil, errors.Join(constants.ErrSignerLoadFailed, err)
-		}
-		return InitInMemorySigner(strings.TrimSpace(string(keyBytes)))
-	case string(enums.WALLET_MODE_REMOTE_SIGNER2):
-		fallthrough
-	case string(enums.WALLET_MODE_REMOTE_SIGNER):
-		slog.Debug("creating RemoteSigner")
-		remoteSpecsFile := state.Global.GetRemoteSpecsFilePath()
-		slog.Debug("loading remote specification from file", "path", remoteSpecsFile)
-		remoteSpecsBytes, err := os.ReadFile(remoteSpecsFile)
+func loadInMemorySignerFromFile(_ string) (common.SignerEngine, error) {
+	slog.Debug("creating InMemorySigner")
+	return loadInMemorySignerFromKeyFile(state.Global.GetPrivateKeyFilePath())
+}
+
+func loadInMemorySignerFromKeyFile(privateKeyFile string) (common.SignerEngine, error) {
+	slog.Debug("loading private key from file", "path", privateKeyFile)
+	keyBytes, err := os.ReadFile(privateKeyFile)
+	if err != nil {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, err)
+	}
+	if IsKeystore(keyBytes) {
+		slog.Debug("private key file is encrypted keystore, decrypting")
+		key, err := DecryptKeystoreFile(keyBytes)
 		if err != nil {
 			return nil, errors.Join(constants.ErrSignerLoadFailed, err)
 		}
-		remoteSpecs := RemoteSignerSpecs{}
-		err = hjson.Unmarshal(remoteSpecsBytes, &remoteSpecs)
-		if err != nil {
-			return nil, errors.Join(constants.ErrSignerLoadFailed, errors.New("failed to unmarshal remote specs"), err)
-		}
-		return InitRemoteSignerFromSpecs(remoteSpecs)
+		return &InMemorySigner{Key: key}, nil
 	}
+	return InitInMemorySigner(strings.TrimSpace(string(keyBytes)))
+}
 
-	if strings.HasPrefix(kind, "key:") {
-		slog.Debug("creating InMemorySigner from parameters")
-		return InitInMemorySigner(strings.TrimPrefix(kind, "key:"))
+func loadInMemorySignerFromKeyFileParameters(privateKeyFile string) (common.SignerEngine, error) {
+	if !path.IsAbs(privateKeyFile) {
+		privateKeyFile = path.Join(state.Global.GetWorkingDirectory(), privateKeyFile)
 	}
+	return loadInMemorySignerFromKeyFile(privateKeyFile)
+}
 
-	if strings.HasPrefix(kind, "remote:") {
-		slog.Debug("creating RemoteSigner from parameters")
-		specs := strings.TrimPrefix(kind, "remote:")
-		parts := strings.Split(specs, "@")
-		if len(parts) != 2 {
-			return nil, errors.Join(constants.ErrSignerLoadFailed, fmt.Errorf("invalid remote specs '%s'", specs))
-		}
-		return InitRemoteSigner(parts[0], parts[1])
+func loadInMemorySignerFromParameters(key string) (common.SignerEngine, error) {
+	slog.Debug("creating InMemorySigner from parameters")
+	return InitInMemorySigner(key)
+}
+
+func loadPublicKeySignerFromParameters(key string) (common.SignerEngine, error) {
+	slog.Debug("creating PublicKeySigner from parameters")
+	return InitPublicKeySigner(key)
+}
+
+func loadRemoteSignerFromFile(_ string) (common.SignerEngine, error) {
+	slog.Debug("creating RemoteSigner")
+	remoteSpecsFile := state.Global.GetRemoteSpecsFilePath()
+	slog.Debug("loading remote specification from file", "path", remoteSpecsFile)
+	remoteSpecsBytes, err := os.ReadFile(remoteSpecsFile)
+	if err != nil {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, err)
+	}
+	remoteSpecs := RemoteSignerSpecs{}
+	err = hjson.Unmarshal(remoteSpecsBytes, &remoteSpecs)
+	if err != nil {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, errors.New("failed to unmarshal remote specs"), err)
 	}
+	return InitRemoteSignerFromSpecs(remoteSpecs)
+}
 
-	return nil, errors.Join(constants.ErrSignerLoadFailed, fmt.Errorf("invalid payout wallet specification: '%s'", kind))
+func loadRemoteSignerFromParameters(specs string) (common.SignerEngine, error) {
+	slog.Debug("creating RemoteSigner from parameters")
+	parts := strings.Split(specs, "@")
+	if len(parts) != 2 {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, fmt.Errorf("invalid remote specs '%s'", specs))
+	}
+	return InitRemoteSigner(parts[0], parts[1])
+}
+
+func init() {
+	RegisterSignerFactory(enums.WALLET_MODE_LOCAL_PRIVATE_KEY, loadInMemorySignerFromFile)
+	RegisterSignerFactory(enums.WALLET_MODE_LOCAL_PRIVATE_KEY2, loadInMemorySignerFromFile)
+	RegisterSignerFactory(enums.WALLET_MODE_REMOTE_SIGNER, loadRemoteSignerFromFile)
+	RegisterSignerFactory(enums.WALLET_MODE_REMOTE_SIGNER2, loadRemoteSignerFromFile)
+	RegisterSignerFactory(enums.WALLET_MODE_STDIO_SIGNER, loadStdioSignerFromFile)
+	RegisterSignerFactory(enums.WALLET_MODE_STDIO_SIGNER2, loadStdioSignerFromFile)
+
+	RegisterSignerPrefixFactory("key:", loadInMemorySignerFromParameters)
+	RegisterSignerPrefixFactory("keyfile:", loadInMemorySignerFromKeyFileParameters)
+	RegisterSignerPrefixFactory("public:", loadPublicKeySignerFromParameters)
+	RegisterSignerPrefixFactory("remote:", loadRemoteSignerFromParameters)
+	RegisterSignerPrefixFactory("stdio:", loadStdioSignerFromParameters)
+}
+
+func Load(kind string) (common.SignerEngine, error) {
+	factory, spec, ok := getSignerFactory(kind)
+	if !ok {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, fmt.Errorf("invalid payout wallet specification: '%s'", kind))
+	}
+	return factory(spec)
 }
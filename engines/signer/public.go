@@ -0,0 +1,45 @@
+package signer_engines
+
+import (
+	"errors"
+
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/signer"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// PublicKeySigner knows only the public key of the payout wallet, it is used to prepare operations signed elsewhere
+type PublicKeySigner struct {
+	Key tezos.Key
+}
+
+func InitPublicKeySigner(key string) (*PublicKeySigner, error) {
+	tkey, err := tezos.ParseKey(key)
+	if err != nil {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, err)
+	}
+	return &PublicKeySigner{
+		Key: tkey,
+	}, nil
+}
+
+func (publicKeySigner *PublicKeySigner) GetId() string {
+	return "PublicKeySigner"
+}
+
+func (publicKeySigner *PublicKeySigner) GetPKH() tezos.Address {
+	return publicKeySigner.Key.Address()
+}
+
+func (publicKeySigner *PublicKeySigner) GetKey() tezos.Key {
+	return publicKeySigner.Key
+}
+
+func (publicKeySigner *PublicKeySigner) Sign(op *codec.Op) error {
+	return constants.ErrSignerCannotSign
+}
+
+func (publicKeySigner *PublicKeySigner) GetSigner() signer.Signer {
+	return nil
+}
@@ -0,0 +1,71 @@
+package signer_engines
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants/enums"
+)
+
+// SignerFactory creates signer engine from wallet mode specification.
+// For prefixed factories spec is the part after the prefix.
+type SignerFactory func(spec string) (common.SignerEngine, error)
+
+var (
+	signerFactories       = map[string]SignerFactory{}
+	signerPrefixFactories = map[string]SignerFactory{}
+	signerRegistryMtx     sync.RWMutex
+)
+
+// RegisterSignerFactory registers factory for exact wallet mode (e.g. 'remote-signer').
+// Registered modes are accepted by configuration validation.
+func RegisterSignerFactory(mode enums.EWalletMode, factory SignerFactory) {
+	signerRegistryMtx.Lock()
+	defer signerRegistryMtx.Unlock()
+	signerFactories[string(mode)] = factory
+}
+
+// GetSupportedWalletModes returns wallet modes with registered factory
+func GetSupportedWalletModes() []enums.EWalletMode {
+	signerRegistryMtx.RLock()
+	defer signerRegistryMtx.RUnlock()
+	modes := make([]enums.EWalletMode, 0, len(signerFactories))
+	for mode := range signerFactories {
+		modes = append(modes, enums.EWalletMode(mode))
+	}
+	slices.Sort(modes)
+	return modes
+}
+
+// RegisterSignerPrefixFactory registers factory for inline wallet specification (e.g. 'key:<private key>').
+func RegisterSignerPrefixFactory(prefix string, factory SignerFactory) {
+	signerRegistryMtx.Lock()
+	defer signerRegistryMtx.Unlock()
+	signerPrefixFactories[prefix] = factory
+}
+
+func getSignerFactory(kind string) (SignerFactory, string, bool) {
+	signerRegistryMtx.RLock()
+	defer signerRegistryMtx.RUnlock()
+	if factory, ok := signerFactories[kind]; ok {
+		return factory, kind, true
+	}
+
+	// longest prefix wins
+	prefixes := make([]string, 0, len(signerPrefixFactories))
+	for prefix := range signerPrefixFactories {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		return len(prefixes[i]) > len(prefixes[j])
+	})
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(kind, prefix) {
+			return signerPrefixFactories[prefix], strings.TrimPrefix(kind, prefix), true
+		}
+	}
+	return nil, "", false
+}
@@ -0,0 +1,20 @@
+package signer_engines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants/enums"
+)
+
+func TestGetSupportedWalletModes(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Contains(GetSupportedWalletModes(), enums.WALLET_MODE_REMOTE_SIGNER)
+	assert.NotContains(GetSupportedWalletModes(), enums.EWalletMode("custom-signer"))
+	RegisterSignerFactory("custom-signer", func(spec string) (common.SignerEngine, error) {
+		return nil, nil
+	})
+	assert.Contains(GetSupportedWalletModes(), enums.EWalletMode("custom-signer"))
+}
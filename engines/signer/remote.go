@@ -7,6 +7,7 @@ import (
 
 	"github.com/tez-capital/tezpay/constants"
 	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/rpc"
 	"github.com/trilitech/tzgo/signer"
 	"github.com/trilitech/tzgo/signer/remote"
 	"github.com/trilitech/tzgo/tezos"
@@ -21,6 +22,7 @@ type RemoteSigner struct {
 	Address tezos.Address
 	Remote  *remote.RemoteSigner
 	Key     tezos.Key
+	client  *rpc.Client
 }
 
 func InitRemoteSignerFromSpecs(specs RemoteSignerSpecs) (*RemoteSigner, error) {
@@ -35,6 +37,11 @@ func InitRemoteSigner(address string, remoteUrl string) (*RemoteSigner, error) {
 	if err != nil {
 		return nil, errors.Join(constants.ErrSignerLoadFailed, err)
 	}
+	// tzgo remote signer signs only operations, raw data are posted through own client
+	client, err := rpc.NewClient(remoteUrl, nil)
+	if err != nil {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, err)
+	}
 	addr, err := tezos.ParseAddress(address)
 	if err != nil {
 		return nil, errors.Join(constants.ErrSignerLoadFailed, err)
@@ -49,6 +56,7 @@ func InitRemoteSigner(address string, remoteUrl string) (*RemoteSigner, error) {
 		Address: addr,
 		Remote:  rs,
 		Key:     key,
+		client:  client,
 	}, nil
 }
 
@@ -76,3 +84,11 @@ func (remoteSigner *RemoteSigner) Sign(op *codec.Op) error {
 	op.WithSignature(sig)
 	return nil
 }
+
+func (remoteSigner *RemoteSigner) SignBytes(data []byte) (tezos.Signature, error) {
+	var response struct {
+		Signature tezos.Signature `json:"signature"`
+	}
+	err := remoteSigner.client.Post(context.Background(), "/keys/"+remoteSigner.Address.String(), tezos.HexBytes(data), &response)
+	return response.Signature, err
+}
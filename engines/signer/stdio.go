@@ -0,0 +1,250 @@
+package signer_engines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/hjson/hjson-go/v4"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/extension"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/signer"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+/*
+Stdio signer talks to external signing process over stdio JSON-RPC (same transport as stdio extensions).
+The process has to implement:
+	tp.get_key	{ "pkh": "tz1..." }                       -> { "public_key": "edpk..." }
+	tp.sign		{ "pkh": "tz1...", "data": "<hex bytes>" } -> { "signature": "edsig..." }
+data are watermarked operation bytes or packed michelson data (multisig payouts), same payload as octez remote signer receives.
+*/
+
+const (
+	STDIO_SIGNER_GET_KEY_CALL = "get_key"
+	STDIO_SIGNER_SIGN_CALL    = "sign"
+)
+
+type StdioSignerSpecs struct {
+	Pkh          string   `json:"pkh,omitempty"`
+	Command      string   `json:"command"`
+	Args         []string `json:"args,omitempty"`
+	Timeout      *int     `json:"timeout,omitempty"`
+	WaitForStart int      `json:"wait_for_start,omitempty"`
+}
+
+type stdioSignerGetKeyParams struct {
+	Pkh string `json:"pkh,omitempty"`
+}
+
+type stdioSignerGetKeyResult struct {
+	PublicKey string `json:"public_key"`
+}
+
+type stdioSignerSignParams struct {
+	Pkh  string         `json:"pkh"`
+	Data tezos.HexBytes `json:"data"`
+}
+
+type stdioSignerSignResult struct {
+	Signature string `json:"signature"`
+}
+
+// stdioSignerClient implements tzgo signer interface on top of stdio endpoint
+type stdioSignerClient struct {
+	ext extension.Extension
+	mtx sync.Mutex
+}
+
+func (client *stdioSignerClient) getEndpoint() (extension.EndpointClient, error) {
+	client.mtx.Lock()
+	defer client.mtx.Unlock()
+	if !client.ext.IsLoaded() {
+		if err := client.ext.Load(); err != nil {
+			return nil, err
+		}
+	}
+	return client.ext.GetEndpoint(), nil
+}
+
+func (client *stdioSignerClient) getKey(ctx context.Context, pkh string) (tezos.Key, error) {
+	endpoint, err := client.getEndpoint()
+	if err != nil {
+		return tezos.InvalidKey, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, client.ext.GetTimeout())
+	defer cancel()
+	response, err := extension.Request[stdioSignerGetKeyParams, stdioSignerGetKeyResult](ctx, endpoint, STDIO_SIGNER_GET_KEY_CALL, stdioSignerGetKeyParams{Pkh: pkh})
+	if err != nil {
+		return tezos.InvalidKey, err
+	}
+	result, err := response.Unwrap()
+	if err != nil {
+		return tezos.InvalidKey, err
+	}
+	return tezos.ParseKey(result.PublicKey)
+}
+
+func (client *stdioSignerClient) sign(ctx context.Context, address tezos.Address, data []byte) (tezos.Signature, error) {
+	endpoint, err := client.getEndpoint()
+	if err != nil {
+		return tezos.InvalidSignature, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, client.ext.GetTimeout())
+	defer cancel()
+	response, err := extension.Request[stdioSignerSignParams, stdioSignerSignResult](ctx, endpoint, STDIO_SIGNER_SIGN_CALL, stdioSignerSignParams{
+		Pkh:  address.String(),
+		Data: data,
+	})
+	if err != nil {
+		return tezos.InvalidSignature, err
+	}
+	result, err := response.Unwrap()
+	if err != nil {
+		return tezos.InvalidSignature, err
+	}
+	return tezos.ParseSignature(result.Signature)
+}
+
+func (client *stdioSignerClient) ListAddresses(ctx context.Context) ([]tezos.Address, error) {
+	key, err := client.getKey(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return []tezos.Address{key.Address()}, nil
+}
+
+func (client *stdioSignerClient) GetKey(ctx context.Context, address tezos.Address) (tezos.Key, error) {
+	return client.getKey(ctx, address.String())
+}
+
+func (client *stdioSignerClient) SignMessage(ctx context.Context, address tezos.Address, msg string) (tezos.Signature, error) {
+	op := codec.NewOp().
+		WithBranch(tezos.ZeroBlockHash).
+		WithContents(&codec.FailingNoop{
+			Arbitrary: msg,
+		})
+	return client.SignOperation(ctx, address, op)
+}
+
+func (client *stdioSignerClient) SignOperation(ctx context.Context, address tezos.Address, op *codec.Op) (tezos.Signature, error) {
+	return client.sign(ctx, address, op.WatermarkedBytes())
+}
+
+func (client *stdioSignerClient) SignBlock(ctx context.Context, address tezos.Address, head *codec.BlockHeader) (tezos.Signature, error) {
+	return tezos.InvalidSignature, errors.New("stdio signer does not sign blocks")
+}
+
+type StdioSigner struct {
+	Address tezos.Address
+	Key     tezos.Key
+	client  *stdioSignerClient
+}
+
+func InitStdioSignerFromSpecs(specs StdioSignerSpecs) (*StdioSigner, error) {
+	if specs.Command == "" {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, errors.New("stdio signer command not specified"))
+	}
+
+	ext, err := extension.RegisterExtension(context.Background(), common.ExtensionDefinition{
+		Name:         "stdio-signer",
+		Command:      specs.Command,
+		Args:         specs.Args,
+		Kind:         enums.EXTENSION_STDIO_RPC,
+		Timeout:      specs.Timeout,
+		WaitForStart: specs.WaitForStart,
+	})
+	if err != nil {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, err)
+	}
+	client := &stdioSignerClient{ext: ext}
+
+	key, err := client.getKey(context.Background(), specs.Pkh)
+	if err != nil {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, ext.Close(), err)
+	}
+	if specs.Pkh != "" {
+		addr, err := tezos.ParseAddress(specs.Pkh)
+		if err != nil {
+			return nil, errors.Join(constants.ErrSignerLoadFailed, ext.Close(), err)
+		}
+		if !key.Address().Equal(addr) {
+			return nil, errors.Join(constants.ErrSignerLoadFailed, ext.Close(), fmt.Errorf("stdio signer returned key for '%s', expected '%s'", key.Address(), addr))
+		}
+	}
+
+	return &StdioSigner{
+		Address: key.Address(),
+		Key:     key,
+		client:  client,
+	}, nil
+}
+
+func loadStdioSignerFromFile(_ string) (common.SignerEngine, error) {
+	slog.Debug("creating StdioSigner")
+	stdioSpecsFile := state.Global.GetStdioSignerSpecsFilePath()
+	slog.Debug("loading stdio signer specification from file", "path", stdioSpecsFile)
+	stdioSpecsBytes, err := os.ReadFile(stdioSpecsFile)
+	if err != nil {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, err)
+	}
+	specs := StdioSignerSpecs{}
+	if err := hjson.Unmarshal(stdioSpecsBytes, &specs); err != nil {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, errors.New("failed to unmarshal stdio signer specs"), err)
+	}
+	return InitStdioSignerFromSpecs(specs)
+}
+
+// stdio:<command> [args...]
+func loadStdioSignerFromParameters(specs string) (common.SignerEngine, error) {
+	slog.Debug("creating StdioSigner from parameters")
+	parts := strings.Fields(specs)
+	if len(parts) == 0 {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, fmt.Errorf("invalid stdio signer specs '%s'", specs))
+	}
+	return InitStdioSignerFromSpecs(StdioSignerSpecs{
+		Command: parts[0],
+		Args:    parts[1:],
+	})
+}
+
+func (stdioSigner *StdioSigner) GetId() string {
+	return "StdioSigner"
+}
+
+func (stdioSigner *StdioSigner) GetPKH() tezos.Address {
+	return stdioSigner.Address
+}
+
+func (stdioSigner *StdioSigner) GetKey() tezos.Key {
+	return stdioSigner.Key
+}
+
+func (stdioSigner *StdioSigner) GetSigner() signer.Signer {
+	return stdioSigner.client
+}
+
+func (stdioSigner *StdioSigner) Sign(op *codec.Op) error {
+	sig, err := stdioSigner.client.SignOperation(context.Background(), stdioSigner.Address, op)
+	if err != nil {
+		return err
+	}
+	op.WithSignature(sig)
+	return nil
+}
+
+func (stdioSigner *StdioSigner) SignBytes(data []byte) (tezos.Signature, error) {
+	return stdioSigner.client.sign(context.Background(), stdioSigner.Address, data)
+}
+
+func (stdioSigner *StdioSigner) Close() error {
+	return stdioSigner.client.ext.Close()
+}
@@ -0,0 +1,92 @@
+package signer_engines
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	rpc "github.com/alis-is/jsonrpc2"
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/extension"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+const testSignerKeyEnv = "TEZPAY_TEST_STDIO_SIGNER_KEY"
+
+type rwCloser struct {
+	io.ReadCloser
+	io.WriteCloser
+}
+
+func (rw rwCloser) Close() error {
+	return errors.Join(rw.WriteCloser.Close(), rw.ReadCloser.Close())
+}
+
+// when started with test key in env the test binary acts as external signer
+func TestMain(m *testing.M) {
+	if rawKey := os.Getenv(testSignerKeyEnv); rawKey != "" {
+		runTestStdioSigner(tezos.MustParsePrivateKey(rawKey))
+		return
+	}
+	os.Exit(m.Run())
+}
+
+func runTestStdioSigner(key tezos.PrivateKey) {
+	endpoint := extension.NewStreamEndpoint(context.Background(), extension.NewPlainObjectStream(rwCloser{os.Stdin, os.Stdout}))
+	extension.RegisterEndpointMethod(endpoint, STDIO_SIGNER_GET_KEY_CALL, func(ctx context.Context, params stdioSignerGetKeyParams) (stdioSignerGetKeyResult, *rpc.Error) {
+		return stdioSignerGetKeyResult{PublicKey: key.Public().String()}, nil
+	})
+	extension.RegisterEndpointMethod(endpoint, STDIO_SIGNER_SIGN_CALL, func(ctx context.Context, params stdioSignerSignParams) (stdioSignerSignResult, *rpc.Error) {
+		digest := tezos.Digest(params.Data)
+		sig, err := key.Sign(digest[:])
+		if err != nil {
+			return stdioSignerSignResult{}, rpc.NewInternalErrorWithData(err.Error())
+		}
+		return stdioSignerSignResult{Signature: sig.String()}, nil
+	})
+	<-endpoint.GetOnCloseListener()
+}
+
+func TestStdioSigner(t *testing.T) {
+	assert := assert.New(t)
+
+	key, err := tezos.GenerateKey(tezos.KeyTypeEd25519)
+	assert.Nil(err)
+	t.Setenv(testSignerKeyEnv, key.String())
+
+	stdioSigner, err := InitStdioSignerFromSpecs(StdioSignerSpecs{
+		Pkh:     key.Address().String(),
+		Command: os.Args[0],
+		Args:    []string{"-test.run=^$"},
+	})
+	assert.Nil(err)
+	defer stdioSigner.Close()
+	assert.Equal(key.Address(), stdioSigner.GetPKH())
+
+	op := codec.NewOp().WithBranch(tezos.ZeroBlockHash).WithSource(key.Address()).WithTransfer(tezos.BurnAddress, 1)
+	assert.Nil(stdioSigner.Sign(op))
+	assert.Nil(key.Public().Verify(op.Digest(), op.Signature))
+
+	_, err = InitStdioSignerFromSpecs(StdioSignerSpecs{
+		Pkh:     tezos.BurnAddress.String(),
+		Command: os.Args[0],
+		Args:    []string{"-test.run=^$"},
+	})
+	assert.NotNil(err)
+}
+
+func TestLoadFromRegistry(t *testing.T) {
+	assert := assert.New(t)
+
+	key, err := tezos.GenerateKey(tezos.KeyTypeEd25519)
+	assert.Nil(err)
+	engine, err := Load("key:" + key.String())
+	assert.Nil(err)
+	assert.Equal(key.Address(), engine.GetPKH())
+
+	_, err = Load("unknown-mode")
+	assert.NotNil(err)
+}
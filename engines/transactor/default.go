@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
+	"sync/atomic"
 	"time"
 
 	"github.com/tez-capital/tezpay/common"
@@ -24,60 +26,134 @@ type DefaultRpcTransactor struct {
 }
 
 type DefaultRpcTransactorOpResult struct {
-	opHash tezos.OpHash
-	result *rpc.Result
-	rpc    *rpc.Client
-	tzkt   *tzkt.Client
+	opHash         tezos.OpHash
+	injectedAt     time.Time
+	injectionLevel int64
+	confirmations  int64
+	headLevel      atomic.Int64
+	inclusion      *rpc.Result
+	result         *rpc.Result
+	rpc            *rpc.Client
+	tzkt           *tzkt.Client
 }
 
 func (result *DefaultRpcTransactorOpResult) GetOpHash() tezos.OpHash {
 	return result.opHash
 }
 
-func (result *DefaultRpcTransactorOpResult) WaitForApply() error {
+func (result *DefaultRpcTransactorOpResult) GetInjectionLevel() int64 {
+	return result.injectionLevel
+}
+
+func (result *DefaultRpcTransactorOpResult) isIncluded() bool {
+	select {
+	case <-result.inclusion.Done():
+		return result.inclusion.Err() == nil
+	default:
+		return false
+	}
+}
+
+func (result *DefaultRpcTransactorOpResult) WaitForInclusion(level int64) (bool, error) {
 	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 	utils.CallbackOnInterrupt(ctx, func() {
-		slog.Warn("waiting for confirmation canceled", "op_hash", result.opHash)
+		slog.Warn("waiting for inclusion canceled", "op_hash", result.opHash)
 		cancel()
 	})
-	appliedChan := make(chan common.OperationStatus, 1)
-	go func() {
-		utils.SleepContext(ctx, 130*time.Second) //give monitor 4 blocks before fallback kicks in
-		if ctx.Err() != context.Canceled {
-			slog.Debug(`failed to confirm with live monitoring, falling back to polling`)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	lastStatusCheck := time.Time{}
+	for {
+		if result.isIncluded() {
+			return true, nil
 		}
-		for ctx.Err() != context.Canceled {
-			applied, _ := result.tzkt.WasOperationApplied(ctx, result.opHash)
-			slog.Debug("operation status checked", "op_hash", result.opHash, "applied", applied)
-			if applied == common.OPERATION_STATUS_APPLIED || applied == common.OPERATION_STATUS_FAILED {
-				cancel()
-				appliedChan <- applied
-				break
+		// live monitoring may miss the operation, fall back to polling
+		if time.Since(result.injectedAt) > 130*time.Second && time.Since(lastStatusCheck) > 15*time.Second {
+			lastStatusCheck = time.Now()
+			status, _ := result.tzkt.WasOperationApplied(ctx, result.opHash)
+			slog.Debug("operation status checked", "op_hash", result.opHash, "status", status)
+			if status == common.OPERATION_STATUS_APPLIED || status == common.OPERATION_STATUS_FAILED {
+				return true, nil
 			}
-			time.Sleep(15 * time.Second)
 		}
-		close(appliedChan)
-	}()
-	result.result.WaitContext(ctx)
-	cancel() // cancel fallback
-	switch <-appliedChan {
-	case common.OPERATION_STATUS_FAILED:
-		return constants.ErrOperationFailed
-	case common.OPERATION_STATUS_APPLIED:
-		return nil
-	}
-	if err := result.result.Err(); err != nil {
-		return err
+		if result.headLevel.Load() >= level {
+			return false, nil
+		}
+		select {
+		case <-ctx.Done():
+			return false, context.Canceled
+		case <-result.inclusion.Done():
+		case <-ticker.C:
+		}
 	}
-	rcpt, err := result.result.GetReceipt(context.Background())
-	result.rpc.Close()
+}
+
+// WaitForApply waits until the operation is included and confirmed, waiting ends once head passes
+// the expiration of the operation or the confirmation blocks
+func (result *DefaultRpcTransactorOpResult) WaitForApply() error {
+	defer result.Close()
+	included, err := result.WaitForInclusion(result.injectionLevel + constants.MAX_OPERATION_TTL)
 	if err != nil {
 		return err
 	}
-	if rcpt.IsSuccess() {
-		return nil
+	if !included {
+		return constants.ErrOperationNotIncluded
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	utils.CallbackOnInterrupt(ctx, func() {
+		slog.Warn("waiting for confirmation canceled", "op_hash", result.opHash)
+		cancel()
+	})
+	ticker := time.NewTicker(15 * time.Second)
+	defer ticker.Stop()
+	deadline := result.headLevel.Load() + result.confirmations + constants.MAX_OPERATION_TTL
+	for {
+		select {
+		case <-ctx.Done():
+			return context.Canceled
+		case <-result.result.Done():
+			if err := result.result.Err(); err != nil {
+				return err
+			}
+			rcpt, err := result.result.GetReceipt(context.Background())
+			if err != nil {
+				return err
+			}
+			if rcpt.IsSuccess() {
+				return nil
+			}
+			return rcpt.Error()
+		case <-ticker.C:
+		}
+		// give monitor 4 blocks before fallback kicks in
+		if time.Since(result.injectedAt) < 130*time.Second {
+			continue
+		}
+		status, _ := result.tzkt.WasOperationApplied(ctx, result.opHash)
+		slog.Debug("operation status checked", "op_hash", result.opHash, "status", status)
+		switch status {
+		case common.OPERATION_STATUS_APPLIED:
+			return nil
+		case common.OPERATION_STATUS_FAILED:
+			return constants.ErrOperationFailed
+		}
+		if result.headLevel.Load() > deadline {
+			return constants.ErrOperationNotConfirmed
+		}
 	}
-	return rcpt.Error()
+}
+
+// Close stops observing blocks and mempool, inclusion is still checked through tzkt afterwards
+func (result *DefaultRpcTransactorOpResult) Close() error {
+	result.rpc.Close()
+	return nil
+}
+
+func (result *DefaultRpcTransactorOpResult) GetStatus() (common.OperationStatus, error) {
+	return result.tzkt.WasOperationApplied(context.Background(), result.opHash)
 }
 
 func InitDefaultTransactor(config *configuration.RuntimeConfiguration) (*DefaultRpcTransactor, error) {
@@ -166,15 +242,30 @@ func (transactor *DefaultRpcTransactor) initOpResult(opHash tezos.OpHash, opts *
 	if err != nil {
 		return nil, err
 	}
+	head, err := rpc_client.GetTipHeader(context.Background())
+	if err != nil {
+		return nil, err
+	}
 	rpc_client.Listen()
-	res := rpc.NewResult(opHash).WithTTL(opts.TTL).WithConfirmations(opts.Confirmations)
-	res.Listen(rpc_client.BlockObserver)
-	return &DefaultRpcTransactorOpResult{
-		opHash: opHash,
-		result: res,
-		rpc:    rpc_client,
-		tzkt:   transactor.tzkt,
-	}, nil
+	result := &DefaultRpcTransactorOpResult{
+		opHash:         opHash,
+		injectedAt:     time.Now(),
+		injectionLevel: head.Level,
+		confirmations:  opts.Confirmations,
+		inclusion:      rpc.NewResult(opHash),
+		result:         rpc.NewResult(opHash).WithConfirmations(opts.Confirmations),
+		rpc:            rpc_client,
+		tzkt:           transactor.tzkt,
+	}
+	result.headLevel.Store(head.Level)
+	// zero hash subscribes to every block
+	rpc_client.BlockObserver.Subscribe(tezos.ZeroOpHash, func(block *rpc.BlockHeaderLogEntry, level int64, _, _ int, _ bool) bool {
+		result.headLevel.Store(level)
+		return false
+	})
+	result.inclusion.Listen(rpc_client.BlockObserver)
+	result.result.Listen(rpc_client.BlockObserver)
+	return result, nil
 }
 
 func (transactor *DefaultRpcTransactor) broadcast(op *codec.Op) (tezos.OpHash, error) {
@@ -183,6 +274,29 @@ func (transactor *DefaultRpcTransactor) broadcast(op *codec.Op) (tezos.OpHash, e
 	})
 }
 
+// IsInMempool checks mempools of all rpc nodes, operation is pending if any of them can still include it
+func (transactor *DefaultRpcTransactor) IsInMempool(opHash tezos.OpHash) (bool, error) {
+	var lastErr error
+	checked := 0
+	for _, client := range transactor.rpcs {
+		mempool, err := client.GetMempool(context.Background())
+		if err != nil {
+			slog.Debug("failed to get mempool", "error", err.Error(), "rpc_url", client.BaseURL.String())
+			lastErr = err
+			continue
+		}
+		checked++
+		pending := slices.Concat(mempool.Applied, mempool.BranchDelayed, mempool.Unprocessed)
+		if slices.ContainsFunc(pending, func(op *rpc.Operation) bool { return op.Hash.Equal(opHash) }) {
+			return true, nil
+		}
+	}
+	if checked == 0 {
+		return false, lastErr
+	}
+	return false, nil
+}
+
 func (transactor *DefaultRpcTransactor) Dispatch(op *codec.Op, opts *rpc.CallOptions) (common.OpResult, error) {
 	if opts == nil {
 		opts = &rpc.DefaultOptions
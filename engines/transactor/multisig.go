@@ -0,0 +1,269 @@
+package transactor_engines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/utils"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/micheline"
+	"github.com/trilitech/tzgo/rpc"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+const MULTISIG_ENTRYPOINT = "main"
+
+/*
+MultisigTransactor wraps transfers of each batch into a single call of generic multisig contract:
+
+	parameter (pair %main (pair :payload (nat %counter) (or :action (lambda %operation unit (list operation)) ...)) (list %sigs (option signature)))
+	storage (pair (nat %stored_counter) (pair (nat %threshold) (list %keys key)))
+
+payload is signed by the multisig signers, the operation itself is signed and paid by the payout wallet.
+*/
+type MultisigTransactor struct {
+	*DefaultRpcTransactor
+	contract tezos.Address
+	signers  []common.SignerEngine
+}
+
+type multisigStorage struct {
+	Counter   *big.Int
+	Threshold int64
+	Keys      []tezos.Key
+}
+
+type multisigState struct {
+	Storage *multisigStorage
+	ChainId tezos.ChainIdHash
+	Params  *tezos.Params
+}
+
+func InitMultisigTransactor(transactor *DefaultRpcTransactor, multisig *configuration.RuntimeMultisigConfiguration, signers []common.SignerEngine) *MultisigTransactor {
+	return &MultisigTransactor{
+		DefaultRpcTransactor: transactor,
+		contract:             multisig.Contract,
+		signers:              signers,
+	}
+}
+
+func (transactor *MultisigTransactor) GetId() string {
+	return "MultisigTransactor"
+}
+
+func flattenPair(p micheline.Prim) []micheline.Prim {
+	if p.OpCode != micheline.D_PAIR {
+		return []micheline.Prim{p}
+	}
+	return lo.FlatMap(p.Args, func(arg micheline.Prim, _ int) []micheline.Prim {
+		return flattenPair(arg)
+	})
+}
+
+func parseMultisigStorage(storage micheline.Prim) (*multisigStorage, error) {
+	values := flattenPair(storage)
+	if len(values) != 3 || values[0].Type != micheline.PrimInt || values[1].Type != micheline.PrimInt || !values[2].IsSequence() {
+		return nil, errors.Join(constants.ErrMultisigStorageInvalid, fmt.Errorf("unexpected storage %s", storage.Dump()))
+	}
+	keys := make([]tezos.Key, 0, len(values[2].Args))
+	for _, v := range values[2].Args {
+		var (
+			key tezos.Key
+			err error
+		)
+		switch v.Type {
+		case micheline.PrimString:
+			key, err = tezos.ParseKey(v.String)
+		case micheline.PrimBytes:
+			key, err = tezos.DecodeKey(v.Bytes)
+		default:
+			err = fmt.Errorf("unexpected key %s", v.Dump())
+		}
+		if err != nil {
+			return nil, errors.Join(constants.ErrMultisigStorageInvalid, err)
+		}
+		keys = append(keys, key)
+	}
+	return &multisigStorage{
+		Counter:   values[0].Int,
+		Threshold: values[1].Int.Int64(),
+		Keys:      keys,
+	}, nil
+}
+
+// buildMultisigLambda builds lambda emitting transfers in the same order as they are in contents
+func buildMultisigLambda(contents []codec.Operation) (micheline.Prim, error) {
+	code := []micheline.Prim{
+		micheline.NewCode(micheline.I_DROP),
+		micheline.NewCode(micheline.I_NIL, micheline.NewCode(micheline.T_OPERATION)),
+	}
+	for i := len(contents) - 1; i >= 0; i-- {
+		tx, ok := contents[i].(*codec.Transaction)
+		if !ok {
+			return micheline.InvalidPrim, errors.Join(constants.ErrMultisigUnsupportedTransfer, fmt.Errorf("content %d is %s", i, contents[i].Kind()))
+		}
+		if tx.Parameters != nil && (tx.Parameters.Entrypoint != "default" || tx.Parameters.Value.OpCode != micheline.D_UNIT) {
+			return micheline.InvalidPrim, errors.Join(constants.ErrMultisigUnsupportedTransfer, fmt.Errorf("content %d calls %s entrypoint of %s", i, tx.Parameters.Entrypoint, tx.Destination))
+		}
+		code = append(code,
+			micheline.NewCode(micheline.I_PUSH, micheline.NewCode(micheline.T_ADDRESS), micheline.NewAddress(tx.Destination)),
+			micheline.NewCode(micheline.I_CONTRACT, micheline.NewCode(micheline.T_UNIT)),
+			micheline.NewCode(micheline.I_IF_NONE,
+				micheline.NewSeq(micheline.NewCode(micheline.I_UNIT), micheline.NewCode(micheline.I_FAILWITH)),
+				micheline.NewSeq(),
+			),
+			micheline.NewCode(micheline.I_PUSH, micheline.NewCode(micheline.T_MUTEZ), micheline.NewMutez(tx.Amount)),
+			micheline.NewCode(micheline.I_UNIT),
+			micheline.NewCode(micheline.I_TRANSFER_TOKENS),
+			micheline.NewCode(micheline.I_CONS),
+		)
+	}
+	return micheline.NewSeq(code...), nil
+}
+
+func buildMultisigPayload(counter *big.Int, lambda micheline.Prim) micheline.Prim {
+	return micheline.NewPair(micheline.NewNat(counter), micheline.NewCode(micheline.D_LEFT, lambda))
+}
+
+// getMultisigSigningBytes returns packed data checked by the contract - (pair (pair chain_id address) payload)
+func getMultisigSigningBytes(chainId tezos.ChainIdHash, contract tezos.Address, payload micheline.Prim) []byte {
+	return micheline.NewPair(
+		micheline.NewPair(micheline.NewBytes(chainId[:]), micheline.NewAddress(contract)),
+		payload,
+	).Pack()
+}
+
+// collectSignatures signs data by configured signers, signatures are ordered by keys of the contract
+func (transactor *MultisigTransactor) collectSignatures(data []byte, storage *multisigStorage) (micheline.Prim, error) {
+	for _, signer := range transactor.signers {
+		if !lo.ContainsBy(storage.Keys, func(key tezos.Key) bool { return key.IsEqual(signer.GetKey()) }) {
+			slog.Warn("multisig signer is not a key of the multisig contract", "signer", signer.GetPKH().String(), "contract", transactor.contract.String())
+		}
+	}
+
+	collected := int64(0)
+	signatures := make([]micheline.Prim, 0, len(storage.Keys))
+	for _, key := range storage.Keys {
+		signer, ok := lo.Find(transactor.signers, func(signer common.SignerEngine) bool { return key.IsEqual(signer.GetKey()) })
+		if !ok || collected >= storage.Threshold {
+			signatures = append(signatures, micheline.NewOption())
+			continue
+		}
+		bytesSigner, ok := signer.(common.BytesSignerEngine)
+		if !ok {
+			return micheline.InvalidPrim, errors.Join(constants.ErrMultisigSignerCannotSignData, fmt.Errorf("signer %s (%s)", signer.GetPKH().String(), signer.GetId()))
+		}
+		signature, err := bytesSigner.SignBytes(data)
+		if err != nil {
+			return micheline.InvalidPrim, errors.Join(constants.ErrFailedToSignOperation, fmt.Errorf("multisig signer %s", signer.GetPKH().String()), err)
+		}
+		signatures = append(signatures, micheline.NewOption(micheline.NewString(signature.String())))
+		collected++
+	}
+	if collected < storage.Threshold {
+		return micheline.InvalidPrim, errors.Join(constants.ErrMultisigThresholdNotMet, fmt.Errorf("collected %d of %d required signatures", collected, storage.Threshold))
+	}
+	return micheline.NewSeq(signatures...), nil
+}
+
+func (transactor *MultisigTransactor) getState() (*multisigState, error) {
+	return utils.AttemptWithRpcClients(context.Background(), transactor.rpcs, func(client *rpc.Client) (*multisigState, error) {
+		storage, err := client.GetContractStorage(context.Background(), transactor.contract, rpc.Head)
+		if err != nil {
+			return nil, err
+		}
+		parsedStorage, err := parseMultisigStorage(storage)
+		if err != nil {
+			return nil, err
+		}
+		chainId, err := client.GetChainId(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		return &multisigState{
+			Storage: parsedStorage,
+			ChainId: chainId,
+			Params:  client.Params,
+		}, nil
+	})
+}
+
+// getMultisigOverhead returns costs of the multisig call, signatures are checked against all keys of the contract
+func getMultisigOverhead(storage *multisigStorage) common.BatchOverhead {
+	keys := len(storage.Keys)
+	return common.BatchOverhead{
+		GasLimit:     constants.MULTISIG_BASE_GAS_LIMIT + constants.MULTISIG_SIGNATURE_GAS_LIMIT*int64(keys),
+		StorageLimit: constants.MULTISIG_STORAGE_LIMIT,
+		Bytes:        constants.MULTISIG_BASE_BYTES + constants.MULTISIG_SIGNATURE_BYTES*keys,
+	}
+}
+
+func (transactor *MultisigTransactor) GetBatchOverhead() (common.BatchOverhead, error) {
+	state, err := transactor.getState()
+	if err != nil {
+		return common.BatchOverhead{}, err
+	}
+	return getMultisigOverhead(state.Storage), nil
+}
+
+func (transactor *MultisigTransactor) isWrapped(op *codec.Op) bool {
+	if len(op.Contents) != 1 {
+		return false
+	}
+	tx, ok := op.Contents[0].(*codec.Transaction)
+	return ok && tx.Destination.Equal(transactor.contract) && tx.Parameters != nil && tx.Parameters.Entrypoint == MULTISIG_ENTRYPOINT
+}
+
+func (transactor *MultisigTransactor) Complete(op *codec.Op, key tezos.Key) error {
+	if transactor.isWrapped(op) {
+		return transactor.DefaultRpcTransactor.Complete(op, key)
+	}
+
+	lambda, err := buildMultisigLambda(op.Contents)
+	if err != nil {
+		return err
+	}
+	state, err := transactor.getState()
+	if err != nil {
+		return err
+	}
+	payload := buildMultisigPayload(state.Storage.Counter, lambda)
+	signatures, err := transactor.collectSignatures(getMultisigSigningBytes(state.ChainId, transactor.contract, payload), state.Storage)
+	if err != nil {
+		return err
+	}
+
+	limits := lo.Reduce(op.Contents, func(agg tezos.Limits, content codec.Operation, _ int) tezos.Limits {
+		return agg.Add(content.Limits())
+	}, tezos.Limits{})
+	overhead := getMultisigOverhead(state.Storage)
+	limits.GasLimit += overhead.GasLimit
+	limits.StorageLimit += overhead.StorageLimit
+
+	call := &codec.Transaction{
+		Manager: codec.Manager{
+			Source: op.Source,
+		},
+		Destination: transactor.contract,
+		Parameters: &micheline.Parameters{
+			Entrypoint: MULTISIG_ENTRYPOINT,
+			Value:      micheline.NewPair(payload, signatures),
+		},
+	}
+	call.WithLimits(limits)
+	if fee := utils.EstimateContentFee(call, limits.GasLimit, state.Params); fee > limits.Fee {
+		limits.Fee = fee
+		call.WithLimits(limits)
+	}
+
+	slog.Debug("batch wrapped into multisig call", "contract", transactor.contract.String(), "counter", state.Storage.Counter.String(), "transfers", len(op.Contents))
+	op.Contents = []codec.Operation{call}
+	return transactor.DefaultRpcTransactor.Complete(op, key)
+}
@@ -0,0 +1,108 @@
+package transactor_engines
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	signer_engines "github.com/tez-capital/tezpay/engines/signer"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/micheline"
+	"github.com/trilitech/tzgo/tezos"
+	"golang.org/x/crypto/blake2b"
+)
+
+func generateMultisigSigner(t *testing.T) *signer_engines.InMemorySigner {
+	key, err := tezos.GenerateKey(tezos.KeyTypeEd25519)
+	assert.Nil(t, err)
+	return &signer_engines.InMemorySigner{Key: key}
+}
+
+func TestParseMultisigStorage(t *testing.T) {
+	assert := assert.New(t)
+
+	a, b := generateMultisigSigner(t), generateMultisigSigner(t)
+	// comb pair as returned by rpc
+	storage := micheline.NewCode(micheline.D_PAIR,
+		micheline.NewInt64(7),
+		micheline.NewInt64(1),
+		micheline.NewSeq(micheline.NewString(a.GetKey().String()), micheline.NewBytes(b.GetKey().Bytes())),
+	)
+	parsed, err := parseMultisigStorage(storage)
+	assert.Nil(err)
+	assert.Equal(int64(7), parsed.Counter.Int64())
+	assert.Equal(int64(1), parsed.Threshold)
+	assert.Len(parsed.Keys, 2)
+	assert.True(parsed.Keys[0].IsEqual(a.GetKey()))
+	assert.True(parsed.Keys[1].IsEqual(b.GetKey()))
+
+	// overhead is sized by keys of the contract, not by configured signers
+	overhead := getMultisigOverhead(parsed)
+	assert.Equal(constants.MULTISIG_BASE_GAS_LIMIT+2*constants.MULTISIG_SIGNATURE_GAS_LIMIT, overhead.GasLimit)
+	assert.Equal(constants.MULTISIG_BASE_BYTES+2*constants.MULTISIG_SIGNATURE_BYTES, overhead.Bytes)
+
+	_, err = parseMultisigStorage(micheline.NewPair(micheline.NewInt64(7), micheline.NewInt64(1)))
+	assert.True(errors.Is(err, constants.ErrMultisigStorageInvalid))
+}
+
+func TestBuildMultisigLambda(t *testing.T) {
+	assert := assert.New(t)
+
+	first, second := mock.GetRandomAddress(), mock.GetRandomAddress()
+	op := codec.NewOp().WithSource(mock.GetRandomAddress()).WithTransfer(first, 100).WithTransfer(second, 200)
+	lambda, err := buildMultisigLambda(op.Contents)
+	assert.Nil(err)
+	// DROP, NIL and 7 instructions per transfer, transfers are consed in reverse order
+	assert.Len(lambda.Args, 2+2*7)
+	assert.Equal(micheline.NewAddress(second), lambda.Args[2].Args[1])
+	assert.Equal(micheline.NewAddress(first), lambda.Args[9].Args[1])
+	assert.Equal(int64(100), lambda.Args[12].Args[1].Int.Int64())
+
+	packed := getMultisigSigningBytes(tezos.Mainnet, mock.GetRandomAddress(), buildMultisigPayload(big.NewInt(3), lambda))
+	unpacked := micheline.Prim{}
+	assert.Nil(unpacked.UnmarshalBinary(packed[1:]))
+	assert.Equal(lambda.Args[2], unpacked.Args[1].Args[1].Args[0].Args[2])
+
+	fa := codec.NewOp().WithSource(mock.GetRandomAddress())
+	assert.Nil(common.InjectTransferContents(fa, fa.Source, &common.PayoutRecipe{
+		Recipient:  first,
+		TxKind:     enums.PAYOUT_TX_KIND_FA1_2,
+		FAContract: tezos.MustParseAddress("KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"),
+		Amount:     tezos.NewZ(10),
+	}))
+	_, err = buildMultisigLambda(fa.Contents)
+	assert.True(errors.Is(err, constants.ErrMultisigUnsupportedTransfer))
+}
+
+func TestCollectMultisigSignatures(t *testing.T) {
+	assert := assert.New(t)
+
+	a, b, c := generateMultisigSigner(t), generateMultisigSigner(t), generateMultisigSigner(t)
+	storage := &multisigStorage{
+		Counter:   big.NewInt(0),
+		Threshold: 2,
+		Keys:      []tezos.Key{a.GetKey(), b.GetKey(), c.GetKey()},
+	}
+	data := []byte{0x05, 0x01, 0x02}
+	digest := blake2b.Sum256(data)
+
+	transactor := &MultisigTransactor{signers: []common.SignerEngine{c, a}}
+	signatures, err := transactor.collectSignatures(data, storage)
+	assert.Nil(err)
+	assert.Len(signatures.Args, 3)
+	assert.Equal(micheline.D_SOME, signatures.Args[0].OpCode)
+	assert.Equal(micheline.D_NONE, signatures.Args[1].OpCode)
+	assert.Equal(micheline.D_SOME, signatures.Args[2].OpCode)
+	signature, err := tezos.ParseSignature(signatures.Args[0].Args[0].String)
+	assert.Nil(err)
+	assert.Nil(a.GetKey().Verify(digest[:], signature))
+
+	transactor = &MultisigTransactor{signers: []common.SignerEngine{a}}
+	_, err = transactor.collectSignatures(data, storage)
+	assert.True(errors.Is(err, constants.ErrMultisigThresholdNotMet))
+}
@@ -2,6 +2,7 @@ package tzkt
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
@@ -10,6 +11,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
@@ -340,12 +342,16 @@ func (client *Client) GetCycleData(ctx context.Context, chainId tezos.ChainIdHas
 		IdealDalDelegatedRewards:          dalDelegatedRewards.Add(delegationShare.Mul64(tzktBakerCycleData.MissedDalRewards).Div64(precision)),
 		BlockDelegatedFees:                blockDelegatedFees,
 
-		StakersCount:                  tzktBakerCycleData.StakersCount,
-		OwnStakedBalance:              tezos.NewZ(tzktBakerCycleData.OwnStakedBalance),
-		ExternalStakedBalance:         tezos.NewZ(tzktBakerCycleData.ExternalStakedBalance),
-		BlockStakingRewardsEdge:       tezos.NewZ(tzktBakerCycleData.BlockRewardsStakedEdge),
-		AttestationStakingRewardsEdge: tezos.NewZ(tzktBakerCycleData.AttestationRewardsStakedEdge),
-		BlockStakingFees:              tezos.Zero, // block fees are distributed as liquid balance only
+		StakersCount:                    tzktBakerCycleData.StakersCount,
+		OwnStakedBalance:                tezos.NewZ(tzktBakerCycleData.OwnStakedBalance),
+		ExternalStakedBalance:           tezos.NewZ(tzktBakerCycleData.ExternalStakedBalance),
+		BlockStakingRewardsEdge:         tezos.NewZ(tzktBakerCycleData.BlockRewardsStakedEdge),
+		AttestationStakingRewardsEdge:   tezos.NewZ(tzktBakerCycleData.AttestationRewardsStakedEdge),
+		DalStakingRewardsEdge:           tezos.NewZ(tzktBakerCycleData.DalRewardsStakedEdge),
+		BlockStakingRewardsShared:       tezos.NewZ(tzktBakerCycleData.BlockRewardsStakedShared),
+		AttestationStakingRewardsShared: tezos.NewZ(tzktBakerCycleData.AttestationRewardsStakedShared),
+		DalStakingRewardsShared:         tezos.NewZ(tzktBakerCycleData.DalRewardsStakedShared),
+		BlockStakingFees:                tezos.Zero, // block fees are distributed as liquid balance only
 
 		FrozenDepositLimit: tezos.NewZ(tzktBakerData.FrozenDepositLimit),
 		Delegators: lo.Map(collectedDelegators, func(delegator splitDelegator, _ int) common.Delegator {
@@ -390,3 +396,125 @@ func (client *Client) WasOperationApplied(ctx context.Context, opHash tezos.OpHa
 	}
 	return common.OPERATION_STATUS_NOT_EXISTS, nil
 }
+
+type tzktTransaction struct {
+	Hash      string    `json:"hash"`
+	Amount    int64     `json:"amount"`
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// https://api.tzkt.io/v1/operations/transactions?sender=${source}&target=${target}&timestamp.ge=${since}&timestamp.le=${until}
+func (client *Client) GetTransactions(ctx context.Context, source tezos.Address, target tezos.Address, since time.Time, until time.Time) ([]common.TransactionInfo, error) {
+	query := url.Values{}
+	query.Set("sender", source.String())
+	query.Set("target", target.String())
+	query.Set("timestamp.ge", since.UTC().Format(time.RFC3339))
+	query.Set("timestamp.le", until.UTC().Format(time.RFC3339))
+	query.Set("select", "hash,amount,status,timestamp")
+	query.Set("limit", "1000")
+
+	u := fmt.Sprintf("v1/operations/transactions?%s", query.Encode())
+	slog.Debug("getting transactions", "source", source.String(), "target", target.String(), "url", u)
+	resp, err := client.Get(ctx, u)
+	if err != nil {
+		return nil, errors.Join(constants.ErrTransactionsFetchFailed, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Join(constants.ErrTransactionsFetchFailed, fmt.Errorf("unexpected status code %d", resp.StatusCode))
+	}
+
+	transactions := make([]tzktTransaction, 0)
+	if err := unmarshallTzktResponse(resp, &transactions); err != nil {
+		return nil, errors.Join(constants.ErrTransactionsFetchFailed, err)
+	}
+
+	result := make([]common.TransactionInfo, 0, len(transactions))
+	for _, transaction := range transactions {
+		opHash, err := tezos.ParseOpHash(transaction.Hash)
+		if err != nil {
+			return nil, errors.Join(constants.ErrTransactionsFetchFailed, err)
+		}
+		status := common.OPERATION_STATUS_FAILED
+		if transaction.Status == "applied" {
+			status = common.OPERATION_STATUS_APPLIED
+		}
+		result = append(result, common.TransactionInfo{
+			OpHash:    opHash,
+			Amount:    tezos.NewZ(transaction.Amount),
+			Status:    status,
+			Timestamp: transaction.Timestamp,
+		})
+	}
+	return result, nil
+}
+
+type tzktDelegation struct {
+	Address         string `json:"address"`
+	DelegationLevel int64  `json:"delegationLevel"`
+}
+
+type tzktCycle struct {
+	Index      int64 `json:"index"`
+	FirstLevel int64 `json:"firstLevel"`
+}
+
+func (client *Client) getDelegations(ctx context.Context, baker tezos.Address) ([]tzktDelegation, error) {
+	result := make([]tzktDelegation, 0)
+	for offset := 0; ; offset += DELEGATOR_FETCH_LIMIT {
+		u := fmt.Sprintf("v1/delegates/%s/delegators?select=address%%2CdelegationLevel&limit=%d&offset=%d", baker.String(), DELEGATOR_FETCH_LIMIT, offset)
+		slog.Debug("getting delegations", "baker", baker.String(), "url", u)
+		resp, err := client.Get(ctx, u)
+		if err != nil {
+			return nil, errors.Join(constants.ErrDelegationsFetchFailed, err)
+		}
+		page := make([]tzktDelegation, 0)
+		if err := unmarshallTzktResponse(resp, &page); err != nil {
+			return nil, errors.Join(constants.ErrDelegationsFetchFailed, err)
+		}
+		result = append(result, page...)
+		if len(page) < DELEGATOR_FETCH_LIMIT {
+			return result, nil
+		}
+	}
+}
+
+// https://api.tzkt.io/v1/delegates/${baker}/delegators?select=address,delegationLevel
+// https://api.tzkt.io/v1/cycles?select=index,firstLevel
+func (client *Client) GetDelegationStartCycles(ctx context.Context, baker tezos.Address) (map[string]int64, error) {
+	delegations, err := client.getDelegations(ctx, baker)
+	if err != nil {
+		return nil, err
+	}
+
+	u := "v1/cycles?select=index%2CfirstLevel&limit=10000"
+	slog.Debug("getting cycles", "url", u)
+	resp, err := client.Get(ctx, u)
+	if err != nil {
+		return nil, errors.Join(constants.ErrDelegationsFetchFailed, err)
+	}
+	cycles := make([]tzktCycle, 0)
+	if err := unmarshallTzktResponse(resp, &cycles); err != nil {
+		return nil, errors.Join(constants.ErrDelegationsFetchFailed, err)
+	}
+	slices.SortFunc(cycles, func(a, b tzktCycle) int {
+		return cmp.Compare(a.FirstLevel, b.FirstLevel)
+	})
+
+	result := make(map[string]int64, len(delegations))
+	for _, delegation := range delegations {
+		// last cycle starting at or before delegation level
+		index, found := slices.BinarySearchFunc(cycles, delegation.DelegationLevel, func(cycle tzktCycle, level int64) int {
+			return cmp.Compare(cycle.FirstLevel, level)
+		})
+		if !found {
+			index--
+		}
+		if index < 0 {
+			continue
+		}
+		result[delegation.Address] = cycles[index].Index
+	}
+	return result, nil
+}
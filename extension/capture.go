@@ -0,0 +1,120 @@
+package extension
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+)
+
+// HookCapture is data passed to the hook during dry run, it can be replayed against extensions later
+type HookCapture struct {
+	Hook      enums.EExtensionHook `json:"hook"`
+	Version   string               `json:"version"`
+	Timestamp time.Time            `json:"timestamp"`
+	Cycles    []int64              `json:"cycles"`
+	Data      json.RawMessage      `json:"data"`
+}
+
+// EnableHookCapture stores data of all executed hooks into directory, one file per hook and cycle
+func EnableHookCapture(directory string) {
+	extensionStore.captureDirectory = directory
+}
+
+func getCapturedCycles(value any) []int64 {
+	cycles := make([]int64, 0)
+	switch v := value.(type) {
+	case map[string]any:
+		for key, fieldValue := range v {
+			if key != "cycle" && key != "Cycle" {
+				cycles = append(cycles, getCapturedCycles(fieldValue)...)
+				continue
+			}
+			if cycle, err := strconv.ParseInt(fmt.Sprint(fieldValue), 10, 64); err == nil {
+				cycles = append(cycles, cycle)
+			}
+		}
+	case []any:
+		for _, item := range v {
+			cycles = append(cycles, getCapturedCycles(item)...)
+		}
+	}
+	cycles = lo.Uniq(cycles)
+	slices.Sort(cycles)
+	return cycles
+}
+
+func getHookCaptureFilePath(directory string, hook enums.EExtensionHook, cycle int64) string {
+	return path.Join(directory, string(hook), fmt.Sprintf("%d.json", cycle))
+}
+
+func captureHookData(hook enums.EExtensionHook, version string, data any) error {
+	directory := extensionStore.captureDirectory
+	if directory == "" || hook == enums.EXTENSION_HOOK_TEST_NOTIFY || hook == enums.EXTENSION_HOOK_TEST_REQUEST {
+		return nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	value, err := unmarshalHookData(data)
+	if err != nil {
+		return err
+	}
+	capture := HookCapture{
+		Hook:      hook,
+		Version:   version,
+		Timestamp: time.Now().UTC(),
+		Cycles:    getCapturedCycles(value),
+		Data:      raw,
+	}
+	serialized, err := json.MarshalIndent(capture, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(path.Join(directory, string(hook)), 0755); err != nil {
+		return err
+	}
+	for _, cycle := range capture.Cycles {
+		if err := os.WriteFile(getHookCaptureFilePath(directory, hook, cycle), serialized, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadHookCapture loads the latest data captured for the hook and cycle
+func LoadHookCapture(directory string, hook enums.EExtensionHook, cycle int64) (*HookCapture, error) {
+	raw, err := os.ReadFile(getHookCaptureFilePath(directory, hook, cycle))
+	if err != nil {
+		return nil, errors.Join(constants.ErrExtensionHookCaptureLoadFailed, err)
+	}
+	var capture HookCapture
+	if err := json.Unmarshal(raw, &capture); err != nil {
+		return nil, errors.Join(constants.ErrExtensionHookCaptureLoadFailed, err)
+	}
+	return &capture, nil
+}
+
+// ReplayHook executes the hook with captured data and returns changes made by extensions
+func ReplayHook(capture *HookCapture) (*HookDataDiff, error) {
+	var before, data any
+	if err := json.Unmarshal(capture.Data, &before); err != nil {
+		return nil, errors.Join(constants.ErrExtensionHookCaptureLoadFailed, err)
+	}
+	if err := json.Unmarshal(capture.Data, &data); err != nil {
+		return nil, errors.Join(constants.ErrExtensionHookCaptureLoadFailed, err)
+	}
+	if err := ExecuteHook(capture.Hook, capture.Version, &data); err != nil {
+		return nil, err
+	}
+	return DiffHookData(before, data)
+}
@@ -0,0 +1,55 @@
+package extension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/constants/enums"
+)
+
+type testCapturedHookData struct {
+	Cycle   int64          `json:"cycle"`
+	Payouts []testHookItem `json:"payouts"`
+}
+
+func TestHookCapture(t *testing.T) {
+	assert := assert.New(t)
+
+	directory := t.TempDir()
+	extensionStore = ExtensionStore{}
+	defer func() { extensionStore = ExtensionStore{} }()
+
+	data := testCapturedHookData{Cycle: 580, Payouts: []testHookItem{{"tz1a", "tz1a", 0.05, "100"}}}
+	// capture is disabled by default
+	assert.Nil(ExecuteHook(enums.EXTENSION_HOOK_AFTER_CANDIDATES_GENERATED, "0.2", &data))
+	_, err := LoadHookCapture(directory, enums.EXTENSION_HOOK_AFTER_CANDIDATES_GENERATED, 580)
+	assert.NotNil(err)
+
+	EnableHookCapture(directory)
+	assert.Nil(ExecuteHook(enums.EXTENSION_HOOK_AFTER_CANDIDATES_GENERATED, "0.2", &data))
+	assert.Nil(ExecuteHook(enums.EXTENSION_HOOK_TEST_NOTIFY, "0.1", &data))
+
+	capture, err := LoadHookCapture(directory, enums.EXTENSION_HOOK_AFTER_CANDIDATES_GENERATED, 580)
+	assert.Nil(err)
+	assert.Equal("0.2", capture.Version)
+	assert.Equal([]int64{580}, capture.Cycles)
+	_, err = LoadHookCapture(directory, enums.EXTENSION_HOOK_TEST_NOTIFY, 580)
+	assert.NotNil(err)
+
+	// no extensions configured, nothing changes
+	diff, err := ReplayHook(capture)
+	assert.Nil(err)
+	assert.True(diff.IsEmpty())
+	assert.Equal("100", diff.TotalBefore.String())
+}
+
+func TestGetCapturedCycles(t *testing.T) {
+	assert := assert.New(t)
+
+	value, err := unmarshalHookData(map[string]any{
+		"payouts": []map[string]any{{"cycle": 581}, {"cycle": 580}, {"cycle": 581}},
+		"reports": []map[string]any{{"cycle": 579}},
+	})
+	assert.Nil(err)
+	assert.Equal([]int64{579, 580, 581}, getCapturedCycles(value))
+}
@@ -2,9 +2,12 @@ package extension
 
 import (
 	"context"
+	"errors"
 	"io"
+	"net/http"
 
 	rpc "github.com/alis-is/jsonrpc2"
+	"github.com/gorilla/websocket"
 	"github.com/tez-capital/tezpay/constants"
 )
 
@@ -39,3 +42,45 @@ func NewPlainObjectStream(rw io.ReadWriteCloser) rpc.ObjectStream {
 func NewStreamEndpoint(ctx context.Context, stream rpc.ObjectStream) *rpc.StreamEndpoint {
 	return rpc.NewStreamEndpoint(ctx, stream)
 }
+
+type notification interface {
+	IsNotification() bool
+}
+
+// httpClientEndpoint accepts empty responses to notifications, http servers are not expected to respond to them
+type httpClientEndpoint struct {
+	*rpc.HttpClientEndpoint
+}
+
+func (c *httpClientEndpoint) WriteObject(obj interface{}) error {
+	err := c.HttpClientEndpoint.WriteObject(obj)
+	if n, ok := obj.(notification); ok && n.IsNotification() && errors.Is(err, rpc.ErrEmptyResponse) {
+		return nil
+	}
+	return err
+}
+
+func NewHttpClientEndpoint(url string, client *http.Client) EndpointClient {
+	return &httpClientEndpoint{rpc.NewHttpClientEndpoint(url, client)}
+}
+
+type webSocketObjectStream struct {
+	conn *websocket.Conn
+}
+
+// each JSON-RPC 2.0 object is sent as a single text message
+func NewWebSocketObjectStream(conn *websocket.Conn) rpc.ObjectStream {
+	return &webSocketObjectStream{conn: conn}
+}
+
+func (s *webSocketObjectStream) WriteObject(obj interface{}) error {
+	return s.conn.WriteJSON(obj)
+}
+
+func (s *webSocketObjectStream) ReadObject(v interface{}) error {
+	return s.conn.ReadJSON(v)
+}
+
+func (s *webSocketObjectStream) Close() error {
+	return s.conn.Close()
+}
@@ -0,0 +1,48 @@
+// Package examples provides example extensions as templates for new extensions
+package examples
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/constants"
+)
+
+//go:embed log/main.go log/log.lua
+var templates embed.FS
+
+type Template struct {
+	Source   string
+	FileName string
+	Command  string
+	Args     []string
+}
+
+var (
+	TEMPLATES = map[string]Template{
+		"go":  {Source: "log/main.go", FileName: "main.go", Command: "go", Args: []string{"run", "main.go"}},
+		"lua": {Source: "log/log.lua", FileName: "main.lua", Command: "eli", Args: []string{"main.lua"}},
+	}
+)
+
+func GetTemplateLanguages() []string {
+	languages := lo.Keys(TEMPLATES)
+	slices.Sort(languages)
+	return languages
+}
+
+// GetTemplate returns template of the extension for the language and its content
+func GetTemplate(language string) (Template, []byte, error) {
+	template, ok := TEMPLATES[language]
+	if !ok {
+		return template, nil, errors.Join(constants.ErrExtensionScaffoldFailed, fmt.Errorf("unsupported language '%s', supported: %v", language, GetTemplateLanguages()))
+	}
+	content, err := templates.ReadFile(template.Source)
+	if err != nil {
+		return template, nil, errors.Join(constants.ErrExtensionScaffoldFailed, err)
+	}
+	return template, content, nil
+}
@@ -33,7 +33,10 @@ func RegisterExtension(ctx context.Context, def common.ExtensionDefinition) (Ext
 		return newStdioExtension(ctx, def), nil
 	case enums.EXTENSION_TCP_RPC:
 		return newTcpExtension(ctx, def), nil
-		// TODO: http and WS
+	case enums.EXTENSION_HTTP_RPC:
+		return newHttpExtension(ctx, def), nil
+	case enums.EXTENSION_WS_RPC:
+		return newWsExtension(ctx, def), nil
 	default:
 		return nil, errors.Join(constants.ErrUnsupportedExtensionKind, fmt.Errorf("kind - \"%s\"", def.Kind))
 	}
@@ -2,6 +2,7 @@ package extension
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -9,21 +10,26 @@ import (
 
 	rpc "github.com/alis-is/jsonrpc2"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/tez-capital/tezpay/common"
 	"github.com/tez-capital/tezpay/constants"
 	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/metrics"
 )
 
 type ExtensionStoreEnviromnent struct {
 	BakerPKH  string   `json:"baker_pkh"`
 	PayoutPKH string   `json:"payout_pkh"`
 	RpcPool   []string `json:"rpc_pool"`
+	// changes made by rw extensions are recorded here, not shared with extensions
+	AuditFile string `json:"-"`
 }
 
 type ExtensionStore struct {
-	id          uuid.UUID
-	extensions  []Extension
-	environment *ExtensionStoreEnviromnent
+	id               uuid.UUID
+	extensions       []Extension
+	environment      *ExtensionStoreEnviromnent
+	captureDirectory string
 }
 
 var (
@@ -70,6 +76,39 @@ func CloseScopedExtensions() {
 	}
 }
 
+// SetEnvironmentBaker switches baker and payout wallet passed to extensions (baker profiles),
+// loaded extensions are closed so they are initialized with the new baker on next hook
+func SetEnvironmentBaker(bakerPKH, payoutPKH string) {
+	env := extensionStore.environment
+	if env == nil || (env.BakerPKH == bakerPKH && env.PayoutPKH == payoutPKH) {
+		return
+	}
+	env.BakerPKH = bakerPKH
+	env.PayoutPKH = payoutPKH
+	CloseExtensions()
+}
+
+type ExtensionHealth struct {
+	Definition common.ExtensionDefinition
+	Err        error
+}
+
+// CheckExtensionsHealth loads all extensions and calls healthcheck on them
+func CheckExtensionsHealth() []ExtensionHealth {
+	return lo.Map(extensionStore.extensions, func(ext Extension, _ int) ExtensionHealth {
+		err := LoadExtension(ext)
+		if err == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), ext.GetTimeout())
+			defer cancel()
+			err = Notify[any](ctx, ext.GetEndpoint(), string(enums.EXTENSION_HEALTHCHECK_CALL), nil)
+		}
+		return ExtensionHealth{
+			Definition: ext.GetDefinition(),
+			Err:        err,
+		}
+	})
+}
+
 func CloseExtensions() {
 	for _, ext := range extensionStore.extensions {
 		closeExtension(ext)
@@ -80,6 +119,9 @@ func ExecuteHook[TData rpc.Result](hook enums.EExtensionHook, version string, da
 	if data == nil {
 		return constants.ErrExtensionHookMissingData
 	}
+	if err := captureHookData(hook, version, data); err != nil {
+		slog.Warn("failed to capture hook data", "hook", hook, "error", err.Error())
+	}
 
 	for _, ext := range extensionStore.extensions {
 		def := ext.GetDefinition()
@@ -126,6 +168,7 @@ func ExecuteHook[TData rpc.Result](hook enums.EExtensionHook, version string, da
 
 			ctx, cancel := context.WithTimeout(context.Background(), ext.GetTimeout())
 			defer cancel()
+			startedAt := time.Now()
 
 			switch matchedMode {
 			case enums.EXTENSION_HOOK_MODE_READ_ONLY:
@@ -151,6 +194,9 @@ func ExecuteHook[TData rpc.Result](hook enums.EExtensionHook, version string, da
 						err = nil
 						break
 					}
+					if err == nil {
+						err = sandboxHookResult(def, hook, *data, responseResult)
+					}
 					if err == nil {
 						*data = responseResult
 					}
@@ -159,11 +205,13 @@ func ExecuteHook[TData rpc.Result](hook enums.EExtensionHook, version string, da
 				// no hook matched
 				continue
 			}
-			if err == nil {
+			metrics.ExtensionHookDuration.WithLabelValues(string(hook), def.Name).Observe(time.Since(startedAt).Seconds())
+			if err == nil || errors.Is(err, constants.ErrExtensionPermissionDenied) {
 				break
 			}
 		}
 		if err != nil {
+			metrics.ExtensionHookFailures.WithLabelValues(string(hook), def.Name).Inc()
 			switch def.ErrorAction {
 			case enums.EXTENSION_ERROR_ACTION_CONTINUE:
 			default:
@@ -0,0 +1,23 @@
+package extension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetEnvironmentBaker(t *testing.T) {
+	assert := assert.New(t)
+
+	// without environment it is noop
+	extensionStore = ExtensionStore{}
+	SetEnvironmentBaker("tz1baker", "tz1payout")
+
+	extensionStore = ExtensionStore{environment: &ExtensionStoreEnviromnent{BakerPKH: "tz1main", PayoutPKH: "tz1wallet", AuditFile: "audit.jsonl"}}
+	defer func() { extensionStore = ExtensionStore{} }()
+
+	SetEnvironmentBaker("tz1profile", "tz1profilewallet")
+	assert.Equal("tz1profile", extensionStore.environment.BakerPKH)
+	assert.Equal("tz1profilewallet", extensionStore.environment.PayoutPKH)
+	assert.Equal("audit.jsonl", extensionStore.environment.AuditFile)
+}
@@ -0,0 +1,194 @@
+package extension
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+)
+
+func newTlsConfig(options *common.ExtensionTlsOptions) (*tls.Config, error) {
+	if options == nil {
+		return nil, nil
+	}
+	config := &tls.Config{
+		InsecureSkipVerify: options.InsecureSkipVerify,
+	}
+	if options.CaFile != "" {
+		ca, err := os.ReadFile(options.CaFile)
+		if err != nil {
+			return nil, errors.Join(constants.ErrExtensionTlsConfigurationFailed, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.Join(constants.ErrExtensionTlsConfigurationFailed, fmt.Errorf("no certificates found in %s", options.CaFile))
+		}
+		config.RootCAs = pool
+	}
+	if options.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(options.CertFile, options.KeyFile)
+		if err != nil {
+			return nil, errors.Join(constants.ErrExtensionTlsConfigurationFailed, err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+func getHeaders(def common.ExtensionDefinition) http.Header {
+	headers := make(http.Header, len(def.Headers))
+	for k, v := range def.Headers {
+		headers.Set(k, v)
+	}
+	return headers
+}
+
+// headerTransport injects configured headers into every request
+type headerTransport struct {
+	headers http.Header
+	base    http.RoundTripper
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for k, v := range t.headers {
+		req.Header[k] = v
+	}
+	return t.base.RoundTrip(req)
+}
+
+type HttpExtension struct {
+	ExtensionBase
+	ctx context.Context
+}
+
+func newHttpExtension(ctx context.Context, def common.ExtensionDefinition) Extension {
+	return &HttpExtension{
+		ExtensionBase: ExtensionBase{
+			definition: def,
+		},
+		ctx: ctx,
+	}
+}
+
+func (e *HttpExtension) Load() error {
+	tlsConfig, err := newTlsConfig(e.definition.Tls)
+	if err != nil {
+		return err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	client := &http.Client{
+		Timeout: e.GetTimeout(),
+		Transport: &headerTransport{
+			headers: getHeaders(e.definition),
+			base:    transport,
+		},
+	}
+
+	endpoint := NewHttpClientEndpoint(e.definition.Url, client)
+	endpoint.UseLogger(slog.Default().With("extension", e.definition.Name))
+	e.endpoint = endpoint
+	e.loaded = true
+	return nil
+}
+
+// IsLoaded checks health of the service, the extension is initialized again after the service was unreachable
+func (e *HttpExtension) IsLoaded() bool {
+	if e.endpoint == nil {
+		return false
+	}
+	err := Notify[any](e.ctx, e.endpoint, string(enums.EXTENSION_HEALTHCHECK_CALL), nil)
+	return e.loaded && err == nil
+}
+
+func (e *HttpExtension) Close() error {
+	e.loaded = false
+	return nil
+}
+
+type WsExtension struct {
+	ExtensionBase
+	ctx context.Context
+}
+
+func newWsExtension(ctx context.Context, def common.ExtensionDefinition) Extension {
+	return &WsExtension{
+		ExtensionBase: ExtensionBase{
+			definition: def,
+		},
+		ctx: ctx,
+	}
+}
+
+func (e *WsExtension) dial() (*websocket.Conn, error) {
+	tlsConfig, err := newTlsConfig(e.definition.Tls)
+	if err != nil {
+		return nil, err
+	}
+	dialer := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: e.GetTimeout(),
+		TLSClientConfig:  tlsConfig,
+	}
+
+	attempts := e.definition.GetReconnectAttempts()
+	for i := 0; ; i++ {
+		conn, _, err := dialer.DialContext(e.ctx, e.definition.Url, getHeaders(e.definition))
+		if err == nil {
+			return conn, nil
+		}
+		if i+1 >= attempts {
+			return nil, errors.Join(constants.ErrExtensionConnectionFailed, err)
+		}
+		slog.Warn("failed to connect to extension, retrying", "extension", e.definition.Name, "attempt", i+1, "error", err.Error())
+		time.Sleep(time.Duration(e.definition.GetReconnectDelay()) * time.Second)
+	}
+}
+
+// Load connects to the service, dropped connection is detected by IsLoaded and reconnected on next hook
+func (e *WsExtension) Load() error {
+	if e.endpoint != nil {
+		// cleanup old endpoint
+		e.endpoint.Close()
+	}
+	conn, err := e.dial()
+	if err != nil {
+		return err
+	}
+
+	streamEndpoint := NewStreamEndpoint(e.ctx, NewWebSocketObjectStream(conn))
+	streamEndpoint.UseLogger(slog.Default().With("extension", e.definition.Name))
+	e.endpoint = streamEndpoint
+	e.loaded = true
+	return nil
+}
+
+func (e *WsExtension) IsLoaded() bool {
+	if e.endpoint == nil || e.endpoint.IsClosed() {
+		return false
+	}
+	err := Notify[any](e.ctx, e.endpoint, string(enums.EXTENSION_HEALTHCHECK_CALL), nil)
+	return e.loaded && err == nil
+}
+
+func (e *WsExtension) Close() error {
+	if !e.loaded {
+		return nil
+	}
+	e.loaded = false
+	if e.endpoint == nil {
+		return nil
+	}
+	return e.endpoint.Close()
+}
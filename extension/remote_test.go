@@ -0,0 +1,119 @@
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants/enums"
+)
+
+type testRpcMessage struct {
+	Id     *json.RawMessage `json:"id,omitempty"`
+	Method string           `json:"method"`
+}
+
+func testRpcResponse(msg testRpcMessage) map[string]any {
+	return map[string]any{"jsonrpc": "2.0", "id": msg.Id, "result": common.ExtensionInitializationResult{Success: true, Message: msg.Method}}
+}
+
+func requestInit(t *testing.T, ext Extension) string {
+	response, err := Request[common.ExtensionInitializationMessage, common.ExtensionInitializationResult](context.Background(), ext.GetEndpoint(), string(enums.EXTENSION_INIT_CALL), common.ExtensionInitializationMessage{})
+	assert.Nil(t, err)
+	result, err := response.Unwrap()
+	assert.Nil(t, err)
+	return result.Message
+}
+
+func TestHttpExtension(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte("unauthorized"))
+			return
+		}
+		var msg testRpcMessage
+		assert.Nil(json.NewDecoder(r.Body).Decode(&msg))
+		if msg.Id == nil { // notifications are not responded
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		json.NewEncoder(w).Encode(testRpcResponse(msg))
+	}))
+	defer server.Close()
+
+	def := common.ExtensionDefinition{Name: "http", Kind: enums.EXTENSION_HTTP_RPC, Url: server.URL, Headers: map[string]string{"Authorization": "Bearer secret"}}
+	ext, err := RegisterExtension(context.Background(), def)
+	assert.Nil(err)
+	assert.False(ext.IsLoaded())
+	assert.Nil(ext.Load())
+	assert.True(ext.IsLoaded())
+	assert.True(strings.HasSuffix(requestInit(t, ext), string(enums.EXTENSION_INIT_CALL)))
+
+	def.Headers = nil
+	unauthorized, _ := RegisterExtension(context.Background(), def)
+	assert.Nil(unauthorized.Load())
+	assert.False(unauthorized.IsLoaded())
+}
+
+func TestWsExtension(t *testing.T) {
+	assert := assert.New(t)
+
+	connections := make(chan *websocket.Conn, 2)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		connections <- conn
+		for {
+			var msg testRpcMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			if msg.Id != nil {
+				conn.WriteJSON(testRpcResponse(msg))
+			}
+		}
+	}))
+	defer server.Close()
+
+	def := common.ExtensionDefinition{
+		Name:      "ws",
+		Kind:      enums.EXTENSION_WS_RPC,
+		Url:       "ws" + strings.TrimPrefix(server.URL, "http"),
+		Headers:   map[string]string{"Authorization": "Bearer secret"},
+		Reconnect: &common.ExtensionReconnectOptions{Attempts: 2, Delay: 1},
+	}
+	ext, err := RegisterExtension(context.Background(), def)
+	assert.Nil(err)
+	assert.Nil(ext.Load())
+	assert.True(ext.IsLoaded())
+	assert.True(strings.HasSuffix(requestInit(t, ext), string(enums.EXTENSION_INIT_CALL)))
+
+	// dropped connection is detected and reconnected
+	(<-connections).Close()
+	assert.Eventually(func() bool { return !ext.IsLoaded() }, time.Second*5, time.Millisecond*50)
+	assert.Nil(ext.Load())
+	assert.True(ext.IsLoaded())
+	assert.True(strings.HasSuffix(requestInit(t, ext), string(enums.EXTENSION_INIT_CALL)))
+	assert.Nil(ext.Close())
+
+	def.Headers = nil
+	unauthorized, _ := RegisterExtension(context.Background(), def)
+	assert.NotNil(unauthorized.Load())
+}
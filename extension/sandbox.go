@@ -0,0 +1,278 @@
+package extension
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+)
+
+type EHookDataChangeKind string
+
+const (
+	HOOK_DATA_CHANGE_ADDED   EHookDataChangeKind = "added"
+	HOOK_DATA_CHANGE_REMOVED EHookDataChangeKind = "removed"
+	HOOK_DATA_CHANGE_CHANGED EHookDataChangeKind = "changed"
+)
+
+var (
+	// fields identifying items of lists, e.g. candidates by source, cycle data delegators by address or recipes by delegator, cycle and kind
+	hookDataIdentityFields = []string{"source", "delegator", "address", "cycle", "kind", "tx_kind", "fa_contract", "fa_token_id"}
+	// fields summed up to total payout amount, cycle data rewards and fees included as payouts are computed from them
+	hookDataAmountFields = []string{
+		"amount", "bonds_amount",
+		"block_delegated_rewards", "ideal_block_delegated_rewards",
+		"attestations_delegated_rewards", "ideal_attestations_delegated_rewards",
+		"dal_delegated_rewards", "ideal_dal_delegated_rewards", "block_delegated_fees",
+		"block_staking_rewards_edge", "attestation_staking_rewards_edge", "dal_staking_rewards_edge",
+		"block_staking_rewards_shared", "attestation_staking_rewards_shared", "dal_staking_rewards_shared", "block_staking_fees",
+	}
+)
+
+type HookDataChange struct {
+	Path   string              `json:"path"`
+	Field  string              `json:"field,omitempty"`
+	Kind   EHookDataChangeKind `json:"kind"`
+	Before any                 `json:"before,omitempty"`
+	After  any                 `json:"after,omitempty"`
+}
+
+type HookDataDiff struct {
+	Changes     []HookDataChange `json:"changes"`
+	TotalBefore *big.Int         `json:"total_before"`
+	TotalAfter  *big.Int         `json:"total_after"`
+}
+
+func (diff *HookDataDiff) IsEmpty() bool {
+	return len(diff.Changes) == 0
+}
+
+func unmarshalHookData(data any) (any, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var result any
+	err = decoder.Decode(&result)
+	return result, err
+}
+
+func getItemIdentity(item any) (string, bool) {
+	object, ok := item.(map[string]any)
+	if !ok {
+		return "", false
+	}
+	parts := make([]string, 0, len(hookDataIdentityFields))
+	for _, field := range hookDataIdentityFields {
+		if value, ok := object[field]; ok {
+			parts = append(parts, fmt.Sprintf("%s=%v", field, value))
+		}
+	}
+	return strings.Join(parts, ","), len(parts) > 0
+}
+
+// getItemIdentities returns identities of list items, nil if items can not be identified uniquely
+func getItemIdentities(items []any) []string {
+	identities := make([]string, 0, len(items))
+	for _, item := range items {
+		identity, ok := getItemIdentity(item)
+		if !ok || slices.Contains(identities, identity) {
+			return nil
+		}
+		identities = append(identities, identity)
+	}
+	return identities
+}
+
+func diffLists(path, field string, before, after []any, changes []HookDataChange) []HookDataChange {
+	beforeIdentities, afterIdentities := getItemIdentities(before), getItemIdentities(after)
+	if beforeIdentities == nil || afterIdentities == nil {
+		// items matched by position
+		for i := 0; i < max(len(before), len(after)); i++ {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(after):
+				changes = append(changes, HookDataChange{Path: itemPath, Field: field, Kind: HOOK_DATA_CHANGE_REMOVED, Before: before[i]})
+			case i >= len(before):
+				changes = append(changes, HookDataChange{Path: itemPath, Field: field, Kind: HOOK_DATA_CHANGE_ADDED, After: after[i]})
+			default:
+				changes = diffValues(itemPath, field, before[i], after[i], changes)
+			}
+		}
+		return changes
+	}
+
+	for i, identity := range beforeIdentities {
+		itemPath := fmt.Sprintf("%s[%s]", path, identity)
+		j := slices.Index(afterIdentities, identity)
+		if j < 0 {
+			changes = append(changes, HookDataChange{Path: itemPath, Field: field, Kind: HOOK_DATA_CHANGE_REMOVED, Before: before[i]})
+			continue
+		}
+		changes = diffValues(itemPath, field, before[i], after[j], changes)
+	}
+	for j, identity := range afterIdentities {
+		if !slices.Contains(beforeIdentities, identity) {
+			changes = append(changes, HookDataChange{Path: fmt.Sprintf("%s[%s]", path, identity), Field: field, Kind: HOOK_DATA_CHANGE_ADDED, After: after[j]})
+		}
+	}
+	return changes
+}
+
+func diffValues(path, field string, before, after any, changes []HookDataChange) []HookDataChange {
+	beforeObject, isBeforeObject := before.(map[string]any)
+	afterObject, isAfterObject := after.(map[string]any)
+	if isBeforeObject && isAfterObject {
+		keys := lo.Uniq(append(lo.Keys(beforeObject), lo.Keys(afterObject)...))
+		slices.Sort(keys)
+		for _, key := range keys {
+			changes = diffValues(strings.TrimPrefix(path+"."+key, "."), key, beforeObject[key], afterObject[key], changes)
+		}
+		return changes
+	}
+
+	beforeList, isBeforeList := before.([]any)
+	afterList, isAfterList := after.([]any)
+	if (isBeforeList || before == nil) && (isAfterList || after == nil) && (isBeforeList || isAfterList) {
+		return diffLists(path, field, beforeList, afterList, changes)
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		changes = append(changes, HookDataChange{Path: path, Field: field, Kind: HOOK_DATA_CHANGE_CHANGED, Before: before, After: after})
+	}
+	return changes
+}
+
+// getTotalAmount sums up amount fields across the whole hook data
+func getTotalAmount(value any) *big.Int {
+	total := new(big.Int)
+	switch v := value.(type) {
+	case map[string]any:
+		for key, fieldValue := range v {
+			if !slices.Contains(hookDataAmountFields, key) {
+				total.Add(total, getTotalAmount(fieldValue))
+				continue
+			}
+			if amount, ok := new(big.Int).SetString(strings.Trim(fmt.Sprint(fieldValue), "\""), 10); ok {
+				total.Add(total, amount)
+			}
+		}
+	case []any:
+		for _, item := range v {
+			total.Add(total, getTotalAmount(item))
+		}
+	}
+	return total
+}
+
+// DiffHookData computes structured diff between hook data sent to the extension and data returned by it
+func DiffHookData(before, after any) (*HookDataDiff, error) {
+	beforeValue, err := unmarshalHookData(before)
+	if err != nil {
+		return nil, err
+	}
+	afterValue, err := unmarshalHookData(after)
+	if err != nil {
+		return nil, err
+	}
+	return &HookDataDiff{
+		Changes:     diffValues("", "", beforeValue, afterValue, make([]HookDataChange, 0)),
+		TotalBefore: getTotalAmount(beforeValue),
+		TotalAfter:  getTotalAmount(afterValue),
+	}, nil
+}
+
+// CheckHookDataDiff returns violations of the permissions, nil permissions allow everything
+func CheckHookDataDiff(diff *HookDataDiff, permissions *common.ExtensionPermissions) []string {
+	if permissions == nil {
+		return nil
+	}
+	violations := make([]string, 0)
+	for _, change := range diff.Changes {
+		switch {
+		case change.Kind == HOOK_DATA_CHANGE_REMOVED && !permissions.AllowRemove:
+			violations = append(violations, fmt.Sprintf("removing '%s' is not allowed", change.Path))
+		case change.Kind == HOOK_DATA_CHANGE_ADDED && !permissions.AllowAdd:
+			violations = append(violations, fmt.Sprintf("adding '%s' is not allowed", change.Path))
+		case change.Kind == HOOK_DATA_CHANGE_CHANGED && !slices.Contains(permissions.MutableFields, change.Field):
+			violations = append(violations, fmt.Sprintf("changing '%s' is not allowed", change.Path))
+		}
+	}
+	if !permissions.AllowTotalIncrease && diff.TotalAfter.Cmp(diff.TotalBefore) > 0 {
+		violations = append(violations, fmt.Sprintf("increasing total amount from %s to %s is not allowed", diff.TotalBefore, diff.TotalAfter))
+	}
+	return violations
+}
+
+type HookAuditEntry struct {
+	Timestamp  time.Time            `json:"timestamp"`
+	Extension  string               `json:"extension"`
+	Hook       enums.EExtensionHook `json:"hook"`
+	Accepted   bool                 `json:"accepted"`
+	Violations []string             `json:"violations,omitempty"`
+	*HookDataDiff
+}
+
+var auditMutex sync.Mutex
+
+// writeHookAudit appends entry to the audit file as single json line
+func writeHookAudit(file string, entry *HookAuditEntry) error {
+	if file == "" {
+		return nil
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	auditMutex.Lock()
+	defer auditMutex.Unlock()
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(line, '\n'))
+	return errors.Join(err, f.Close())
+}
+
+// sandboxHookResult enforces permissions of the extension on the hook result and records changes to the audit file
+func sandboxHookResult(def common.ExtensionDefinition, hook enums.EExtensionHook, before, after any) error {
+	diff, err := DiffHookData(before, after)
+	if err != nil {
+		return err
+	}
+	if diff.IsEmpty() {
+		return nil
+	}
+	violations := CheckHookDataDiff(diff, def.GetPermissions(hook))
+	entry := &HookAuditEntry{
+		Timestamp:    time.Now().UTC(),
+		Extension:    def.Name,
+		Hook:         hook,
+		Accepted:     len(violations) == 0,
+		Violations:   violations,
+		HookDataDiff: diff,
+	}
+	if extensionStore.environment != nil {
+		if err := writeHookAudit(extensionStore.environment.AuditFile, entry); err != nil {
+			return err
+		}
+	}
+	if len(violations) > 0 {
+		return errors.Join(constants.ErrExtensionPermissionDenied, errors.New(strings.Join(violations, "; ")))
+	}
+	return nil
+}
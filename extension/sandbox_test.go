@@ -0,0 +1,111 @@
+package extension
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type testHookItem struct {
+	Source    string  `json:"source"`
+	Recipient string  `json:"recipient"`
+	FeeRate   float64 `json:"fee_rate"`
+	Amount    string  `json:"amount"`
+}
+
+func TestDiffHookData(t *testing.T) {
+	assert := assert.New(t)
+
+	before := []testHookItem{{"tz1a", "tz1a", 0.05, "100"}, {"tz1b", "tz1b", 0.05, "200"}, {"tz1c", "tz1c", 0.05, "300"}}
+	after := []testHookItem{{"tz1c", "tz1c", 0.05, "300"}, {"tz1a", "tz1x", 0.1, "100"}, {"tz1d", "tz1d", 0.05, "500"}}
+
+	diff, err := DiffHookData(before, after)
+	assert.Nil(err)
+	kinds := make(map[string]EHookDataChangeKind)
+	for _, change := range diff.Changes {
+		kinds[change.Path] = change.Kind
+	}
+	// items are matched by source regardless of order
+	assert.Equal(map[string]EHookDataChangeKind{
+		"[source=tz1a].fee_rate":  HOOK_DATA_CHANGE_CHANGED,
+		"[source=tz1a].recipient": HOOK_DATA_CHANGE_CHANGED,
+		"[source=tz1b]":           HOOK_DATA_CHANGE_REMOVED,
+		"[source=tz1d]":           HOOK_DATA_CHANGE_ADDED,
+	}, kinds)
+	assert.Equal("600", diff.TotalBefore.String())
+	assert.Equal("900", diff.TotalAfter.String())
+
+	assert.Nil(CheckHookDataDiff(diff, nil))
+	violations := CheckHookDataDiff(diff, &common.ExtensionPermissions{AllowRemove: true, MutableFields: []string{"fee_rate"}})
+	assert.Len(violations, 3)
+	assert.Empty(CheckHookDataDiff(diff, &common.ExtensionPermissions{AllowRemove: true, AllowAdd: true, AllowTotalIncrease: true, MutableFields: []string{"fee_rate", "recipient"}}))
+}
+
+func TestDiffHookDataCycleData(t *testing.T) {
+	assert := assert.New(t)
+
+	delegator := tezos.MustParseAddress("tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM")
+	before := common.BakersCycleData{
+		BlockDelegatedRewards: tezos.NewZ(1000),
+		Delegators:            []common.Delegator{{Address: delegator, DelegatedBalance: tezos.NewZ(500)}},
+	}
+	after := before
+	after.BlockDelegatedRewards = tezos.NewZ(2000)
+	after.Delegators = []common.Delegator{{Address: delegator, DelegatedBalance: tezos.NewZ(600)}}
+
+	diff, err := DiffHookData(before, after)
+	assert.Nil(err)
+	paths := make([]string, 0, len(diff.Changes))
+	for _, change := range diff.Changes {
+		paths = append(paths, change.Path)
+	}
+	// delegators are matched by address
+	assert.ElementsMatch([]string{"block_delegated_rewards", "delegators[address=" + delegator.String() + "].delegated_balance"}, paths)
+	assert.Equal("1000", diff.TotalBefore.String())
+	assert.Equal("2000", diff.TotalAfter.String())
+
+	permissions := &common.ExtensionPermissions{MutableFields: []string{"block_delegated_rewards", "delegated_balance"}}
+	violations := CheckHookDataDiff(diff, permissions)
+	assert.Len(violations, 1)
+	assert.Contains(violations[0], "increasing total amount")
+}
+
+func TestSandboxHookResult(t *testing.T) {
+	assert := assert.New(t)
+
+	auditFile := path.Join(t.TempDir(), "audit.jsonl")
+	extensionStore = ExtensionStore{environment: &ExtensionStoreEnviromnent{AuditFile: auditFile}}
+	defer func() { extensionStore = ExtensionStore{} }()
+
+	def := common.ExtensionDefinition{
+		Name: "fee",
+		Permissions: map[enums.EExtensionHook]common.ExtensionPermissions{
+			enums.EXTENSION_HOOK_ALL: {MutableFields: []string{"fee_rate"}},
+		},
+	}
+	before := []testHookItem{{"tz1a", "tz1a", 0.05, "100"}}
+
+	assert.Nil(sandboxHookResult(def, enums.EXTENSION_HOOK_AFTER_CANDIDATES_GENERATED, before, before))
+	assert.Nil(sandboxHookResult(def, enums.EXTENSION_HOOK_AFTER_CANDIDATES_GENERATED, before, []testHookItem{{"tz1a", "tz1a", 0.1, "100"}}))
+	err := sandboxHookResult(def, enums.EXTENSION_HOOK_AFTER_CANDIDATES_GENERATED, before, []testHookItem{{"tz1a", "tz1x", 0.05, "100"}})
+	assert.True(errors.Is(err, constants.ErrExtensionPermissionDenied))
+
+	content, err := os.ReadFile(auditFile)
+	assert.Nil(err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(lines, 2)
+	var entry HookAuditEntry
+	assert.Nil(json.Unmarshal([]byte(lines[1]), &entry))
+	assert.False(entry.Accepted)
+	assert.Equal("fee", entry.Extension)
+	assert.Len(entry.Violations, 1)
+}
@@ -0,0 +1,77 @@
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// OwedLedger keeps recipes of delegators with deferred payouts until they are reported as paid
+type OwedLedger struct {
+	Recipes []common.PayoutRecipe `json:"recipes"`
+}
+
+func NewOwedLedger() *OwedLedger {
+	return &OwedLedger{
+		Recipes: make([]common.PayoutRecipe, 0),
+	}
+}
+
+// Load reads ledger from file, missing file is an empty ledger
+func Load(path string) (*OwedLedger, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewOwedLedger(), nil
+	}
+	if err != nil {
+		return nil, errors.Join(constants.ErrOwedLedgerLoadFailed, err)
+	}
+	ledger := NewOwedLedger()
+	if err := json.Unmarshal(data, ledger); err != nil {
+		return nil, errors.Join(constants.ErrOwedLedgerLoadFailed, err)
+	}
+	return ledger, nil
+}
+
+// Save writes through temporary file so the ledger is never left half written
+func (ledger *OwedLedger) Save(path string) error {
+	data, err := json.MarshalIndent(ledger, "", "\t")
+	if err != nil {
+		return errors.Join(constants.ErrOwedLedgerSaveFailed, err)
+	}
+	tmpFile := path + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return errors.Join(constants.ErrOwedLedgerSaveFailed, err)
+	}
+	if err := os.Rename(tmpFile, path); err != nil {
+		return errors.Join(constants.ErrOwedLedgerSaveFailed, err)
+	}
+	return nil
+}
+
+func GetRecipeKey(recipe *common.PayoutRecipe) string {
+	return fmt.Sprintf("%d_%s", recipe.Cycle, recipe.GetIdentifier())
+}
+
+// GetOwed returns recipes owed by the baker
+func (ledger *OwedLedger) GetOwed(baker tezos.Address) []common.PayoutRecipe {
+	return lo.Filter(ledger.Recipes, func(recipe common.PayoutRecipe, _ int) bool {
+		return recipe.Baker.Equal(baker)
+	})
+}
+
+// SetOwed replaces recipes owed by the baker, recipes of other bakers are kept
+func (ledger *OwedLedger) SetOwed(baker tezos.Address, recipes []common.PayoutRecipe) {
+	owed := lo.Filter(ledger.Recipes, func(recipe common.PayoutRecipe, _ int) bool {
+		return !recipe.Baker.Equal(baker)
+	})
+	ledger.Recipes = append(owed, lo.UniqBy(recipes, func(recipe common.PayoutRecipe) string {
+		return GetRecipeKey(&recipe)
+	})...)
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	BatchesFailed.WithLabelValues("a\"b").Inc()
+	BatchesFailed.WithLabelValues("c").Add(2)
+	LastCompletedCycle.Set(42)
+	ExtensionHookDuration.WithLabelValues("x", "ext").Observe(0.7)
+	ExtensionHookDuration.WithLabelValues("x", "ext").Observe(2)
+
+	app := fiber.New()
+	app.Get("/metrics", Handler)
+	response, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	assert.Nil(err)
+	assert.Equal(200, response.StatusCode)
+	body, _ := io.ReadAll(response.Body)
+	output := string(body)
+
+	assert.Contains(output, "# TYPE tezpay_batches_failed_total counter\n")
+	assert.Contains(output, `tezpay_batches_failed_total{reason="a\"b"} 1`+"\n")
+	assert.Contains(output, `tezpay_batches_failed_total{reason="c"} 2`+"\n")
+	assert.Contains(output, "tezpay_last_completed_cycle 42\n")
+	assert.Contains(output, `tezpay_extension_hook_duration_seconds_bucket{extension="ext",hook="x",le="0.5"} 0`+"\n")
+	assert.Contains(output, `tezpay_extension_hook_duration_seconds_bucket{extension="ext",hook="x",le="1"} 1`+"\n")
+	assert.Contains(output, `tezpay_extension_hook_duration_seconds_bucket{extension="ext",hook="x",le="+Inf"} 2`+"\n")
+	assert.Contains(output, `tezpay_extension_hook_duration_seconds_sum{extension="ext",hook="x"} 2.7`+"\n")
+	assert.Contains(output, "tezpay_batches_dispatched_total 0\n")
+	assert.NotContains(output, "go_goroutines")
+}
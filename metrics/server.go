@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var Handler = adaptor.HTTPHandler(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+
+func Listen(address string) *fiber.App {
+	app := fiber.New(fiber.Config{
+		DisableStartupMessage: true,
+	})
+	app.Get("/metrics", Handler)
+
+	go func() {
+		slog.Info("starting metrics server", "address", address)
+		if err := app.Listen(address); err != nil {
+			slog.Error("metrics server failed", "error", err.Error())
+		}
+	}()
+	return app
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Registry holds tezpay metrics only, go runtime and process collectors are not exposed
+var (
+	Registry = prometheus.NewRegistry()
+	factory  = promauto.With(Registry)
+)
+
+var (
+	BatchesDispatched = factory.NewCounter(prometheus.CounterOpts{
+		Name: "tezpay_batches_dispatched_total",
+		Help: "Number of payout batches broadcasted to the network.",
+	})
+	BatchesConfirmed = factory.NewCounter(prometheus.CounterOpts{
+		Name: "tezpay_batches_confirmed_total",
+		Help: "Number of payout batches confirmed (applied) on chain.",
+	})
+	BatchesFailed = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "tezpay_batches_failed_total",
+		Help: "Number of payout batches which failed to be created, broadcasted or confirmed.",
+	}, []string{"reason"})
+
+	PayoutWalletBalance = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tezpay_payout_wallet_balance_mutez",
+		Help: "Last observed balance of the payout wallet in mutez.",
+	}, []string{"address"})
+	LastProcessedCycle = factory.NewGauge(prometheus.GaugeOpts{
+		Name: "tezpay_last_processed_cycle",
+		Help: "Last cycle processed in continual mode.",
+	})
+	LastCompletedCycle = factory.NewGauge(prometheus.GaugeOpts{
+		Name: "tezpay_last_completed_cycle",
+		Help: "Last completed cycle observed on chain.",
+	})
+
+	ExtensionHookDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "tezpay_extension_hook_duration_seconds",
+		Help:    "Duration of extension hook execution.",
+		Buckets: []float64{.005, .01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"hook", "extension"})
+	ExtensionHookFailures = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "tezpay_extension_hook_failures_total",
+		Help: "Number of failed extension hook executions.",
+	}, []string{"hook", "extension"})
+
+	RpcFailures = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "tezpay_rpc_failures_total",
+		Help: "Number of failed requests per rpc endpoint.",
+	}, []string{"rpc"})
+)
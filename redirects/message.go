@@ -0,0 +1,68 @@
+package redirects
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/micheline"
+	"github.com/trilitech/tzgo/tezos"
+	"golang.org/x/crypto/blake2b"
+)
+
+const MESSAGE_PREFIX = "Tezos Signed Message: "
+
+// RedirectRequest is content of the message signed by the delegator
+type RedirectRequest struct {
+	Baker     tezos.Address `json:"baker"`
+	Delegator tezos.Address `json:"delegator"`
+	Recipient tezos.Address `json:"recipient"`
+	// chosen by the delegator, newer request replaces older ones and older ones can not be replayed
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (request *RedirectRequest) Validate() error {
+	switch {
+	case !request.Baker.IsValid():
+		return errors.Join(constants.ErrRedirectInvalid, errors.New("invalid baker address"))
+	case !request.Delegator.IsValid():
+		return errors.Join(constants.ErrRedirectInvalid, errors.New("invalid delegator address"))
+	case !request.Recipient.IsValid():
+		return errors.Join(constants.ErrRedirectInvalid, errors.New("invalid recipient address"))
+	case request.Timestamp.IsZero():
+		return errors.Join(constants.ErrRedirectInvalid, errors.New("missing timestamp"))
+	}
+	return nil
+}
+
+// GetMessage returns text the delegator signs, the same text has to be rebuilt from the request to verify the signature
+func (request *RedirectRequest) GetMessage() string {
+	return fmt.Sprintf("%stezpay redirect payouts of %s from baker %s to %s at %s",
+		MESSAGE_PREFIX,
+		request.Delegator.String(),
+		request.Baker.String(),
+		request.Recipient.String(),
+		request.Timestamp.UTC().Format(time.RFC3339),
+	)
+}
+
+// GetSigningBytes returns the message packed as michelson string, the way wallets sign off-chain messages
+func (request *RedirectRequest) GetSigningBytes() []byte {
+	return micheline.NewString(request.GetMessage()).Pack()
+}
+
+// Verify checks the signature was made by the delegator, key has to be the revealed key of the delegator
+func (request *RedirectRequest) Verify(key tezos.Key, signature tezos.Signature) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+	if !key.Address().Equal(request.Delegator) {
+		return errors.Join(constants.ErrRedirectKeyMismatch, fmt.Errorf("key %s belongs to %s", key.String(), key.Address().String()))
+	}
+	digest := blake2b.Sum256(request.GetSigningBytes())
+	if err := key.Verify(digest[:], signature); err != nil {
+		return errors.Join(constants.ErrRedirectSignatureInvalid, err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package redirects
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/tezos"
+	"golang.org/x/crypto/blake2b"
+)
+
+type Registration struct {
+	Id string `json:"id"`
+	RedirectRequest
+	PublicKey tezos.Key             `json:"public_key"`
+	Signature tezos.Signature       `json:"signature"`
+	Status    enums.ERedirectStatus `json:"status"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+type AuditEntry struct {
+	Timestamp      time.Time             `json:"timestamp"`
+	RegistrationId string                `json:"registration_id"`
+	Delegator      tezos.Address         `json:"delegator"`
+	Recipient      tezos.Address         `json:"recipient"`
+	Status         enums.ERedirectStatus `json:"status"`
+	Note           string                `json:"note,omitempty"`
+}
+
+// Registry keeps all registrations together with audit trail of their changes, nothing is ever removed
+type Registry struct {
+	Registrations []*Registration `json:"registrations"`
+	Audit         []AuditEntry    `json:"audit"`
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		Registrations: make([]*Registration, 0),
+		Audit:         make([]AuditEntry, 0),
+	}
+}
+
+// Load reads registry from file, missing file is an empty registry
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewRegistry(), nil
+	}
+	if err != nil {
+		return nil, errors.Join(constants.ErrRedirectRegistryLoadFailed, err)
+	}
+	registry := NewRegistry()
+	if err := json.Unmarshal(data, registry); err != nil {
+		return nil, errors.Join(constants.ErrRedirectRegistryLoadFailed, err)
+	}
+	return registry, nil
+}
+
+// Save writes through temporary file so the registry is never left half written
+func (registry *Registry) Save(path string) error {
+	data, err := json.MarshalIndent(registry, "", "\t")
+	if err != nil {
+		return errors.Join(constants.ErrRedirectRegistrySaveFailed, err)
+	}
+	tmpFile := path + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return errors.Join(constants.ErrRedirectRegistrySaveFailed, err)
+	}
+	if err := os.Rename(tmpFile, path); err != nil {
+		return errors.Join(constants.ErrRedirectRegistrySaveFailed, err)
+	}
+	return nil
+}
+
+func (registry *Registry) audit(registration *Registration, note string) {
+	registry.Audit = append(registry.Audit, AuditEntry{
+		Timestamp:      registration.UpdatedAt,
+		RegistrationId: registration.Id,
+		Delegator:      registration.Delegator,
+		Recipient:      registration.Recipient,
+		Status:         registration.Status,
+		Note:           note,
+	})
+}
+
+func (registry *Registry) setStatus(registration *Registration, status enums.ERedirectStatus, note string) {
+	registration.Status = status
+	registration.UpdatedAt = time.Now().UTC()
+	registry.audit(registration, note)
+}
+
+func (registry *Registry) Get(id string) (*Registration, error) {
+	registration, ok := lo.Find(registry.Registrations, func(r *Registration) bool { return r.Id == id })
+	if !ok {
+		return nil, errors.Join(constants.ErrRedirectNotFound, fmt.Errorf("id %s", id))
+	}
+	return registration, nil
+}
+
+func (registry *Registry) getLatest(baker, delegator tezos.Address) (*Registration, bool) {
+	for i := len(registry.Registrations) - 1; i >= 0; i-- {
+		if r := registry.Registrations[i]; r.Baker.Equal(baker) && r.Delegator.Equal(delegator) {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// Submit verifies the signed request and records it as pending registration
+func (registry *Registry) Submit(request RedirectRequest, key tezos.Key, signature tezos.Signature) (*Registration, error) {
+	if err := request.Verify(key, signature); err != nil {
+		return nil, err
+	}
+	if latest, ok := registry.getLatest(request.Baker, request.Delegator); ok && !request.Timestamp.After(latest.Timestamp) {
+		return nil, errors.Join(constants.ErrRedirectOutdated, fmt.Errorf("last request of %s is from %s", request.Delegator.String(), latest.Timestamp.Format(time.RFC3339)))
+	}
+
+	id := blake2b.Sum256(signature.Bytes())
+	now := time.Now().UTC()
+	registration := &Registration{
+		Id:              hex.EncodeToString(id[:])[:12],
+		RedirectRequest: request,
+		PublicKey:       key,
+		Signature:       signature,
+		Status:          enums.REDIRECT_STATUS_PENDING,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	registry.Registrations = append(registry.Registrations, registration)
+	registry.audit(registration, "submitted")
+	return registration, nil
+}
+
+// Approve activates pending registration, previously approved registration of the delegator is superseded
+func (registry *Registry) Approve(id string, note string) (*Registration, error) {
+	registration, err := registry.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if registration.Status != enums.REDIRECT_STATUS_PENDING {
+		return nil, errors.Join(constants.ErrRedirectInvalidStatus, fmt.Errorf("registration %s is %s", id, registration.Status))
+	}
+	if latest, _ := registry.getLatest(registration.Baker, registration.Delegator); latest != registration {
+		return nil, errors.Join(constants.ErrRedirectOutdated, fmt.Errorf("registration %s was replaced by %s", id, latest.Id))
+	}
+	for _, r := range registry.Registrations {
+		if r != registration && r.Status == enums.REDIRECT_STATUS_APPROVED && r.Baker.Equal(registration.Baker) && r.Delegator.Equal(registration.Delegator) {
+			registry.setStatus(r, enums.REDIRECT_STATUS_SUPERSEDED, fmt.Sprintf("superseded by %s", id))
+		}
+	}
+	registry.setStatus(registration, enums.REDIRECT_STATUS_APPROVED, note)
+	return registration, nil
+}
+
+// Revoke deactivates pending or approved registration
+func (registry *Registry) Revoke(id string, note string) (*Registration, error) {
+	registration, err := registry.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if registration.Status != enums.REDIRECT_STATUS_PENDING && registration.Status != enums.REDIRECT_STATUS_APPROVED {
+		return nil, errors.Join(constants.ErrRedirectInvalidStatus, fmt.Errorf("registration %s is %s", id, registration.Status))
+	}
+	registry.setStatus(registration, enums.REDIRECT_STATUS_REVOKED, note)
+	return registration, nil
+}
+
+// GetApprovedRedirects returns recipients of approved registrations of the baker keyed by delegator address
+func (registry *Registry) GetApprovedRedirects(baker tezos.Address) map[string]tezos.Address {
+	result := make(map[string]tezos.Address)
+	for _, registration := range registry.Registrations {
+		if registration.Status == enums.REDIRECT_STATUS_APPROVED && registration.Baker.Equal(baker) {
+			result[registration.Delegator.String()] = registration.Recipient
+		}
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+package redirects
+
+import (
+	"errors"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/tezos"
+	"golang.org/x/crypto/blake2b"
+)
+
+func generateKey(t *testing.T) tezos.PrivateKey {
+	key, err := tezos.GenerateKey(tezos.KeyTypeEd25519)
+	assert.Nil(t, err)
+	return key
+}
+
+func signRequest(t *testing.T, key tezos.PrivateKey, request RedirectRequest) tezos.Signature {
+	digest := blake2b.Sum256(request.GetSigningBytes())
+	signature, err := key.Sign(digest[:])
+	assert.Nil(t, err)
+	return signature
+}
+
+func TestRedirectRequestVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	delegatorKey := generateKey(t)
+	request := RedirectRequest{
+		Baker:     generateKey(t).Address(),
+		Delegator: delegatorKey.Address(),
+		Recipient: generateKey(t).Address(),
+		Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	assert.Equal("Tezos Signed Message: tezpay redirect payouts of "+request.Delegator.String()+" from baker "+request.Baker.String()+" to "+request.Recipient.String()+" at 2024-01-01T00:00:00Z", request.GetMessage())
+	// packed michelson string
+	assert.Equal([]byte{0x05, 0x01}, request.GetSigningBytes()[:2])
+
+	signature := signRequest(t, delegatorKey, request)
+	assert.Nil(request.Verify(delegatorKey.Public(), signature))
+
+	// signed by other key
+	otherKey := generateKey(t)
+	assert.True(errors.Is(request.Verify(otherKey.Public(), signRequest(t, otherKey, request)), constants.ErrRedirectKeyMismatch))
+
+	// signed different recipient
+	tampered := request
+	tampered.Recipient = generateKey(t).Address()
+	assert.True(errors.Is(tampered.Verify(delegatorKey.Public(), signature), constants.ErrRedirectSignatureInvalid))
+}
+
+func TestRegistry(t *testing.T) {
+	assert := assert.New(t)
+
+	baker := generateKey(t).Address()
+	delegatorKey := generateKey(t)
+	submit := func(registry *Registry, recipient tezos.Address, timestamp time.Time) (*Registration, error) {
+		request := RedirectRequest{Baker: baker, Delegator: delegatorKey.Address(), Recipient: recipient, Timestamp: timestamp}
+		return registry.Submit(request, delegatorKey.Public(), signRequest(t, delegatorKey, request))
+	}
+
+	registry := NewRegistry()
+	first, second := generateKey(t).Address(), generateKey(t).Address()
+	registration, err := submit(registry, first, time.Unix(1000, 0))
+	assert.Nil(err)
+	assert.Equal(enums.REDIRECT_STATUS_PENDING, registration.Status)
+	assert.Empty(registry.GetApprovedRedirects(baker))
+
+	_, err = registry.Approve(registration.Id, "")
+	assert.Nil(err)
+	assert.Equal(map[string]tezos.Address{delegatorKey.Address().String(): first}, registry.GetApprovedRedirects(baker))
+	assert.Empty(registry.GetApprovedRedirects(generateKey(t).Address()))
+
+	// older request can not be replayed
+	_, err = submit(registry, second, time.Unix(1000, 0))
+	assert.True(errors.Is(err, constants.ErrRedirectOutdated))
+
+	// approval of newer request supersedes the previous one
+	newer, err := submit(registry, second, time.Unix(2000, 0))
+	assert.Nil(err)
+	assert.Equal(first, registry.GetApprovedRedirects(baker)[delegatorKey.Address().String()])
+	_, err = registry.Approve(newer.Id, "requested by email")
+	assert.Nil(err)
+	assert.Equal(enums.REDIRECT_STATUS_SUPERSEDED, registration.Status)
+	assert.Equal(second, registry.GetApprovedRedirects(baker)[delegatorKey.Address().String()])
+
+	_, err = registry.Approve(newer.Id, "")
+	assert.True(errors.Is(err, constants.ErrRedirectInvalidStatus))
+	_, err = registry.Revoke(newer.Id, "")
+	assert.Nil(err)
+	assert.Empty(registry.GetApprovedRedirects(baker))
+	_, err = registry.Revoke("unknown", "")
+	assert.True(errors.Is(err, constants.ErrRedirectNotFound))
+
+	assert.Equal([]enums.ERedirectStatus{
+		enums.REDIRECT_STATUS_PENDING,
+		enums.REDIRECT_STATUS_APPROVED,
+		enums.REDIRECT_STATUS_PENDING,
+		enums.REDIRECT_STATUS_SUPERSEDED,
+		enums.REDIRECT_STATUS_APPROVED,
+		enums.REDIRECT_STATUS_REVOKED,
+	}, lo.Map(registry.Audit, func(entry AuditEntry, _ int) enums.ERedirectStatus { return entry.Status }))
+
+	file := path.Join(t.TempDir(), "redirects.json")
+	assert.Nil(registry.Save(file))
+	loaded, err := Load(file)
+	assert.Nil(err)
+	assert.Equal(registry.Audit[4].Note, loaded.Audit[4].Note)
+	assert.Len(loaded.Registrations, 2)
+	assert.True(loaded.Registrations[1].PublicKey.IsEqual(delegatorKey.Public()))
+	assert.Nil(loaded.Registrations[1].Verify(loaded.Registrations[1].PublicKey, loaded.Registrations[1].Signature))
+
+	missing, err := Load(path.Join(t.TempDir(), "missing.json"))
+	assert.Nil(err)
+	assert.Empty(missing.Registrations)
+}
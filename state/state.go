@@ -11,10 +11,15 @@ import (
 )
 
 var (
-	Global                 *State
-	CONFIG_FILE_NAME       = "config.hjson"
-	PRIVATE_KEY_FILE_NAME  = "payout_wallet_private.key"
-	REMOTE_SPECS_FILE_NAME = "remote_signer.hjson"
+	Global                      *State
+	CONFIG_FILE_NAME            = "config.hjson"
+	PRIVATE_KEY_FILE_NAME       = "payout_wallet_private.key"
+	REMOTE_SPECS_FILE_NAME      = "remote_signer.hjson"
+	STDIO_SPECS_FILE_NAME       = "stdio_signer.hjson"
+	REDIRECTS_FILE_NAME         = "redirects.json"
+	OWED_LEDGER_FILE_NAME       = "owed.json"
+	EXTENSION_AUDIT_FILE_NAME   = "extensions_audit.jsonl"
+	EXTENSION_CAPTURE_DIRECTORY = "extension_captures"
 )
 
 type StateInitOptions struct {
@@ -116,6 +121,46 @@ func (state *State) GetRemoteSpecsFilePath() string {
 	return path.Join(state.GetWorkingDirectory(), REMOTE_SPECS_FILE_NAME)
 }
 
+func (state *State) GetStdioSignerSpecsFilePath() string {
+	stdioSpecsConfigurationFile := os.Getenv("STDIO_SIGNER_CONFIGURATION_FILE")
+	if stdioSpecsConfigurationFile != "" {
+		return stdioSpecsConfigurationFile
+	}
+	return path.Join(state.GetWorkingDirectory(), STDIO_SPECS_FILE_NAME)
+}
+
+func (state *State) GetRedirectRegistryFilePath() string {
+	redirectRegistryFile := os.Getenv("REDIRECT_REGISTRY_FILE")
+	if redirectRegistryFile != "" {
+		return redirectRegistryFile
+	}
+	return path.Join(state.GetWorkingDirectory(), REDIRECTS_FILE_NAME)
+}
+
+func (state *State) GetOwedLedgerFilePath() string {
+	owedLedgerFile := os.Getenv("OWED_LEDGER_FILE")
+	if owedLedgerFile != "" {
+		return owedLedgerFile
+	}
+	return path.Join(state.GetWorkingDirectory(), OWED_LEDGER_FILE_NAME)
+}
+
+func (state *State) GetExtensionAuditFilePath() string {
+	extensionAuditFile := os.Getenv("EXTENSION_AUDIT_FILE")
+	if extensionAuditFile != "" {
+		return extensionAuditFile
+	}
+	return path.Join(state.GetWorkingDirectory(), EXTENSION_AUDIT_FILE_NAME)
+}
+
+func (state *State) GetExtensionCaptureDirectory() string {
+	extensionCaptureDirectory := os.Getenv("EXTENSION_CAPTURE_DIRECTORY")
+	if extensionCaptureDirectory != "" {
+		return extensionCaptureDirectory
+	}
+	return path.Join(state.GetWorkingDirectory(), EXTENSION_CAPTURE_DIRECTORY)
+}
+
 func (state *State) GetPayOnlyAddressPrefix() string {
 	return state.payOnlyAddressPrefix
 }
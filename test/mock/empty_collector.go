@@ -24,6 +24,10 @@ func (engine *EmptyCollector) IsRevealed(address tezos.Address) (bool, error) {
 	panic("not implemented")
 }
 
+func (engine *EmptyCollector) GetManagerKey(address tezos.Address) (tezos.Key, error) {
+	panic("not implemented")
+}
+
 func (engine *EmptyCollector) GetCurrentCycleNumber() (int64, error) {
 	panic("not implemented")
 }
@@ -44,6 +48,14 @@ func (engine *EmptyCollector) WasOperationApplied(op tezos.OpHash) (common.Opera
 	panic("not implemented")
 }
 
+func (engine *EmptyCollector) GetTransactions(source tezos.Address, target tezos.Address, since time.Time, until time.Time) ([]common.TransactionInfo, error) {
+	panic("not implemented")
+}
+
+func (engine *EmptyCollector) GetDelegationStartCycles(baker tezos.Address) (map[string]int64, error) {
+	panic("not implemented")
+}
+
 func (engine *EmptyCollector) CreateCycleMonitor(options common.CycleMonitorOptions) (common.CycleMonitor, error) {
 	panic("not implemented")
 }
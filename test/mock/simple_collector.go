@@ -55,6 +55,10 @@ func (engine *SimpleColletor) IsRevealed(address tezos.Address) (bool, error) {
 	return true, nil
 }
 
+func (engine *SimpleColletor) GetManagerKey(address tezos.Address) (tezos.Key, error) {
+	return tezos.InvalidKey, nil
+}
+
 func (engine *SimpleColletor) GetOpts() *SimpleCollectorOpts {
 	return engine.opts
 }
@@ -91,6 +95,14 @@ func (engine *SimpleColletor) WasOperationApplied(op tezos.OpHash) (common.Opera
 	return common.OPERATION_STATUS_APPLIED, nil
 }
 
+func (engine *SimpleColletor) GetTransactions(source tezos.Address, target tezos.Address, since time.Time, until time.Time) ([]common.TransactionInfo, error) {
+	return []common.TransactionInfo{}, nil
+}
+
+func (engine *SimpleColletor) GetDelegationStartCycles(baker tezos.Address) (map[string]int64, error) {
+	return map[string]int64{}, nil
+}
+
 func (engine *SimpleColletor) CreateCycleMonitor(options common.CycleMonitorOptions) (common.CycleMonitor, error) {
 	return nil, constants.ErrNotImplemented
 }
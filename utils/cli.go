@@ -6,12 +6,15 @@ import (
 	"os"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/jedib0t/go-pretty/v6/text"
 	"github.com/samber/lo"
 	"github.com/tez-capital/tezpay/common"
 	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/extension"
+	"github.com/tez-capital/tezpay/redirects"
 	"github.com/trilitech/tzgo/tezos"
 )
 
@@ -20,6 +23,7 @@ const (
 	NOT_PAID          = "Invalid Not Paid"
 	TO_PAY            = "To Pay"
 	PAID_IN_PAST      = "Paid in Past"
+	HELD              = "Held"
 )
 
 func getColumnsByIndexes[T any](row []T, indexes []int) []T {
@@ -164,6 +168,7 @@ func PrintPreparePayoutsResult(preparationResult *common.PreparePayoutsResult, o
 	alreadyPaidHeader := fmt.Sprintf("Already Paid - %s", cyclesAsString)
 	invalidHeader := fmt.Sprintf("Invalid - %s", cyclesAsString)
 	validHeader := fmt.Sprintf("To Pay - %s", cyclesAsString)
+	heldHeader := fmt.Sprintf("Held Until Reconciled ('tezpay reconcile') - %s", cyclesAsString)
 
 	payoutTable := table.NewWriter()
 	payoutTable.SetStyle(table.StyleLight)
@@ -202,8 +207,10 @@ func PrintPreparePayoutsResult(preparationResult *common.PreparePayoutsResult, o
 		payoutTable.AppendRow(fillRow("No invalid payouts", headers), table.RowConfig{AutoMerge: true})
 	}
 
-	// already paid
-	reportsOfPastSuccessfulPayouts := preparationResult.ReportsOfPastSuccessfulPayouts
+	// already paid, held payouts are listed separately
+	reportsOfPastSuccessfulPayouts := lo.Filter(preparationResult.ReportsOfPastSuccessfulPayouts, func(report common.PayoutReport, _ int) bool {
+		return !report.IsInFlight()
+	})
 	if len(reportsOfPastSuccessfulPayouts) > 0 {
 		SortPayouts(reportsOfPastSuccessfulPayouts)
 
@@ -229,6 +236,27 @@ func PrintPreparePayoutsResult(preparationResult *common.PreparePayoutsResult, o
 		payoutTable.AppendRow(columnsAsInterfaces(getColumnsByIndexes(totals, validIndexes)), table.RowConfig{AutoMerge: true})
 	}
 
+	// held
+	heldPayouts := preparationResult.HeldPayouts
+	if len(heldPayouts) > 0 {
+		SortPayouts(heldPayouts)
+
+		payoutTable.AppendSeparator()
+		payoutTable.AppendRow(fillRow(heldHeader, headers), table.RowConfig{AutoMerge: true})
+		payoutTable.AppendSeparator()
+		payoutTable.AppendRow(columnsAsInterfaces(headers), table.RowConfig{AutoMerge: true})
+		payoutTable.AppendSeparator()
+		for _, rep := range heldPayouts {
+			row := replaceZeroFields(rep.ToTableRowData(), "-", false)
+			payoutTable.AppendRow(columnsAsInterfaces(getColumnsByIndexes(row, validIndexes)), table.RowConfig{AutoMerge: false})
+		}
+
+		payoutTable.AppendSeparator()
+		totals := replaceZeroFields(common.GetReportsTotals(heldPayouts, false), fmt.Sprintf("%s (%d)", HELD, len(heldPayouts)), true)
+		totals = replaceZeroFields(totals, "-", false)
+		payoutTable.AppendRow(columnsAsInterfaces(getColumnsByIndexes(totals, validIndexes)), table.RowConfig{AutoMerge: true})
+	}
+
 	payoutTable.AppendSeparator()
 	payoutTable.AppendRow(fillRow(validHeader, headers), table.RowConfig{AutoMerge: true})
 	payoutTable.AppendSeparator()
@@ -307,6 +335,12 @@ func PrintCycleSummary(summary common.PayoutSummary, header string) {
 	summaryTable.AppendRow(table.Row{"Distributed Delegator Rewards", replaceZeroValue(common.MutezToTezS(summary.DistributedRewards.Int64()), "-")}, table.RowConfig{AutoMerge: false})
 	summaryTable.AppendRow(table.Row{"NOT Distributed Delegator Rewards", replaceZeroValue(common.MutezToTezS(summary.NotDistributedRewards.Int64()), "-")}, table.RowConfig{AutoMerge: false})
 	summaryTable.AppendSeparator()
+	summaryTable.AppendRow(table.Row{"Staking Rewards Paid By Protocol", replaceZeroValue(common.MutezToTezS(summary.StakingRewardsShared.Int64()), "-")}, table.RowConfig{AutoMerge: false})
+	summaryTable.AppendRow(table.Row{"Staking Rewards Edge", replaceZeroValue(common.MutezToTezS(summary.StakingRewardsEdge.Int64()), "-")}, table.RowConfig{AutoMerge: false})
+	summaryTable.AppendRow(table.Row{"Distributed Staking Rewards", replaceZeroValue(common.MutezToTezS(summary.DistributedStakingRewards.Int64()), "-")}, table.RowConfig{AutoMerge: false})
+	summaryTable.AppendRow(table.Row{"NOT Distributed Staking Rewards", replaceZeroValue(common.MutezToTezS(summary.NotDistributedStakingRewards.Int64()), "-")}, table.RowConfig{AutoMerge: false})
+	summaryTable.AppendRow(table.Row{"Staking Fee Income", replaceZeroValue(common.MutezToTezS(summary.StakingFeeIncome.Int64()), "-")}, table.RowConfig{AutoMerge: false})
+	summaryTable.AppendSeparator()
 	summaryTable.AppendRow(table.Row{"Donated Bonds", replaceZeroValue(common.MutezToTezS(summary.DonatedBonds.Int64()), "-")}, table.RowConfig{AutoMerge: false})
 	summaryTable.AppendRow(table.Row{"Donated Fees", replaceZeroValue(common.MutezToTezS(summary.DonatedFees.Int64()), "-")}, table.RowConfig{AutoMerge: false})
 	summaryTable.AppendRow(table.Row{"Donated Total", replaceZeroValue(common.MutezToTezS(summary.DonatedTotal.Int64()), "-")}, table.RowConfig{AutoMerge: false})
@@ -339,3 +373,130 @@ func PrintBatchResults(results []*common.BatchResult, header string, explorerUrl
 	}
 	resultsTable.Render()
 }
+
+func PrintRedirectRegistrations(registrations []*redirects.Registration, header string) {
+	if len(registrations) == 0 {
+		return
+	}
+	registrationsTable := table.NewWriter()
+	registrationsTable.SetStyle(table.StyleLight)
+	registrationsTable.SetOutputMirror(os.Stdout)
+	registrationsTable.SetTitle(header)
+	registrationsTable.Style().Title.Align = text.AlignCenter
+	registrationsTable.AppendHeader(table.Row{"Id", "Delegator", "Recipient", "Status", "Requested At", "Updated At"}, table.RowConfig{AutoMerge: true})
+	for _, registration := range registrations {
+		registrationsTable.AppendRow(table.Row{
+			registration.Id,
+			registration.Delegator.String(),
+			registration.Recipient.String(),
+			registration.Status,
+			registration.Timestamp.Format(time.RFC3339),
+			registration.UpdatedAt.Format(time.RFC3339),
+		}, table.RowConfig{AutoMerge: false})
+	}
+	registrationsTable.Render()
+}
+
+func PrintReconciledPayouts(reconciled []common.ReconciledPayout, header string) {
+	if len(reconciled) == 0 {
+		return
+	}
+	reconcileTable := table.NewWriter()
+	reconcileTable.SetStyle(table.StyleLight)
+	reconcileTable.SetOutputMirror(os.Stdout)
+	reconcileTable.SetTitle(header)
+	reconcileTable.Style().Title.Align = text.AlignCenter
+	reconcileTable.AppendHeader(table.Row{"Delegator", "Recipient", "Kind", "Amount", "Success", "Status", "OpHash", "Note"}, table.RowConfig{AutoMerge: true})
+	for _, payout := range reconciled {
+		before, after := payout.Original, payout.Reconciled
+		note := after.Note
+		if payout.Reason != "" {
+			note = payout.Reason
+		}
+		opHash := "-"
+		if !after.OpHash.Equal(tezos.ZeroOpHash) {
+			opHash = after.OpHash.String()
+		}
+		reconcileTable.AppendRow(table.Row{
+			common.ShortenAddress(before.Delegator),
+			common.ShortenAddress(before.Recipient),
+			before.Kind,
+			common.MutezToTezS(before.Amount.Int64()),
+			fmt.Sprintf("%t -> %t", before.IsSuccess, after.IsSuccess),
+			payout.Status,
+			opHash,
+			note,
+		}, table.RowConfig{AutoMerge: false})
+	}
+	reconcileTable.Render()
+}
+
+func PrintExtensions(definitions []common.ExtensionDefinition, header string) {
+	if len(definitions) == 0 {
+		return
+	}
+	extensionsTable := table.NewWriter()
+	extensionsTable.SetStyle(table.StyleLight)
+	extensionsTable.SetOutputMirror(os.Stdout)
+	extensionsTable.SetTitle(header)
+	extensionsTable.Style().Title.Align = text.AlignCenter
+	extensionsTable.AppendHeader(table.Row{"Name", "Kind", "Command/Url", "Hooks", "Lifespan", "Error Action"}, table.RowConfig{AutoMerge: true})
+	for _, def := range definitions {
+		target := def.Url
+		if def.Kind == enums.EXTENSION_STDIO_RPC {
+			target = strings.Join(append([]string{def.Command}, def.Args...), " ")
+		}
+		hooks := lo.Map(def.Hooks, func(hook common.ExtensionHook, _ int) string {
+			return fmt.Sprintf("%s (%s)", hook.Id, hook.Mode)
+		})
+		errorAction := def.ErrorAction
+		if errorAction == "" {
+			errorAction = enums.EXTENSION_ERROR_ACTION_STOP
+		}
+		extensionsTable.AppendRow(table.Row{def.Name, def.Kind, target, strings.Join(hooks, "\n"), def.GetLifespan(), errorAction}, table.RowConfig{AutoMerge: false})
+	}
+	extensionsTable.Render()
+}
+
+func PrintExtensionsHealth(results []extension.ExtensionHealth, header string) {
+	if len(results) == 0 {
+		return
+	}
+	healthTable := table.NewWriter()
+	healthTable.SetStyle(table.StyleLight)
+	healthTable.SetOutputMirror(os.Stdout)
+	healthTable.SetTitle(header)
+	healthTable.Style().Title.Align = text.AlignCenter
+	healthTable.AppendHeader(table.Row{"Name", "Kind", "Healthy", "Error"}, table.RowConfig{AutoMerge: true})
+	for _, result := range results {
+		message := ""
+		if result.Err != nil {
+			message = result.Err.Error()
+		}
+		healthTable.AppendRow(table.Row{result.Definition.Name, result.Definition.Kind, result.Err == nil, message}, table.RowConfig{AutoMerge: false})
+	}
+	healthTable.Render()
+}
+
+func PrintHookDataChanges(diff *extension.HookDataDiff, header string) {
+	if diff == nil || diff.IsEmpty() {
+		return
+	}
+	changesTable := table.NewWriter()
+	changesTable.SetStyle(table.StyleLight)
+	changesTable.SetOutputMirror(os.Stdout)
+	changesTable.SetTitle(header)
+	changesTable.Style().Title.Align = text.AlignCenter
+	changesTable.AppendHeader(table.Row{"Path", "Change", "Before", "After"}, table.RowConfig{AutoMerge: true})
+	format := func(value any) string {
+		if value == nil {
+			return "-"
+		}
+		return fmt.Sprint(value)
+	}
+	for _, change := range diff.Changes {
+		changesTable.AppendRow(table.Row{change.Path, change.Kind, format(change.Before), format(change.After)}, table.RowConfig{AutoMerge: false})
+	}
+	changesTable.AppendFooter(table.Row{"Total Amount", "", diff.TotalBefore.String(), diff.TotalAfter.String()}, table.RowConfig{AutoMerge: false})
+	changesTable.Render()
+}
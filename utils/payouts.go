@@ -121,6 +121,12 @@ func FilterReportsByBaker(payouts []common.PayoutReport, t tezos.Address) []comm
 	})
 }
 
+func RejectReportsByBaker(payouts []common.PayoutReport, t tezos.Address) []common.PayoutReport {
+	return lo.Filter(payouts, func(payout common.PayoutReport, _ int) bool {
+		return !payout.Baker.Equal(t)
+	})
+}
+
 func FilterReportsByCycle(payouts []common.PayoutReport, cycle int64) []common.PayoutReport {
 	return lo.Filter(payouts, func(payout common.PayoutReport, _ int) bool {
 		return payout.Cycle == cycle
@@ -135,8 +141,10 @@ type payoutId struct {
 	address  string
 }
 
-func FilterRecipesByReports(payouts []common.PayoutRecipe, reports []common.PayoutReport, collector common.CollectorEngine) ([]common.PayoutRecipe, []common.PayoutReport) {
+// FilterRecipesByReports returns recipes not paid yet, reports of past payouts and reports of payouts held until reconciled
+func FilterRecipesByReports(payouts []common.PayoutRecipe, reports []common.PayoutReport, collector common.CollectorEngine) ([]common.PayoutRecipe, []common.PayoutReport, []common.PayoutReport) {
 	paidOut := make(map[string]common.PayoutReport)
+	held := make([]common.PayoutReport, 0)
 	validOpHashes := make(map[string]bool)
 	if collector == nil {
 		slog.Debug("collector undefined filtering payout recipes only by succcess status from reports")
@@ -167,6 +175,15 @@ func FilterRecipesByReports(payouts []common.PayoutRecipe, reports []common.Payo
 		if report.IsSuccess {
 			paidOut[payoutId] = report
 			validOpHashes[report.OpHash.String()] = true
+			continue
+		}
+
+		// outcome unknown, we hold the payout until reconciled to avoid paying twice
+		// the report is kept within past payouts so it is not dropped when reports are written again
+		if report.IsInFlight() {
+			slog.Debug("payout was recorded before execution but never settled, holding until reconciled", "cycle", report.Cycle, "recipient", report.Recipient.String(), "kind", report.Kind)
+			paidOut[payoutId] = report
+			held = append(held, report)
 		}
 	}
 
@@ -174,7 +191,7 @@ func FilterRecipesByReports(payouts []common.PayoutRecipe, reports []common.Payo
 		payoutId := payout.ToPayoutReport().GetDestinationIdentifier()
 		_, ok := paidOut[payoutId]
 		return !ok
-	}), lo.Values(paidOut)
+	}), lo.Values(paidOut), held
 }
 
 func GeneratePayoutSummary(blueprints []*common.CyclePayoutBlueprint, reports []common.PayoutReport) (summary *common.PayoutSummary) {
@@ -204,6 +221,9 @@ func GeneratePayoutSummary(blueprints []*common.CyclePayoutBlueprint, reports []
 			DonatedBonds:             blueprint.DonatedBonds,
 			DonatedFees:              blueprint.DonatedFees,
 			DonatedTotal:             blueprint.DonatedTotal,
+			StakingRewardsEdge:       blueprint.StakingRewardsEdge,
+			StakingRewardsShared:     blueprint.StakingRewardsShared,
+			StakingFeeIncome:         blueprint.StakingFeeIncome,
 			Timestamp:                time.Now(),
 		}
 		cycleDelegators := make(map[string]struct{}, len(cycleReports))
@@ -222,6 +242,15 @@ func GeneratePayoutSummary(blueprints []*common.CyclePayoutBlueprint, reports []
 				} else {
 					cycleSummary.NotDistributedRewards = cycleSummary.NotDistributedRewards.Add(report.Amount)
 				}
+			case enums.PAYOUT_KIND_STAKING_REWARD:
+				if report.IsSuccess {
+					cycleSummary.DistributedStakingRewards = cycleSummary.DistributedStakingRewards.Add(report.Amount)
+					cycleSummary.TxFeesPaid = cycleSummary.TxFeesPaid.Add64(report.TxFee)
+					cycleSummary.TxFeesPaidForRewards = cycleSummary.TxFeesPaidForRewards.Add64(report.TxFee)
+					cyclePaidDelegators[report.Delegator.String()] = struct{}{}
+				} else {
+					cycleSummary.NotDistributedStakingRewards = cycleSummary.NotDistributedStakingRewards.Add(report.Amount)
+				}
 			default:
 				if report.IsSuccess {
 					cycleSummary.TxFeesPaid = cycleSummary.TxFeesPaid.Add64(report.TxFee)
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func TestFilterRecipesByReportsHoldsInFlightPayouts(t *testing.T) {
+	assert := assert.New(t)
+
+	paid := tezos.MustParseAddress("tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM")
+	inFlight := tezos.MustParseAddress("tz1hZvgjekGo7DmQjWh7XnY5eLQD8wNYPczE")
+	failed := tezos.MustParseAddress("tz1bDXD6nNSrebqmAnnKKwnX1QdePSMCj4MX")
+	unreported := tezos.MustParseAddress("tz1UGkfyrT9yBt6U5PV7Qeui3pt3a8jffoWv")
+
+	recipe := func(recipient tezos.Address) common.PayoutRecipe {
+		return common.PayoutRecipe{
+			Cycle:     10,
+			Delegator: recipient,
+			Recipient: recipient,
+			Kind:      enums.PAYOUT_KIND_DELEGATOR_REWARD,
+			TxKind:    enums.PAYOUT_TX_KIND_TEZ,
+			Amount:    tezos.NewZ(100),
+			IsValid:   true,
+		}
+	}
+	recipes := []common.PayoutRecipe{recipe(paid), recipe(inFlight), recipe(failed), recipe(unreported)}
+
+	paidReport := recipe(paid).ToPayoutReport()
+	paidReport.IsSuccess = true
+	inFlightReport := recipe(inFlight).ToPayoutReport()
+	inFlightReport.Note = constants.ErrPayoutRecordedBeforeExecution.Error()
+	failedReport := recipe(failed).ToPayoutReport()
+	failedReport.Note = "failed"
+
+	unpaid, reports, held := FilterRecipesByReports(recipes, []common.PayoutReport{paidReport, inFlightReport, failedReport}, nil)
+	assert.Equal([]tezos.Address{failed, unreported}, []tezos.Address{unpaid[0].Recipient, unpaid[1].Recipient})
+	assert.Len(unpaid, 2)
+	// held report is kept within past reports to be written again
+	assert.Len(reports, 2)
+	assert.Len(held, 1)
+	assert.Equal(inFlight, held[0].Recipient)
+	assert.True(held[0].IsInFlight())
+}
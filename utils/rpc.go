@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/metrics"
 	"github.com/trilitech/tzgo/rpc"
 )
 
@@ -78,6 +79,7 @@ func AttemptWithRpcClients[T any](ctx context.Context, clients []*rpc.Client, f
 
 		result, err = f(client)
 		if err != nil {
+			metrics.RpcFailures.WithLabelValues(client.BaseURL.Host).Inc()
 			continue
 		}
 		return result, nil